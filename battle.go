@@ -1,166 +1,103 @@
 package overpower
 
-import (
-//	"mule/hexagon"
-)
-
-func Battle(source Source, pl PlanetDat, sh ShipDat, turn int, truces map[[2]int]TruceDat) {
-	// if truce is broken (by trucee):
-	// delete(truces, [2]int{trucer, trucee}
-	// source.DropTrouce(pl.Loc, trucer, trucee)
-	prFid := pl.PrimaryFaction()
-	prPr := pl.PrimaryPresence()
-	prPW := pl.PrimaryPower()
-	seFid := pl.SecondaryFaction()
-	sePr := pl.SecondaryPresence()
-	sePW := pl.SecondaryPower()
-	var shFid, shSize int
+func Battle(source Source, rules GameRules, pl PlanetDat, sh ShipDat, turn int, truces map[[2]int]TruceDat) {
+	var fleet Fleet
 	if sh != nil {
-		shFid = sh.FID()
-		shSize = sh.Size()
-		if shSize < 1 {
+		fleet = Fleet{sh.FID(), sh.Size(), sh.Power()}
+		if fleet.Size < 1 {
 			return
 		}
 	}
-	defer AllSee(source, pl, prFid, seFid, shFid, turn)
-	var betrayals [][2]int
-	defer AllBattleRecords(source, sh, pl, turn, prFid, prPr, seFid, sePr, &betrayals)
-	// ------ GROUND FIGHT ---------- //
-	peace := func(fid1, fid2 int) bool {
-		return truces[[2]int{fid1, fid2}] != nil
-	}
-	betrayedF := func(truster, betrayor int) {
-		pt := [2]int{truster, betrayor}
-		tr := truces[pt]
-		delete(truces, pt)
-		tr.DELETE()
-		betrayals = append(betrayals, [2]int{betrayor, truster})
+	set := make(TrustSet, len(truces))
+	for pair, _ := range truces {
+		set[pair] = true
 	}
-	if prFid != 0 && seFid != 0 {
-		prPeace := peace(prFid, seFid)
-		sePeace := peace(seFid, prFid)
-		if shFid != 0 && prPeace && sePeace &&
-			peace(shFid, seFid) && peace(seFid, shFid) &&
-			peace(prFid, shFid) && peace(shFid, prFid) {
+	start := PlanetCombatState(pl)
+	res := ResolveLanding(rules, start, fleet, set)
+	applyCombat(pl, truces, res)
+	AllBattleRecords(source, sh, pl, turn, res)
+	AllSee(source, pl, start.PrimaryFaction, start.SecondaryFaction, fleet.FID, turn)
+}
 
-			prPeace = false
-			sePeace = false
-			for _, pair := range [][2]int{
-				[2]int{seFid, shFid}, [2]int{shFid, seFid},
-				[2]int{prFid, shFid}, [2]int{shFid, prFid},
-				[2]int{prFid, seFid}, [2]int{seFid, prFid},
-			} {
-				betrayedF(pair[0], pair[1])
-			}
-			if prPr > 0 {
-				prPr -= 1
-			}
-			if sePr > 0 {
-				sePr -= 1
-			}
-			shSize -= 1
-		}
-		if !prPeace || !sePeace {
-			if prPeace && !sePeace {
-				betrayedF(prFid, seFid)
-				if prPr > 0 {
-					prPr -= 1
-				}
-			} else if sePeace && !prPeace {
-				betrayedF(seFid, prFid)
-				if sePr > 0 {
-					sePr -= 1
-				}
-			}
-			prPr, sePr = prPr-sePr, sePr-prPr
-			if sePr > 0 {
-				prPr = sePr
-				prFid = seFid
-				prPW = sePW
-			}
-			seFid = 0
-			sePr = 0
-			sePW = 0
-		}
+// BattleAll lands every ship reaching a planet at the same step together,
+// as by ResolveArrival.  Each faction involved gets one battle record,
+// with its own first ship as the lander if it sent any and no lander if
+// it only held the planet.
+func BattleAll(source Source, rules GameRules, pl PlanetDat, ships []ShipDat, turn int, truces map[[2]int]TruceDat) {
+	if len(ships) == 1 {
+		Battle(source, rules, pl, ships[0], turn, truces)
+		return
 	}
-	if shFid != 0 {
-		prShPeace := peace(prFid, shFid)
-		seShPeace := peace(seFid, shFid)
-		shSePeace := peace(shFid, seFid)
-		shPrPeace := peace(shFid, prFid)
-		if prShPeace && !shPrPeace {
-			betrayedF(prFid, shFid)
-			if prPr > 0 {
-				prPr -= 1
-			}
-			prShPeace = false
-		} else if shPrPeace && !prShPeace {
-			betrayedF(shFid, prFid)
-			if shSize > 0 {
-				shSize -= 1
-			}
-			shPrPeace = false
+	set := make(TrustSet, len(truces))
+	for pair, _ := range truces {
+		set[pair] = true
+	}
+	fleets := make([]Fleet, 0, len(ships))
+	landers := map[int]ShipDat{}
+	for _, sh := range ships {
+		if sh.Size() < 1 {
+			continue
 		}
-		if seShPeace && !shSePeace {
-			betrayedF(seFid, shFid)
-			if sePr > 0 {
-				sePr -= 1
-			}
-			seShPeace = false
-		} else if shSePeace && !seShPeace {
-			betrayedF(shFid, seFid)
-			if shSize > 0 {
-				shSize -= 1
-			}
-			shSePeace = false
+		fleets = append(fleets, Fleet{sh.FID(), sh.Size(), sh.Power()})
+		if landers[sh.FID()] == nil {
+			landers[sh.FID()] = sh
 		}
-		for shSize > 0 {
-			var fightLeft bool
-			if prPr > 0 && !shPrPeace {
-				fightLeft = true
-				prPr -= 1
-				shSize -= 1
-			}
-			if shSize > 0 && sePr > 0 && !shSePeace {
-				fightLeft = true
-				sePr -= 1
-				shSize -= 1
-			}
-			if !fightLeft {
-				break
-			}
+	}
+	if len(fleets) == 0 {
+		return
+	}
+	start := PlanetCombatState(pl)
+	res := ResolveArrival(rules, start, fleets, set)
+	applyCombat(pl, truces, res)
+	fids := []int{start.PrimaryFaction, start.SecondaryFaction}
+	for _, sh := range ships {
+		fids = append(fids, sh.FID())
+	}
+	seen := map[int]bool{0: true}
+	for _, fid := range fids {
+		if seen[fid] {
+			continue
 		}
-		if shSize > 0 {
-			if !shSePeace && !shPrPeace {
-				prFid = shFid
-				prPr = shSize
-				prPW = sePW
-				seFid = 0
-				sePr = 0
-				sePW = 0
-			} else if shSePeace {
-				prFid = shFid
-				prPr = shSize
-				prPW = 0
-			} else {
-				seFid = shFid
-				sePr = shSize
-				sePW = 0
-			}
+		seen[fid] = true
+		source.NewBattleRecord(landers[fid], fid, turn, start.PrimaryFaction, start.PrimaryPresence, start.SecondaryFaction, start.SecondaryPresence, pl, res.Betrayals, res.Events)
+	}
+	pFid, sFid := pl.PrimaryFaction(), pl.SecondaryFaction()
+	for fid, _ := range seen {
+		if fid != 0 && fid != pFid && fid != sFid {
+			source.UpdatePlanetView(fid, turn, pl)
 		}
-		if seFid != 0 && sePr > prPr {
-			seFid, prFid = prFid, seFid
-			sePr, prPr = prPr, sePr
-			sePW, prPW = prPW, sePW
+	}
+}
+
+// applyCombat ends the truces betrayed in a fight and leaves the planet
+// as the fight did.
+func applyCombat(pl PlanetDat, truces map[[2]int]TruceDat, res CombatResult) {
+	for _, pair := range res.Betrayals {
+		pt := [2]int{pair[1], pair[0]}
+		if tr := truces[pt]; tr != nil {
+			tr.DELETE()
 		}
+		delete(truces, pt)
+	}
+	final := res.Final
+	pl.SetPrimaryFaction(final.PrimaryFaction)
+	pl.SetPrimaryPresence(final.PrimaryPresence)
+	pl.SetPrimaryPower(final.PrimaryPower)
+	pl.SetSecondaryFaction(final.SecondaryFaction)
+	pl.SetSecondaryPresence(final.SecondaryPresence)
+	pl.SetSecondaryPower(final.SecondaryPower)
+}
+
+// PlanetCombatState gives who holds a planet now.
+func PlanetCombatState(pl PlanetDat) CombatState {
+	return CombatState{
+		PrimaryFaction:    pl.PrimaryFaction(),
+		PrimaryPresence:   pl.PrimaryPresence(),
+		PrimaryPower:      pl.PrimaryPower(),
+		SecondaryFaction:  pl.SecondaryFaction(),
+		SecondaryPresence: pl.SecondaryPresence(),
+		SecondaryPower:    pl.SecondaryPower(),
 	}
-	pl.SetPrimaryFaction(prFid)
-	pl.SetPrimaryPresence(prPr)
-	pl.SetPrimaryPower(prPW)
-	pl.SetSecondaryFaction(prFid)
-	pl.SetSecondaryPresence(prPr)
-	pl.SetSecondaryPower(sePW)
-	return
 }
 
 func AllSee(source Source, pl PlanetDat, fid1, fid2, fid3, turn int) {
@@ -173,15 +110,16 @@ func AllSee(source Source, pl PlanetDat, fid1, fid2, fid3, turn int) {
 	}
 }
 
-func AllBattleRecords(source Source, lander ShipDat, planet PlanetDat, turn, initPrF, initPrP, initSeF, initSeP int, betrayals *[][2]int) {
+func AllBattleRecords(source Source, lander ShipDat, planet PlanetDat, turn int, res CombatResult) {
 	var shFid int
 	if lander != nil {
 		shFid = lander.FID()
 	}
-	for _, fid := range []int{initPrF, initSeF, shFid} {
+	start := res.Initial
+	for _, fid := range []int{start.PrimaryFaction, start.SecondaryFaction, shFid} {
 		if fid == 0 {
 			continue
 		}
-		source.NewBattleRecord(lander, fid, turn, initPrF, initPrP, initSeF, initSeP, planet, *betrayals)
+		source.NewBattleRecord(lander, fid, turn, start.PrimaryFaction, start.PrimaryPresence, start.SecondaryFaction, start.SecondaryPresence, planet, res.Betrayals, res.Events)
 	}
 }
@@ -0,0 +1,70 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"reflect"
+	"testing"
+)
+
+func TestSimultaneousLanding(t *testing.T) {
+	for _, mode := range []int{overpower.SIMULTANEOUS, overpower.SEQUENTIAL} {
+		s := makeTestGalaxy(t, 2)
+		s.GameItem.Rules.CombatMode = mode
+		target := nearestFree(s, s.HomePlanet(1).Loc)
+		target.PrimaryPresence = 1
+		from := hexagon.Coord{target.Loc[0] + 1, target.Loc[1]}
+		turn := s.GameItem.Turn
+		s.NewShip(1, 1, 5, 0, turn, hexagon.CoordList{from, target.Loc})
+		s.NewShip(2, 2, 3, 0, turn, hexagon.CoordList{from, target.Loc})
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatalf("mode %d: run turn failed: %s", mode, failE)
+		}
+		if ships, _ := s.Ships(); len(ships) != 0 {
+			t.Errorf("mode %d: %d ships left after landing", mode, len(ships))
+		}
+		records := map[int]int{}
+		for _, br := range s.BattleRecordList {
+			if br.Loc == target.Loc {
+				records[br.FID]++
+			}
+		}
+		switch mode {
+		case overpower.SIMULTANEOUS:
+			if target.PrimaryFaction != 1 || target.PrimaryPresence != 1 || target.SecondaryFaction != 0 {
+				t.Errorf("fleets left target as %+v, want held by 1 with 1", target)
+			}
+			if records[1] != 1 || records[2] != 1 {
+				t.Errorf("battle records by faction %v, want one each", records)
+			}
+		case overpower.SEQUENTIAL:
+			if records[1]+records[2] != 3 {
+				t.Errorf("battle records by faction %v, want three from two landings", records)
+			}
+		}
+	}
+}
+
+func TestBattleRecordLanders(t *testing.T) {
+	s := makeTestGalaxy(t, 3)
+	s.GameItem.Rules.CombatMode = overpower.SIMULTANEOUS
+	target := nearestFree(s, s.HomePlanet(1).Loc)
+	target.PrimaryFaction, target.PrimaryPresence = 3, 10
+	from := hexagon.Coord{target.Loc[0] + 1, target.Loc[1]}
+	turn := s.GameItem.Turn
+	s.NewShip(1, 1, 2, 0, turn, hexagon.CoordList{from, target.Loc})
+	s.NewShip(2, 2, 3, 0, turn, hexagon.CoordList{from, target.Loc})
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	landers := map[int]int{}
+	for _, br := range s.BattleRecordList {
+		if br.Loc == target.Loc {
+			landers[br.FID] = br.ShipFaction
+		}
+	}
+	want := map[int]int{1: 1, 2: 2, 3: 0}
+	if !reflect.DeepEqual(landers, want) {
+		t.Errorf("landers by record faction %v, want %v", landers, want)
+	}
+}
@@ -0,0 +1,218 @@
+// Package bots plays factions without a human behind them.  A bot is
+// handed only what its faction could see on the map page and answers with
+// the orders a player would give.
+package bots
+
+import (
+	"math/rand"
+	"mule/hexagon"
+	"mule/overpower"
+	"sort"
+)
+
+// View is what one faction knows at the start of a turn: every planet it
+// has seen, the ships it spotted last turn and the battles it was in.
+type View struct {
+	FID     int
+	Turn    int
+	Rules   overpower.GameRules
+	Planets []overpower.PlanetViewDat
+	Ships   []overpower.ShipViewDat
+	Battles []overpower.BattleRecordDat
+}
+
+// Launch sends Size ships from Source to Target.
+type Launch struct {
+	Source hexagon.Coord
+	Target hexagon.Coord
+	Size   int
+}
+
+// Power sets the faction's power at Loc: antimatter if UpPower is
+// positive, tachyons if negative.
+type Power struct {
+	Loc     hexagon.Coord
+	UpPower int
+}
+
+// Truce lists the factions to keep a truce with at one planet.
+type Truce struct {
+	Loc     hexagon.Coord
+	Trucees []int
+}
+
+// Orders are one faction's orders for a turn.  Power orders last only
+// the turn they are given for, so a planet not in Powers keeps the power
+// it has.
+type Orders struct {
+	Launches []Launch
+	Powers   []Power
+	Truces   []Truce
+}
+
+// AI picks a faction's orders.  All randomness must come from rng so a bot
+// always plays the same position the same way.
+type AI interface {
+	Orders(v *View, rng *rand.Rand) *Orders
+}
+
+var strategies = map[string]AI{
+	"expander": Expander{},
+	"defender": Defender{},
+}
+
+func Get(name string) (AI, bool) {
+	ai, ok := strategies[name]
+	return ai, ok
+}
+
+// Names lists the bot strategies in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(strategies))
+	for name, _ := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rand gives the random source a bot uses for a turn of a game.
+func Rand(seed int64, fid, turn int) *rand.Rand {
+	return overpower.TurnRand(seed+int64(fid), turn)
+}
+
+// base is a planet the faction can launch from or change the power of.
+type base struct {
+	pv      overpower.PlanetViewDat
+	power   int
+	avail   int
+	swapped int
+}
+
+// bases lists the planets the view's faction holds, nearest the center
+// first, with the ships each can launch this turn.
+func bases(v *View) []*base {
+	var list []*base
+	for _, pv := range v.Planets {
+		var pres, power int
+		switch v.FID {
+		case pv.PrimaryFaction():
+			pres, power = pv.PrimaryPresence(), pv.PrimaryPower()
+		case pv.SecondaryFaction():
+			pres, power = pv.SecondaryPresence(), pv.SecondaryPower()
+		default:
+			continue
+		}
+		b := &base{pv: pv, power: power}
+		switch power {
+		case overpower.ANTIMATTER:
+			b.avail, b.swapped = pv.Antimatter(), pv.Tachyons()
+		case overpower.TACHYONS:
+			b.avail, b.swapped = pv.Tachyons(), pv.Antimatter()
+		}
+		if b.avail > pres {
+			b.avail = pres
+		}
+		if b.swapped > pres {
+			b.swapped = pres
+		}
+		list = append(list, b)
+	}
+	sort.Sort(sortBases(list))
+	return list
+}
+
+// defence gives the presence a ship would need to beat to take a planet,
+// as last seen.
+func defence(v *View, pv overpower.PlanetViewDat) int {
+	var d int
+	if f := pv.PrimaryFaction(); f != 0 && f != v.FID {
+		d += pv.PrimaryPresence()
+	} else if f == 0 {
+		d += pv.PrimaryPresence()
+	}
+	if f := pv.SecondaryFaction(); f != 0 && f != v.FID {
+		d += pv.SecondaryPresence()
+	}
+	return d
+}
+
+// powerOrders switches the power of every base that would gain ships
+// from it.
+func powerOrders(bs []*base) []Power {
+	var list []Power
+	for _, b := range bs {
+		if b.swapped <= b.avail {
+			continue
+		}
+		up := overpower.ANTIMATTER
+		if b.power == overpower.ANTIMATTER {
+			up = overpower.TACHYONS
+		}
+		list = append(list, Power{Loc: b.pv.Loc(), UpPower: up})
+	}
+	return list
+}
+
+// conquer sends ships from the bases to the targets, nearest first, each
+// big enough to take its target as last seen.  A base only attacks
+// targets within reach hexes, or any distance if reach is 0.
+func conquer(v *View, bs []*base, targets []overpower.PlanetViewDat, reach int, o *Orders) {
+	sent := map[hexagon.Coord]bool{}
+	for _, b := range bs {
+		from := b.pv.Loc()
+		list := make([]overpower.PlanetViewDat, len(targets))
+		copy(list, targets)
+		sort.Sort(sortByDist{from, list})
+		for _, t := range list {
+			if b.avail < 1 {
+				break
+			}
+			loc := t.Loc()
+			if sent[loc] || (reach > 0 && from.StepsTo(loc) > reach) {
+				continue
+			}
+			need := defence(v, t) + 1
+			if need > b.avail {
+				continue
+			}
+			sent[loc] = true
+			b.avail -= need
+			o.Launches = append(o.Launches, Launch{Source: from, Target: loc, Size: need})
+		}
+	}
+}
+
+type sortBases []*base
+
+func (s sortBases) Len() int      { return len(s) }
+func (s sortBases) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortBases) Less(i, j int) bool {
+	a, b := s[i].pv.Loc(), s[j].pv.Loc()
+	var center hexagon.Coord
+	if da, db := a.StepsTo(center), b.StepsTo(center); da != db {
+		return da < db
+	}
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
+
+type sortByDist struct {
+	from hexagon.Coord
+	list []overpower.PlanetViewDat
+}
+
+func (s sortByDist) Len() int      { return len(s.list) }
+func (s sortByDist) Swap(i, j int) { s.list[i], s.list[j] = s.list[j], s.list[i] }
+func (s sortByDist) Less(i, j int) bool {
+	a, b := s.list[i].Loc(), s.list[j].Loc()
+	if da, db := s.from.StepsTo(a), s.from.StepsTo(b); da != db {
+		return da < db
+	}
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
@@ -0,0 +1,103 @@
+package bots_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/bots"
+	"mule/overpower/memsource"
+	"reflect"
+	"testing"
+)
+
+// makeGame makes a standard galaxy for the given players and seed.
+func makeGame(t *testing.T, players int, seed int64) *memsource.Source {
+	s := memsource.New(1)
+	s.GameItem.ToWin = 100
+	s.GameItem.Seed = seed
+	for i := 0; i < players; i++ {
+		s.AddFaction("tester", "Faction")
+	}
+	if err := overpower.MakeGalaxy(s, "standard"); err != nil {
+		t.Fatal("make galaxy failed:", err)
+	}
+	return s
+}
+
+func playBots(t *testing.T, strategies []string, seed int64, turns int) *memsource.Source {
+	s := makeGame(t, len(strategies), seed)
+	for i, name := range strategies {
+		s.FactionList[i].Bot = name
+	}
+	for i := 0; i < turns && s.GameItem.Winner == ""; i++ {
+		bots.MemPlay(s)
+		logE, failE := overpower.RunGameTurn(s)
+		if failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+		if logE != nil {
+			t.Fatalf("turn %d logged bad orders: %s", s.GameItem.Turn, logE)
+		}
+	}
+	return s
+}
+
+func TestBotsPlay(t *testing.T) {
+	strategies := []string{"expander", "defender", "expander", "defender"}
+	s := playBots(t, strategies, 7, 30)
+	again := playBots(t, strategies, 7, 30)
+	if !reflect.DeepEqual(s.PlanetList, again.PlanetList) {
+		t.Error("bots played the same game differently")
+	}
+	held := map[int]int{}
+	for _, pl := range s.PlanetList {
+		held[pl.PrimaryFaction] += 1
+	}
+	for i, name := range strategies {
+		if held[i+1] < 2 {
+			t.Errorf("%s bot %d holds %d planets after %d turns", name, i+1, held[i+1], s.GameItem.Turn)
+		}
+	}
+}
+
+func TestDefenderGrudges(t *testing.T) {
+	s := makeGame(t, 3, 1)
+	home := s.HomePlanet(1)
+	var lost *memsource.Planet
+	for _, pl := range s.PlanetList {
+		if pl.PrimaryFaction == 0 && pl.PrimaryPresence == 0 && (lost == nil || home.Loc.StepsTo(pl.Loc) < home.Loc.StepsTo(lost.Loc)) {
+			lost = pl
+		}
+	}
+	for _, pv := range s.PlanetViewList {
+		if pv.FID == 1 && pv.Loc == lost.Loc {
+			pv.PrimaryFaction, pv.PrimaryPresence, pv.PrimaryPower = 2, 2, overpower.ANTIMATTER
+		}
+		if pv.FID == 1 && pv.Loc == s.HomePlanet(3).Loc {
+			pv.PrimaryFaction, pv.PrimaryPresence, pv.PrimaryPower = 3, 5, overpower.ANTIMATTER
+		}
+	}
+	v := bots.MemView(s, 1)
+	v.Battles = append(v.Battles, (&memsource.BattleRecord{
+		FID: 1, Loc: lost.Loc, Turn: 1,
+		InitPrimaryFaction: 1, InitPrimaryPresence: 1,
+		ShipFaction: 2, ShipSize: 3,
+		PrimaryFaction: 2, PrimaryPresence: 2,
+	}).Intf())
+	o := bots.Defender{}.Orders(v, bots.Rand(1, 1, 1))
+	var retook bool
+	for _, l := range o.Launches {
+		if l.Target == lost.Loc && l.Size > 2 {
+			retook = true
+		}
+	}
+	if !retook {
+		t.Errorf("defender did not retake its lost planet: %+v", o.Launches)
+	}
+	if len(o.Truces) == 0 {
+		t.Fatal("defender offered no truces")
+	}
+	for _, tr := range o.Truces {
+		if !reflect.DeepEqual(tr.Trucees, []int{3}) {
+			t.Errorf("defender at %v trusts %v, want only faction 3", tr.Loc, tr.Trucees)
+		}
+	}
+}
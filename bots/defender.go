@@ -0,0 +1,95 @@
+package bots
+
+import (
+	"math/rand"
+	"mule/hexagon"
+	"mule/overpower"
+	"sort"
+)
+
+// Defender keeps a truce at each of its planets with every faction that has
+// not yet attacked or betrayed it, takes back planets lost to those that
+// have, and otherwise only claims neutral planets near its own.
+type Defender struct{}
+
+// defenderReach is how many turns of flight away a defender will look for
+// neutral planets.
+const defenderReach = 2
+
+func (Defender) Orders(v *View, rng *rand.Rand) *Orders {
+	o := &Orders{}
+	hostile, lost := grudges(v)
+	known := map[int]bool{}
+	for _, pv := range v.Planets {
+		known[pv.PrimaryFaction()] = true
+		known[pv.SecondaryFaction()] = true
+	}
+	for _, sv := range v.Ships {
+		known[sv.Controller()] = true
+	}
+	var friends []int
+	for fid, _ := range known {
+		if fid != 0 && fid != v.FID && !hostile[fid] {
+			friends = append(friends, fid)
+		}
+	}
+	sort.Ints(friends)
+	bs := bases(v)
+	if len(friends) > 0 {
+		for _, b := range bs {
+			o.Truces = append(o.Truces, Truce{Loc: b.pv.Loc(), Trucees: friends})
+		}
+	}
+	var retake, neutral []overpower.PlanetViewDat
+	for _, pv := range v.Planets {
+		f1, f2 := pv.PrimaryFaction(), pv.SecondaryFaction()
+		switch {
+		case f1 == v.FID || f2 == v.FID:
+		case lost[pv.Loc()] && (hostile[f1] || hostile[f2]):
+			retake = append(retake, pv)
+		case f1 == 0 && f2 == 0:
+			neutral = append(neutral, pv)
+		}
+	}
+	conquer(v, bs, retake, 0, o)
+	conquer(v, bs, neutral, defenderReach*v.Rules.ShipSpeed, o)
+	o.Powers = powerOrders(bs)
+	return o
+}
+
+// grudges reads the view's battle records for the factions that have
+// betrayed the view's faction or cost it presence, and the planets where
+// that happened.
+func grudges(v *View) (hostile map[int]bool, lost map[hexagon.Coord]bool) {
+	hostile = map[int]bool{}
+	lost = map[hexagon.Coord]bool{}
+	for _, br := range v.Battles {
+		for _, pair := range br.Betrayals() {
+			if pair[1] == v.FID {
+				hostile[pair[0]] = true
+			}
+		}
+		shFid := br.ShipFaction()
+		if shFid == 0 || shFid == v.FID {
+			continue
+		}
+		var before, after int
+		if br.InitPrimaryFaction() == v.FID {
+			before += br.InitPrimaryPresence()
+		}
+		if br.InitSecondaryFaction() == v.FID {
+			before += br.InitSecondaryPresence()
+		}
+		if br.PrimaryFaction() == v.FID {
+			after += br.PrimaryPresence()
+		}
+		if br.SecondaryFaction() == v.FID {
+			after += br.SecondaryPresence()
+		}
+		if after < before {
+			hostile[shFid] = true
+			lost[br.Loc()] = true
+		}
+	}
+	return hostile, lost
+}
@@ -0,0 +1,30 @@
+package bots
+
+import (
+	"math/rand"
+	"mule/overpower"
+)
+
+// Expander grabs every planet it can afford to take, neutral planets before
+// other factions', and turns its power to whichever resource gives it the
+// most ships.  It never keeps a truce.
+type Expander struct{}
+
+func (Expander) Orders(v *View, rng *rand.Rand) *Orders {
+	o := &Orders{}
+	bs := bases(v)
+	var neutral, enemy []overpower.PlanetViewDat
+	for _, pv := range v.Planets {
+		switch f1, f2 := pv.PrimaryFaction(), pv.SecondaryFaction(); {
+		case f1 == v.FID || f2 == v.FID:
+		case f1 == 0 && f2 == 0:
+			neutral = append(neutral, pv)
+		default:
+			enemy = append(enemy, pv)
+		}
+	}
+	conquer(v, bs, neutral, 0, o)
+	conquer(v, bs, enemy, 0, o)
+	o.Powers = powerOrders(bs)
+	return o
+}
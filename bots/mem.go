@@ -0,0 +1,72 @@
+package bots
+
+import (
+	"mule/overpower/memsource"
+)
+
+// MemView gathers what a faction of an in-memory game can see this turn.
+func MemView(s *memsource.Source, fid int) *View {
+	v := &View{
+		FID:   fid,
+		Turn:  s.GameItem.Turn,
+		Rules: s.GameItem.Rules,
+	}
+	for _, pv := range s.PlanetViewList {
+		if pv.FID == fid && !pv.Deleted {
+			v.Planets = append(v.Planets, pv.Intf())
+		}
+	}
+	for _, sv := range s.ShipViewList {
+		if sv.FID == fid && sv.Turn == v.Turn-1 && !sv.Deleted {
+			v.Ships = append(v.Ships, sv.Intf())
+		}
+	}
+	for _, br := range s.BattleRecordList {
+		if br.FID == fid && !br.Deleted {
+			v.Battles = append(v.Battles, br.Intf())
+		}
+	}
+	return v
+}
+
+// MemPlay has each bot faction of an in-memory game replace its orders
+// with the ones its strategy gives for the current turn.
+func MemPlay(s *memsource.Source) {
+	game := s.GameItem
+	for _, f := range s.FactionList {
+		if f.Deleted || f.Bot == "" {
+			continue
+		}
+		ai, ok := Get(f.Bot)
+		if !ok {
+			continue
+		}
+		o := ai.Orders(MemView(s, f.FID), Rand(game.Seed, f.FID, game.Turn))
+		for _, lo := range s.LaunchOrderList {
+			if lo.FID == f.FID {
+				lo.Deleted = true
+			}
+		}
+		for _, tr := range s.TruceList {
+			if tr.FID == f.FID {
+				tr.Deleted = true
+			}
+		}
+		for _, po := range s.PowerOrderList {
+			if po.FID == f.FID {
+				po.Deleted = true
+			}
+		}
+		for _, l := range o.Launches {
+			s.AddLaunchOrder(f.FID, l.Size, l.Source, l.Target)
+		}
+		for _, t := range o.Truces {
+			for _, fid := range t.Trucees {
+				s.AddTruce(f.FID, fid, t.Loc)
+			}
+		}
+		for _, p := range o.Powers {
+			s.AddPowerOrder(f.FID, p.UpPower, p.Loc)
+		}
+	}
+}
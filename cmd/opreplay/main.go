@@ -0,0 +1,53 @@
+// Command opreplay reruns a game from turn 1 using its archived orders and
+// prints every difference between the replay and the stored game.
+//
+//	opreplay [-maps DIR] GID
+//
+// Games made from map templates need the templates' directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"mule/overpower"
+	"mule/overpower/models"
+	"os"
+	"strconv"
+)
+
+func main() {
+	maps := flag.String("maps", "DATA/maps", "directory of map templates")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: opreplay [-maps DIR] GID")
+		os.Exit(2)
+	}
+	gid, err := strconv.Atoi(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bad gid:", flag.Arg(0))
+		os.Exit(2)
+	}
+	if err := overpower.RegisterMapTemplates(*maps); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load map templates:", err)
+		os.Exit(1)
+	}
+	d, err := models.LoadDB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load database:", err)
+		os.Exit(1)
+	}
+	diffs, err := d.ReplayGame(gid)
+	d.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "replay failed:", err)
+		os.Exit(1)
+	}
+	for _, diff := range diffs {
+		fmt.Println(diff)
+	}
+	if len(diffs) > 0 {
+		fmt.Printf("game %d: %d differences\n", gid, len(diffs))
+		os.Exit(1)
+	}
+	fmt.Printf("game %d replays cleanly\n", gid)
+}
@@ -0,0 +1,384 @@
+// Command optourney plays bot against bot in memory, without a database,
+// and reports how each strategy fared.
+//
+//	optourney [-games N] [-seed S] [-bots LIST] [-rules LIST] [-format csv|json] ...
+//
+// Every game gets its own seed, counting up from -seed, so any one game can
+// be played again alone.  Strategies take turns in each seat to even out
+// where the galaxy put them.  Rules are given as name=value pairs, named as
+// in the game's JSON, to try out changes before making them on live games:
+//
+//	optourney -games 2000 -rules shipspeed=8,homeantimatter=15
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mule/overpower"
+	"mule/overpower/bots"
+	"mule/overpower/memsource"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type config struct {
+	Games      int
+	Seed       int64
+	Bots       []string
+	Turns      int
+	ToWin      int
+	Generator  string
+	Rules      overpower.GameRules
+	Workers    int
+	Format     string
+	CurvesFile string
+}
+
+func main() {
+	var c config
+	var botList, ruleList, out string
+	flag.IntVar(&c.Games, "games", 100, "number of games to play")
+	flag.Int64Var(&c.Seed, "seed", 1, "seed of the first game")
+	flag.StringVar(&botList, "bots", "expander,defender", "comma separated strategies, one per faction")
+	flag.IntVar(&c.Turns, "turns", 200, "turns to play before calling a game unfinished")
+	flag.IntVar(&c.ToWin, "towin", 0, "planets to win; 0 gives 40% of the galaxy")
+	flag.StringVar(&c.Generator, "generator", "standard", "galaxy generator")
+	flag.StringVar(&ruleList, "rules", "", "comma separated name=value rule changes")
+	flag.IntVar(&c.Workers, "workers", runtime.NumCPU(), "games played at once")
+	flag.StringVar(&c.Format, "format", "csv", "output format: csv or json")
+	flag.StringVar(&out, "out", "", "file to write results to instead of stdout")
+	flag.StringVar(&c.CurvesFile, "curves", "", "with csv output, file to write score curves to")
+	maps := flag.String("maps", "DATA/maps", "directory of map templates")
+	flag.Parse()
+	c.Bots = strings.Split(botList, ",")
+	var err error
+	if c.Rules, err = parseRules(ruleList); err != nil {
+		fail(2, "bad rules:", err)
+	}
+	if err := overpower.RegisterMapTemplates(*maps); err != nil {
+		fail(1, "failed to load map templates:", err)
+	}
+	if err := c.check(); err != nil {
+		fail(2, err)
+	}
+	w := io.Writer(os.Stdout)
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			fail(1, "failed to open output:", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	report := summarize(c, playAll(c))
+	switch c.Format {
+	case "json":
+		err = writeJSON(w, report)
+	default:
+		err = writeCSV(w, report)
+		if err == nil && c.CurvesFile != "" {
+			err = writeCurvesFile(c.CurvesFile, report)
+		}
+	}
+	if err != nil {
+		fail(1, "failed to write results:", err)
+	}
+}
+
+func fail(code int, args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(code)
+}
+
+func (c config) check() error {
+	if c.Games < 1 || c.Turns < 1 || c.Workers < 1 {
+		return fmt.Errorf("games, turns and workers must be positive")
+	}
+	if len(c.Bots) < 2 {
+		return fmt.Errorf("a tournament needs at least two bots")
+	}
+	for _, name := range c.Bots {
+		if _, ok := bots.Get(name); !ok {
+			return fmt.Errorf("unknown bot %q; have %s", name, strings.Join(bots.Names(), ", "))
+		}
+	}
+	if _, ok := overpower.GetGenerator(c.Generator); !ok {
+		return fmt.Errorf("unknown generator %q; have %s", c.Generator, strings.Join(overpower.GeneratorNames(), ", "))
+	}
+	if c.Format != "csv" && c.Format != "json" {
+		return fmt.Errorf("unknown format %q", c.Format)
+	}
+	return nil
+}
+
+// parseRules changes the standard rules by a list like "shipspeed=8,
+// resourcecap=20", naming rules by their JSON keys.
+func parseRules(list string) (overpower.GameRules, error) {
+	rules := overpower.DefaultRules()
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return rules, err
+	}
+	known := map[string]int{}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return rules, err
+	}
+	for _, pair := range strings.Split(list, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		if _, ok := known[name]; !ok || len(parts) != 2 {
+			return rules, fmt.Errorf("unknown rule %q", pair)
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return rules, fmt.Errorf("unparsable rule %q", pair)
+		}
+		known[name] = val
+	}
+	if data, err = json.Marshal(known); err != nil {
+		return rules, err
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, err
+	}
+	return rules, rules.Validate()
+}
+
+// result is how one game went.  Scores holds each seat's score after each
+// turn played.
+type result struct {
+	Seed     int64
+	Seats    []string
+	Turns    int
+	Finished bool
+	Winners  []int
+	Scores   [][]int
+	Err      error
+}
+
+func playAll(c config) []*result {
+	results := make([]*result, c.Games)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < c.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for g := range jobs {
+				results[g] = play(c, g)
+			}
+		}()
+	}
+	for g := 0; g < c.Games; g++ {
+		jobs <- g
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// play runs game number g of the tournament, with the bots moved round
+// the seats by g.
+func play(c config, g int) *result {
+	n := len(c.Bots)
+	r := &result{Seed: c.Seed + int64(g), Seats: make([]string, n)}
+	s := memsource.New(g + 1)
+	s.GameItem.Seed = r.Seed
+	s.GameItem.Rules = c.Rules
+	s.GameItem.ToWin = c.ToWin
+	if c.ToWin == 0 {
+		s.GameItem.ToWin = n * c.Rules.PlanetsPerPlayer() * 2 / 5
+	}
+	for seat := 0; seat < n; seat++ {
+		r.Seats[seat] = c.Bots[(seat+g)%n]
+		f := s.AddFaction("~bot"+strconv.Itoa(seat+1), "Seat "+strconv.Itoa(seat+1))
+		f.Bot = r.Seats[seat]
+	}
+	if r.Err = overpower.MakeGalaxy(s, c.Generator); r.Err != nil {
+		return r
+	}
+	for r.Turns < c.Turns && !overpower.GameOver(s.GameItem.Intf()) {
+		bots.MemPlay(s)
+		if _, r.Err = overpower.RunGameTurn(s); r.Err != nil {
+			return r
+		}
+		r.Turns++
+		scores := make([]int, n)
+		for _, f := range s.FactionList {
+			scores[f.FID-1] = f.Score
+		}
+		r.Scores = append(r.Scores, scores)
+		// The archive is only wanted for replays.
+		s.Archive = nil
+	}
+	r.Finished = overpower.GameOver(s.GameItem.Intf())
+	for _, st := range s.StandingList {
+		if st.Rank == 1 {
+			r.Winners = append(r.Winners, st.FID-1)
+		}
+	}
+	sort.Ints(r.Winners)
+	return r
+}
+
+// Record sums how one strategy fared over the tournament.  Shared wins
+// count as a win for each sharer.
+type Record struct {
+	Bot        string  `json:"bot"`
+	Seats      int     `json:"seats"`
+	Wins       int     `json:"wins"`
+	WinRate    float64 `json:"winrate"`
+	AvgScore   float64 `json:"avgscore"`
+	ScoreCurve []Point `json:"scorecurve"`
+}
+
+// Point is a strategy's average score after a turn, over the seats it held
+// in games still running then.
+type Point struct {
+	Turn     int     `json:"turn"`
+	AvgScore float64 `json:"avgscore"`
+	Seats    int     `json:"seats"`
+}
+
+type Report struct {
+	Games       int                 `json:"games"`
+	Seed        int64               `json:"seed"`
+	Generator   string              `json:"generator"`
+	Rules       overpower.GameRules `json:"rules"`
+	Finished    int                 `json:"finished"`
+	Failed      int                 `json:"failed"`
+	AvgLength   float64             `json:"avglength"`
+	Records     []*Record           `json:"records"`
+	FailedSeeds []int64             `json:"failedseeds,omitempty"`
+}
+
+func summarize(c config, results []*result) *Report {
+	rep := &Report{
+		Games:     c.Games,
+		Seed:      c.Seed,
+		Generator: c.Generator,
+		Rules:     c.Rules,
+	}
+	records := map[string]*Record{}
+	var names []string
+	for _, name := range c.Bots {
+		if records[name] == nil {
+			records[name] = &Record{Bot: name}
+			names = append(names, name)
+		}
+	}
+	sums := map[string][]float64{}
+	counts := map[string][]int{}
+	finalSum := map[string]float64{}
+	var turns int
+	for _, r := range results {
+		if r.Err != nil {
+			rep.Failed++
+			rep.FailedSeeds = append(rep.FailedSeeds, r.Seed)
+			fmt.Fprintf(os.Stderr, "game with seed %d failed: %s\n", r.Seed, r.Err)
+			continue
+		}
+		if r.Finished {
+			rep.Finished++
+			turns += r.Turns
+		}
+		for _, seat := range r.Winners {
+			records[r.Seats[seat]].Wins++
+		}
+		for seat, name := range r.Seats {
+			records[name].Seats++
+			if len(r.Scores) > 0 {
+				finalSum[name] += float64(r.Scores[len(r.Scores)-1][seat])
+			}
+			for t, scores := range r.Scores {
+				for len(sums[name]) <= t {
+					sums[name] = append(sums[name], 0)
+					counts[name] = append(counts[name], 0)
+				}
+				sums[name][t] += float64(scores[seat])
+				counts[name][t]++
+			}
+		}
+	}
+	if rep.Finished > 0 {
+		rep.AvgLength = float64(turns) / float64(rep.Finished)
+	}
+	for _, name := range names {
+		rec := records[name]
+		if rec.Seats > 0 {
+			rec.WinRate = float64(rec.Wins) / float64(rec.Seats)
+			rec.AvgScore = finalSum[name] / float64(rec.Seats)
+		}
+		for t, sum := range sums[name] {
+			rec.ScoreCurve = append(rec.ScoreCurve, Point{
+				Turn:     t + 1,
+				AvgScore: sum / float64(counts[name][t]),
+				Seats:    counts[name][t],
+			})
+		}
+		rep.Records = append(rep.Records, rec)
+	}
+	return rep
+}
+
+func writeJSON(w io.Writer, rep *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
+
+// writeCSV writes one row per strategy.  Average length counts finished
+// games only.
+func writeCSV(w io.Writer, rep *Report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"bot", "seats", "wins", "winrate", "avgscore", "games", "finished", "avglength"})
+	for _, rec := range rep.Records {
+		cw.Write([]string{
+			rec.Bot,
+			strconv.Itoa(rec.Seats),
+			strconv.Itoa(rec.Wins),
+			strconv.FormatFloat(rec.WinRate, 'f', 4, 64),
+			strconv.FormatFloat(rec.AvgScore, 'f', 2, 64),
+			strconv.Itoa(rep.Games),
+			strconv.Itoa(rep.Finished),
+			strconv.FormatFloat(rep.AvgLength, 'f', 2, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeCurvesFile(name string, rep *Report) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	cw.Write([]string{"bot", "turn", "avgscore", "seats"})
+	for _, rec := range rep.Records {
+		for _, pt := range rec.ScoreCurve {
+			cw.Write([]string{
+				rec.Bot,
+				strconv.Itoa(pt.Turn),
+				strconv.FormatFloat(pt.AvgScore, 'f', 2, 64),
+				strconv.Itoa(pt.Seats),
+			})
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
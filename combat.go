@@ -0,0 +1,443 @@
+package overpower
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// CombatState is who holds a planet and with how much.
+type CombatState struct {
+	PrimaryFaction    int
+	PrimaryPresence   int
+	PrimaryPower      int
+	SecondaryFaction  int
+	SecondaryPresence int
+	SecondaryPower    int
+}
+
+// TrustSet holds the truces in force at a planet, keyed {truster, trustee}.
+type TrustSet map[[2]int]bool
+
+func (ts TrustSet) Trusts(truster, trustee int) bool {
+	return ts[[2]int{truster, trustee}]
+}
+
+// Combat event kinds.
+const (
+	COMBATCROWD  = 1
+	COMBATBETRAY = 2
+	COMBATGROUND = 3
+	COMBATLAND   = 4
+	COMBATTAKE   = 5
+	COMBATJOIN   = 6
+	COMBATSWAP   = 7
+	COMBATSPACE  = 8
+	COMBATSTRIKE = 9
+)
+
+var combatKindNames = map[int]string{
+	COMBATCROWD:  "crowd",
+	COMBATBETRAY: "betray",
+	COMBATGROUND: "ground",
+	COMBATLAND:   "land",
+	COMBATTAKE:   "take",
+	COMBATJOIN:   "join",
+	COMBATSWAP:   "swap",
+	COMBATSPACE:  "space",
+	COMBATSTRIKE: "strike",
+}
+
+// CombatEvent is one step of a fight, with the losses and the presence left
+// to each of the two factions named in Sides:
+//
+//	crowd:  {occupant, occupant} or {ship, 0}, all trusting and all
+//	        turning on each other
+//	betray: {betrayor, truster}, the truster losing one to the surprise
+//	ground: {primary, secondary}, fought to the last of one of them
+//	land:   {ship, occupant}, a single exchange
+//	take:   {ship, 0}, the ship's survivors landing as primary
+//	join:   {ship, primary}, the ship's survivors landing as secondary
+//	swap:   {primary, secondary}, the secondary taking the primary slot
+//	        by outnumbering it
+//	space:  {fleet, 0}, a fleet losing one to the other fleets arriving
+//	        with it
+//	strike: {ship, occupant}, an antimatter ship killing defenders as it
+//	        lands, before they can fight back
+type CombatEvent struct {
+	Kind   int
+	Sides  [2]int
+	Losses [2]int
+	Left   [2]int
+}
+
+func (e CombatEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind   string `json:"kind"`
+		Sides  [2]int `json:"sides"`
+		Losses [2]int `json:"losses"`
+		Left   [2]int `json:"left"`
+	}{combatKindNames[e.Kind], e.Sides, e.Losses, e.Left})
+}
+
+// combatEventInts is how many ints EncodeCombatEvents stores per event.
+const combatEventInts = 7
+
+// EncodeCombatEvents flattens events into a list of ints for storage.
+func EncodeCombatEvents(events []CombatEvent) []int {
+	list := make([]int, 0, len(events)*combatEventInts)
+	for _, e := range events {
+		list = append(list, e.Kind, e.Sides[0], e.Sides[1], e.Losses[0], e.Losses[1], e.Left[0], e.Left[1])
+	}
+	return list
+}
+
+// DecodeCombatEvents undoes EncodeCombatEvents.
+func DecodeCombatEvents(list []int) []CombatEvent {
+	events := make([]CombatEvent, 0, len(list)/combatEventInts)
+	for i := 0; i+combatEventInts <= len(list); i += combatEventInts {
+		events = append(events, CombatEvent{
+			Kind:   list[i],
+			Sides:  [2]int{list[i+1], list[i+2]},
+			Losses: [2]int{list[i+3], list[i+4]},
+			Left:   [2]int{list[i+5], list[i+6]},
+		})
+	}
+	return events
+}
+
+// CombatResult is everything a fight at a planet decided.  Rounds counts
+// the one for one exchanges fought.  Betrayals are {betrayor, truster}
+// pairs, each a truce the truster lost.
+type CombatResult struct {
+	Initial   CombatState
+	Final     CombatState
+	Rounds    int
+	Events    []CombatEvent
+	Losses    map[int]int
+	Betrayals [][2]int
+}
+
+// ResolveCombat settles a planet's occupants fighting each other and a ship
+// landing there, if shipFid is not 0.  It changes nothing; the caller
+// applies the result.
+func ResolveCombat(state CombatState, shipFid, shipSize int, truces TrustSet) CombatResult {
+	return ResolveLanding(GameRules{}, state, Fleet{FID: shipFid, Size: shipSize}, truces)
+}
+
+// ResolveLanding is ResolveCombat for a fleet that may strike: the
+// defenders the rules give a fleet of its power and surviving size kill
+// die before the exchanges start, hostile occupants only, primary first.
+func ResolveLanding(rules GameRules, state CombatState, fleet Fleet, truces TrustSet) CombatResult {
+	res := CombatResult{Initial: state, Losses: map[int]int{}}
+	prFid, prPr, prPW := state.PrimaryFaction, state.PrimaryPresence, state.PrimaryPower
+	seFid, sePr, sePW := state.SecondaryFaction, state.SecondaryPresence, state.SecondaryPower
+	shFid, shSize := fleet.FID, fleet.Size
+	if shSize < 1 {
+		shFid, shSize = 0, 0
+	}
+	broken := map[[2]int]bool{}
+	peace := func(fid1, fid2 int) bool {
+		return truces.Trusts(fid1, fid2) && !broken[[2]int{fid1, fid2}]
+	}
+	betrayedF := func(truster, betrayor int) {
+		broken[[2]int{truster, betrayor}] = true
+		res.Betrayals = append(res.Betrayals, [2]int{betrayor, truster})
+	}
+	lose := func(fid int, amount *int) int {
+		if *amount > 0 {
+			*amount -= 1
+			res.Losses[fid] += 1
+			return 1
+		}
+		return 0
+	}
+	event := func(kind, fid1, fid2, lost1, lost2, left1, left2 int) {
+		res.Events = append(res.Events, CombatEvent{
+			Kind:   kind,
+			Sides:  [2]int{fid1, fid2},
+			Losses: [2]int{lost1, lost2},
+			Left:   [2]int{left1, left2},
+		})
+	}
+	// ------ GROUND FIGHT ---------- //
+	if prFid != 0 && seFid != 0 {
+		prPeace := peace(prFid, seFid)
+		sePeace := peace(seFid, prFid)
+		if shFid != 0 && prPeace && sePeace &&
+			peace(shFid, seFid) && peace(seFid, shFid) &&
+			peace(prFid, shFid) && peace(shFid, prFid) {
+
+			prPeace = false
+			sePeace = false
+			for _, pair := range [][2]int{
+				[2]int{seFid, shFid}, [2]int{shFid, seFid},
+				[2]int{prFid, shFid}, [2]int{shFid, prFid},
+				[2]int{prFid, seFid}, [2]int{seFid, prFid},
+			} {
+				betrayedF(pair[0], pair[1])
+			}
+			prLost, seLost := lose(prFid, &prPr), lose(seFid, &sePr)
+			event(COMBATCROWD, prFid, seFid, prLost, seLost, prPr, sePr)
+			shLost := lose(shFid, &shSize)
+			event(COMBATCROWD, shFid, 0, shLost, 0, shSize, 0)
+		}
+		if !prPeace || !sePeace {
+			if prPeace && !sePeace {
+				betrayedF(prFid, seFid)
+				lost := lose(prFid, &prPr)
+				event(COMBATBETRAY, seFid, prFid, 0, lost, sePr, prPr)
+			} else if sePeace && !prPeace {
+				betrayedF(seFid, prFid)
+				lost := lose(seFid, &sePr)
+				event(COMBATBETRAY, prFid, seFid, 0, lost, prPr, sePr)
+			}
+			fought := prPr
+			if sePr < fought {
+				fought = sePr
+			}
+			res.Rounds += fought
+			res.Losses[prFid] += fought
+			res.Losses[seFid] += fought
+			prPr, sePr = prPr-fought, sePr-fought
+			event(COMBATGROUND, prFid, seFid, fought, fought, prPr, sePr)
+			if sePr > 0 {
+				prFid, prPr, prPW = seFid, sePr, sePW
+			}
+			seFid, sePr, sePW = 0, 0, 0
+		}
+	}
+	// ------ LANDING FIGHT ---------- //
+	if shFid != 0 {
+		prShPeace := peace(prFid, shFid)
+		seShPeace := peace(seFid, shFid)
+		shSePeace := peace(shFid, seFid)
+		shPrPeace := peace(shFid, prFid)
+		if prShPeace && !shPrPeace {
+			betrayedF(prFid, shFid)
+			lost := lose(prFid, &prPr)
+			event(COMBATBETRAY, shFid, prFid, 0, lost, shSize, prPr)
+			prShPeace = false
+		} else if shPrPeace && !prShPeace {
+			betrayedF(shFid, prFid)
+			lost := lose(shFid, &shSize)
+			event(COMBATBETRAY, prFid, shFid, 0, lost, prPr, shSize)
+			shPrPeace = false
+		}
+		if seShPeace && !shSePeace {
+			betrayedF(seFid, shFid)
+			lost := lose(seFid, &sePr)
+			event(COMBATBETRAY, shFid, seFid, 0, lost, shSize, sePr)
+			seShPeace = false
+		} else if shSePeace && !seShPeace {
+			betrayedF(shFid, seFid)
+			lost := lose(shFid, &shSize)
+			event(COMBATBETRAY, seFid, shFid, 0, lost, sePr, shSize)
+			shSePeace = false
+		}
+		strike := rules.StrikeOf(fleet.Power, shSize)
+		hit := func(fid int, pres *int, peace bool) {
+			if strike < 1 || shSize < 1 || *pres < 1 || peace {
+				return
+			}
+			killed := strike
+			if killed > *pres {
+				killed = *pres
+			}
+			strike -= killed
+			*pres -= killed
+			res.Losses[fid] += killed
+			event(COMBATSTRIKE, shFid, fid, 0, killed, shSize, *pres)
+		}
+		hit(prFid, &prPr, shPrPeace)
+		hit(seFid, &sePr, shSePeace)
+		for shSize > 0 {
+			var fightLeft bool
+			if prPr > 0 && !shPrPeace {
+				fightLeft = true
+				res.Rounds += 1
+				prLost, shLost := lose(prFid, &prPr), lose(shFid, &shSize)
+				event(COMBATLAND, shFid, prFid, shLost, prLost, shSize, prPr)
+			}
+			if shSize > 0 && sePr > 0 && !shSePeace {
+				fightLeft = true
+				res.Rounds += 1
+				seLost, shLost := lose(seFid, &sePr), lose(shFid, &shSize)
+				event(COMBATLAND, shFid, seFid, shLost, seLost, shSize, sePr)
+			}
+			if !fightLeft {
+				break
+			}
+		}
+		if shSize > 0 {
+			if !shSePeace && !shPrPeace {
+				prFid, prPr, prPW = shFid, shSize, sePW
+				seFid, sePr, sePW = 0, 0, 0
+				event(COMBATTAKE, shFid, 0, 0, 0, shSize, 0)
+			} else if shSePeace {
+				prFid, prPr, prPW = shFid, shSize, 0
+				event(COMBATTAKE, shFid, 0, 0, 0, shSize, 0)
+			} else {
+				seFid, sePr, sePW = shFid, shSize, 0
+				event(COMBATJOIN, shFid, prFid, 0, 0, shSize, prPr)
+			}
+		}
+		if seFid != 0 && sePr > prPr {
+			seFid, prFid = prFid, seFid
+			sePr, prPr = prPr, sePr
+			sePW, prPW = prPW, sePW
+			event(COMBATSWAP, prFid, seFid, 0, 0, prPr, sePr)
+		}
+	}
+	for fid, lost := range res.Losses {
+		if lost == 0 {
+			delete(res.Losses, fid)
+		}
+	}
+	res.Final = CombatState{
+		PrimaryFaction:    prFid,
+		PrimaryPresence:   prPr,
+		PrimaryPower:      prPW,
+		SecondaryFaction:  seFid,
+		SecondaryPresence: sePr,
+		SecondaryPower:    sePW,
+	}
+	return res
+}
+
+// Fleet is the ships one faction lands on a planet at the same step, and
+// the power they were launched on.
+type Fleet struct {
+	FID   int
+	Size  int
+	Power int
+}
+
+// ResolveArrival settles fleets reaching a planet at the same step.  Fleets
+// not at mutual peace fight in space first, every such fleet losing one
+// each round until no two hostile fleets are left; a fleet betrayed by
+// another arriving with it loses one before the fighting starts.  The
+// survivors then land in turn, largest first, as by ResolveLanding.  A
+// faction's fleets join into one with the power most of their size was
+// launched on, antimatter winning ties.
+func ResolveArrival(rules GameRules, state CombatState, fleets []Fleet, truces TrustSet) CombatResult {
+	sizes := map[int]int{}
+	powers := map[int]map[int]int{}
+	for _, fl := range fleets {
+		if fl.FID != 0 && fl.Size > 0 {
+			sizes[fl.FID] += fl.Size
+			if powers[fl.FID] == nil {
+				powers[fl.FID] = map[int]int{}
+			}
+			powers[fl.FID][fl.Power] += fl.Size
+		}
+	}
+	merged := make([]Fleet, 0, len(sizes))
+	for fid, size := range sizes {
+		power := ANTIMATTER
+		for _, pw := range []int{0, TACHYONS} {
+			if powers[fid][pw] > powers[fid][power] {
+				power = pw
+			}
+		}
+		merged = append(merged, Fleet{fid, size, power})
+	}
+	sort.Sort(sortFleets(merged))
+	res := CombatResult{Initial: state, Final: state, Losses: map[int]int{}}
+	trust := make(TrustSet, len(truces))
+	for pair, ok := range truces {
+		trust[pair] = ok
+	}
+	peace := func(fid1, fid2 int) bool {
+		return trust.Trusts(fid1, fid2) && trust.Trusts(fid2, fid1)
+	}
+	event := func(kind int, fl *Fleet, lost int) {
+		res.Events = append(res.Events, CombatEvent{
+			Kind:   kind,
+			Sides:  [2]int{fl.FID, 0},
+			Losses: [2]int{lost, 0},
+			Left:   [2]int{fl.Size, 0},
+		})
+	}
+	// ------ SPACE FIGHT ---------- //
+	for i := range merged {
+		for j := range merged {
+			truster, betrayor := merged[i].FID, merged[j].FID
+			if i == j || !trust.Trusts(truster, betrayor) || trust.Trusts(betrayor, truster) {
+				continue
+			}
+			delete(trust, [2]int{truster, betrayor})
+			res.Betrayals = append(res.Betrayals, [2]int{betrayor, truster})
+			fl := &merged[i]
+			var lost int
+			if fl.Size > 0 {
+				lost = 1
+				fl.Size -= 1
+				res.Losses[truster] += 1
+			}
+			res.Events = append(res.Events, CombatEvent{
+				Kind:   COMBATBETRAY,
+				Sides:  [2]int{betrayor, truster},
+				Losses: [2]int{0, lost},
+				Left:   [2]int{merged[j].Size, fl.Size},
+			})
+		}
+	}
+	for {
+		var hit []int
+		for i, fl := range merged {
+			if fl.Size < 1 {
+				continue
+			}
+			for _, foe := range merged {
+				if foe.Size > 0 && foe.FID != fl.FID && !peace(fl.FID, foe.FID) {
+					hit = append(hit, i)
+					break
+				}
+			}
+		}
+		if len(hit) == 0 {
+			break
+		}
+		res.Rounds += 1
+		for _, i := range hit {
+			fl := &merged[i]
+			fl.Size -= 1
+			res.Losses[fl.FID] += 1
+			event(COMBATSPACE, fl, 1)
+		}
+	}
+	// ------ LANDINGS ---------- //
+	survivors := make([]Fleet, 0, len(merged))
+	for _, fl := range merged {
+		if fl.Size > 0 {
+			survivors = append(survivors, fl)
+		}
+	}
+	sort.Stable(sortFleetsBySize(survivors))
+	for _, fl := range survivors {
+		land := ResolveLanding(rules, res.Final, fl, trust)
+		for _, pair := range land.Betrayals {
+			delete(trust, [2]int{pair[1], pair[0]})
+		}
+		res.Final = land.Final
+		res.Rounds += land.Rounds
+		res.Events = append(res.Events, land.Events...)
+		res.Betrayals = append(res.Betrayals, land.Betrayals...)
+		for fid, lost := range land.Losses {
+			res.Losses[fid] += lost
+		}
+	}
+	return res
+}
+
+type sortFleets []Fleet
+
+func (s sortFleets) Len() int           { return len(s) }
+func (s sortFleets) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortFleets) Less(i, j int) bool { return s[i].FID < s[j].FID }
+
+type sortFleetsBySize []Fleet
+
+func (s sortFleetsBySize) Len() int           { return len(s) }
+func (s sortFleetsBySize) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortFleetsBySize) Less(i, j int) bool { return s[i].Size > s[j].Size }
@@ -0,0 +1,308 @@
+package overpower_test
+
+import (
+	"encoding/json"
+	"mule/overpower"
+	"reflect"
+	"testing"
+)
+
+func TestResolveCombat(t *testing.T) {
+	const A, T = overpower.ANTIMATTER, overpower.TACHYONS
+	held := overpower.CombatState{
+		PrimaryFaction: 1, PrimaryPresence: 4, PrimaryPower: A,
+		SecondaryFaction: 2, SecondaryPresence: 2, SecondaryPower: T,
+	}
+	for _, test := range []struct {
+		name      string
+		state     overpower.CombatState
+		shFid     int
+		shSize    int
+		truces    [][2]int
+		final     overpower.CombatState
+		losses    map[int]int
+		betrayals [][2]int
+	}{
+		{
+			name: "no truces", state: held, shFid: 3, shSize: 5,
+			final:  overpower.CombatState{PrimaryFaction: 3, PrimaryPresence: 3},
+			losses: map[int]int{1: 4, 2: 2, 3: 2},
+		},
+		{
+			name: "occupants at peace", state: held, shFid: 3, shSize: 5,
+			truces: [][2]int{{1, 2}, {2, 1}},
+			final: overpower.CombatState{
+				PrimaryFaction: 1, PrimaryPresence: 1, PrimaryPower: A,
+				SecondaryFaction: 2, SecondaryPresence: 0, SecondaryPower: T,
+			},
+			losses: map[int]int{1: 3, 2: 2, 3: 5},
+		},
+		{
+			name: "crowded", state: held, shFid: 3, shSize: 5,
+			truces: [][2]int{{1, 2}, {2, 1}, {1, 3}, {3, 1}, {2, 3}, {3, 2}},
+			final:  overpower.CombatState{PrimaryFaction: 3, PrimaryPresence: 2},
+			losses: map[int]int{1: 4, 2: 2, 3: 3},
+			betrayals: [][2]int{
+				{3, 2}, {2, 3}, {3, 1}, {1, 3}, {2, 1}, {1, 2},
+			},
+		},
+		{
+			name: "trusting ship", state: held, shFid: 3, shSize: 5,
+			truces: [][2]int{{1, 2}, {2, 1}, {3, 1}, {3, 2}},
+			final: overpower.CombatState{
+				PrimaryFaction: 1, PrimaryPresence: 2, PrimaryPower: A,
+				SecondaryFaction: 2, SecondaryPresence: 1, SecondaryPower: T,
+			},
+			losses:    map[int]int{1: 2, 2: 1, 3: 5},
+			betrayals: [][2]int{{1, 3}, {2, 3}},
+		},
+		{
+			name: "ship joins winner", state: held, shFid: 3, shSize: 5,
+			truces: [][2]int{{1, 3}, {3, 1}},
+			final: overpower.CombatState{
+				PrimaryFaction: 3, PrimaryPresence: 5,
+				SecondaryFaction: 1, SecondaryPresence: 2, SecondaryPower: A,
+			},
+			losses: map[int]int{1: 2, 2: 2},
+		},
+		{
+			name: "occupant betrayed", state: held, shFid: 3, shSize: 5,
+			truces:    [][2]int{{1, 2}},
+			final:     overpower.CombatState{PrimaryFaction: 3, PrimaryPresence: 4},
+			losses:    map[int]int{1: 4, 2: 2, 3: 1},
+			betrayals: [][2]int{{2, 1}},
+		},
+		{
+			name: "ground war", state: held,
+			final:  overpower.CombatState{PrimaryFaction: 1, PrimaryPresence: 2, PrimaryPower: A},
+			losses: map[int]int{1: 2, 2: 2},
+		},
+		{
+			name: "secondary wins ground war",
+			state: overpower.CombatState{
+				PrimaryFaction: 1, PrimaryPresence: 1, PrimaryPower: A,
+				SecondaryFaction: 2, SecondaryPresence: 3, SecondaryPower: T,
+			},
+			final:  overpower.CombatState{PrimaryFaction: 2, PrimaryPresence: 2, PrimaryPower: T},
+			losses: map[int]int{1: 1, 2: 1},
+		},
+		{
+			name: "neutral planet", state: overpower.CombatState{PrimaryPresence: 2}, shFid: 3, shSize: 3,
+			final:  overpower.CombatState{PrimaryFaction: 3, PrimaryPresence: 1},
+			losses: map[int]int{0: 2, 3: 2},
+		},
+	} {
+		set := overpower.TrustSet{}
+		for _, pair := range test.truces {
+			set[pair] = true
+		}
+		res := overpower.ResolveCombat(test.state, test.shFid, test.shSize, set)
+		if res.Final != test.final {
+			t.Errorf("%s: ended %+v, want %+v", test.name, res.Final, test.final)
+		}
+		if !reflect.DeepEqual(res.Losses, test.losses) {
+			t.Errorf("%s: losses %v, want %v", test.name, res.Losses, test.losses)
+		}
+		if len(res.Betrayals) != 0 || len(test.betrayals) != 0 {
+			if !reflect.DeepEqual(res.Betrayals, test.betrayals) {
+				t.Errorf("%s: betrayals %v, want %v", test.name, res.Betrayals, test.betrayals)
+			}
+		}
+		if len(set) != len(test.truces) {
+			t.Errorf("%s: combat changed the truces it was given", test.name)
+		}
+	}
+}
+
+// TestResolveCombatTruces fights every combination of truces between the
+// two occupants of a planet and a landing ship.
+func TestResolveCombatTruces(t *testing.T) {
+	pairs := [][2]int{{1, 2}, {2, 1}, {1, 3}, {3, 1}, {2, 3}, {3, 2}}
+	for _, sizes := range [][3]int{{4, 2, 5}, {2, 4, 3}, {1, 1, 1}, {3, 3, 9}} {
+		state := overpower.CombatState{
+			PrimaryFaction: 1, PrimaryPresence: sizes[0], PrimaryPower: overpower.ANTIMATTER,
+			SecondaryFaction: 2, SecondaryPresence: sizes[1], SecondaryPower: overpower.TACHYONS,
+		}
+		for bits := 0; bits < 1<<uint(len(pairs)); bits++ {
+			set := overpower.TrustSet{}
+			for i, pair := range pairs {
+				if bits&(1<<uint(i)) != 0 {
+					set[pair] = true
+				}
+			}
+			res := overpower.ResolveCombat(state, 3, sizes[2], set)
+			fin := res.Final
+			var lost int
+			for _, n := range res.Losses {
+				lost += n
+			}
+			if got, want := fin.PrimaryPresence+fin.SecondaryPresence, sizes[0]+sizes[1]+sizes[2]-lost; got != want {
+				t.Errorf("sizes %v truces %06b: %d presence left, want %d", sizes, bits, got, want)
+			}
+			if fin.SecondaryFaction != 0 && fin.SecondaryFaction == fin.PrimaryFaction {
+				t.Errorf("sizes %v truces %06b: faction %d holds both slots", sizes, bits, fin.PrimaryFaction)
+			}
+			if fin.SecondaryPresence > fin.PrimaryPresence {
+				t.Errorf("sizes %v truces %06b: secondary outnumbers primary: %+v", sizes, bits, fin)
+			}
+			crowded := bits == 1<<uint(len(pairs))-1
+			if crowded && len(res.Betrayals) != 6 {
+				t.Errorf("sizes %v truces %06b: crowding broke %d truces, want 6", sizes, bits, len(res.Betrayals))
+			}
+			seen := map[[2]int]bool{}
+			for _, b := range res.Betrayals {
+				truce := [2]int{b[1], b[0]}
+				if !set[truce] || seen[truce] {
+					t.Errorf("sizes %v truces %06b: bad betrayal %v", sizes, bits, b)
+				}
+				seen[truce] = true
+				if !crowded && set[[2]int{b[0], b[1]}] {
+					t.Errorf("sizes %v truces %06b: mutual truce %v broken", sizes, bits, b)
+				}
+			}
+		}
+	}
+}
+
+func TestCombatEvents(t *testing.T) {
+	state := overpower.CombatState{
+		PrimaryFaction: 1, PrimaryPresence: 4, PrimaryPower: overpower.ANTIMATTER,
+		SecondaryFaction: 2, SecondaryPresence: 2, SecondaryPower: overpower.TACHYONS,
+	}
+	res := overpower.ResolveCombat(state, 3, 5, overpower.TrustSet{{2, 3}: true})
+	want := []overpower.CombatEvent{
+		{Kind: overpower.COMBATGROUND, Sides: [2]int{1, 2}, Losses: [2]int{2, 2}, Left: [2]int{2, 0}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{4, 1}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{3, 0}},
+		{Kind: overpower.COMBATTAKE, Sides: [2]int{3, 0}, Left: [2]int{3, 0}},
+	}
+	if !reflect.DeepEqual(res.Events, want) {
+		t.Errorf("events %+v, want %+v", res.Events, want)
+	}
+	if res.Rounds != 4 {
+		t.Errorf("fought %d rounds, want 4", res.Rounds)
+	}
+	res = overpower.ResolveCombat(state, 3, 5, overpower.TrustSet{{1, 3}: true, {1, 2}: true, {2, 1}: true})
+	want = []overpower.CombatEvent{
+		{Kind: overpower.COMBATBETRAY, Sides: [2]int{3, 1}, Losses: [2]int{0, 1}, Left: [2]int{5, 3}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{4, 2}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 2}, Losses: [2]int{1, 1}, Left: [2]int{3, 1}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{2, 1}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 2}, Losses: [2]int{1, 1}, Left: [2]int{1, 0}},
+		{Kind: overpower.COMBATLAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{0, 0}},
+	}
+	if !reflect.DeepEqual(res.Events, want) {
+		t.Errorf("betrayal events %+v, want %+v", res.Events, want)
+	}
+	if back := overpower.DecodeCombatEvents(overpower.EncodeCombatEvents(want)); !reflect.DeepEqual(back, want) {
+		t.Errorf("events stored as %+v, want %+v", back, want)
+	}
+	data, err := json.Marshal(want[0])
+	if err != nil || string(data) != `{"kind":"betray","sides":[3,1],"losses":[0,1],"left":[5,3]}` {
+		t.Errorf("event marshalled to %s, %v", data, err)
+	}
+}
+
+func TestLandingEvents(t *testing.T) {
+	const (
+		BETRAY = overpower.COMBATBETRAY
+		CROWD  = overpower.COMBATCROWD
+		GROUND = overpower.COMBATGROUND
+		LAND   = overpower.COMBATLAND
+		TAKE   = overpower.COMBATTAKE
+	)
+	state := overpower.CombatState{
+		PrimaryFaction: 1, PrimaryPresence: 4, PrimaryPower: overpower.ANTIMATTER,
+		SecondaryFaction: 2, SecondaryPresence: 2, SecondaryPower: overpower.TACHYONS,
+	}
+	for _, test := range []struct {
+		name   string
+		truces overpower.TrustSet
+		want   []overpower.CombatEvent
+	}{
+		{
+			name: "crowded",
+			truces: overpower.TrustSet{
+				{1, 2}: true, {2, 1}: true, {1, 3}: true,
+				{3, 1}: true, {2, 3}: true, {3, 2}: true,
+			},
+			want: []overpower.CombatEvent{
+				{Kind: CROWD, Sides: [2]int{1, 2}, Losses: [2]int{1, 1}, Left: [2]int{3, 1}},
+				{Kind: CROWD, Sides: [2]int{3, 0}, Losses: [2]int{1, 0}, Left: [2]int{4, 0}},
+				{Kind: GROUND, Sides: [2]int{1, 2}, Losses: [2]int{1, 1}, Left: [2]int{2, 0}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{3, 1}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{2, 0}},
+				{Kind: TAKE, Sides: [2]int{3, 0}, Left: [2]int{2, 0}},
+			},
+		},
+		{
+			name:   "primary betrayed",
+			truces: overpower.TrustSet{{1, 2}: true},
+			want: []overpower.CombatEvent{
+				{Kind: BETRAY, Sides: [2]int{2, 1}, Losses: [2]int{0, 1}, Left: [2]int{2, 3}},
+				{Kind: GROUND, Sides: [2]int{1, 2}, Losses: [2]int{2, 2}, Left: [2]int{1, 0}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{4, 0}},
+				{Kind: TAKE, Sides: [2]int{3, 0}, Left: [2]int{4, 0}},
+			},
+		},
+		{
+			name:   "secondary betrayed",
+			truces: overpower.TrustSet{{2, 1}: true},
+			want: []overpower.CombatEvent{
+				{Kind: BETRAY, Sides: [2]int{1, 2}, Losses: [2]int{0, 1}, Left: [2]int{4, 1}},
+				{Kind: GROUND, Sides: [2]int{1, 2}, Losses: [2]int{1, 1}, Left: [2]int{3, 0}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{4, 2}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{3, 1}},
+				{Kind: LAND, Sides: [2]int{3, 1}, Losses: [2]int{1, 1}, Left: [2]int{2, 0}},
+				{Kind: TAKE, Sides: [2]int{3, 0}, Left: [2]int{2, 0}},
+			},
+		},
+	} {
+		res := overpower.ResolveLanding(overpower.GameRules{}, state, overpower.Fleet{FID: 3, Size: 5}, test.truces)
+		if !reflect.DeepEqual(res.Events, test.want) {
+			t.Errorf("%s: events %+v, want %+v", test.name, res.Events, test.want)
+		}
+	}
+}
+
+func TestResolveArrival(t *testing.T) {
+	neutral := overpower.CombatState{PrimaryPresence: 1}
+	fleets := []overpower.Fleet{{FID: 1, Size: 5}, {FID: 2, Size: 3}}
+	res := overpower.ResolveArrival(overpower.DefaultRules(), neutral, fleets, nil)
+	flipped := overpower.ResolveArrival(overpower.DefaultRules(), neutral, []overpower.Fleet{fleets[1], fleets[0]}, nil)
+	if !reflect.DeepEqual(res, flipped) {
+		t.Errorf("arrival order changed the fight: %+v, then %+v", res, flipped)
+	}
+	want := overpower.CombatState{PrimaryFaction: 1, PrimaryPresence: 1}
+	if res.Final != want {
+		t.Errorf("fleets left %+v, want %+v", res.Final, want)
+	}
+	if res.Losses[1] != 4 || res.Losses[2] != 3 {
+		t.Errorf("losses %v, want 4 for 1 and 3 for 2", res.Losses)
+	}
+	var space int
+	for _, ev := range res.Events {
+		if ev.Kind == overpower.COMBATSPACE {
+			space++
+		}
+	}
+	if space != 6 {
+		t.Errorf("%d space events, want 6: %+v", space, res.Events)
+	}
+	allied := overpower.ResolveArrival(overpower.DefaultRules(), neutral, fleets, overpower.TrustSet{{1, 2}: true, {2, 1}: true})
+	for _, ev := range allied.Events {
+		if ev.Kind == overpower.COMBATSPACE {
+			t.Fatalf("fleets at peace fought in space: %+v", allied.Events)
+		}
+	}
+	if allied.Losses[2] != 0 {
+		t.Errorf("allied fleet lost %d, want 0", allied.Losses[2])
+	}
+	betrayed := overpower.ResolveArrival(overpower.DefaultRules(), neutral, fleets, overpower.TrustSet{{2, 1}: true})
+	if len(betrayed.Betrayals) == 0 || betrayed.Betrayals[0] != [2]int{1, 2} {
+		t.Errorf("betrayals %v, want 1 betraying 2 first", betrayed.Betrayals)
+	}
+	if betrayed.Losses[2] != 3 || betrayed.Final.PrimaryFaction != 1 {
+		t.Errorf("betrayal left %+v with losses %v", betrayed.Final, betrayed.Losses)
+	}
+}
@@ -0,0 +1,94 @@
+package overpower
+
+import (
+	"mule/hexagon"
+)
+
+// sighting is a stretch of a ship's move seen by another faction.
+type sighting struct {
+	fid  int
+	seen []hexagon.Coord
+}
+
+// conditionCause gives the faction whose ship sets off the order this
+// turn, or 0 if its condition did not come about.  attacked holds, by
+// planet and then defending faction, the first hostile faction to land;
+// sighted holds by faction the ships it saw move.
+func conditionCause(co ConditionalOrderDat, attacked map[hexagon.Coord]map[int]int, sighted map[int][]sighting) int {
+	switch co.Kind() {
+	case CONDATTACKED:
+		return attacked[co.Loc()][co.FID()]
+	case CONDSIGHTED:
+		loc, within := co.Loc(), co.Within()
+		for _, st := range sighted[co.FID()] {
+			for _, c := range st.seen {
+				if c.StepsTo(loc) <= within {
+					return st.fid
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// markAttacked notes a ship of fid landing on pl for each occupant with
+// no truce toward fid, keeping the first attacker found.
+func markAttacked(attacked map[hexagon.Coord]map[int]int, pl PlanetDat, fid int, truces map[[2]int]TruceDat) {
+	loc := pl.Loc()
+	for _, occ := range []int{pl.PrimaryFaction(), pl.SecondaryFaction()} {
+		if occ == 0 || occ == fid || truces[[2]int{occ, fid}] != nil {
+			continue
+		}
+		if attacked[loc] == nil {
+			attacked[loc] = map[int]int{}
+		}
+		if attacked[loc][occ] == 0 {
+			attacked[loc][occ] = fid
+		}
+	}
+}
+
+// conditionLaunch pays for up to size ships, or all it can with size 0,
+// from fid's presence at src not yet launched this turn.  It gives the
+// number paid for and their power.
+func conditionLaunch(src PlanetDat, fid, size int, launched map[hexagon.Coord][2]int) (int, int) {
+	lCount := launched[src.Loc()]
+	var avail, power int
+	if fid == src.PrimaryFaction() {
+		avail, power = src.PrimaryPresence()-lCount[0], src.PrimaryPower()
+	} else if fid == src.SecondaryFaction() {
+		avail, power = src.SecondaryPresence()-lCount[1], src.SecondaryPower()
+	} else {
+		return 0, 0
+	}
+	if size < 1 || size > avail {
+		size = avail
+	}
+	switch power {
+	case TACHYONS:
+		if have := src.Tachyons(); size > have {
+			size = have
+		}
+		if size > 0 {
+			src.SetTachyons(src.Tachyons() - size)
+		}
+	case ANTIMATTER:
+		if have := src.Antimatter(); size > have {
+			size = have
+		}
+		if size > 0 {
+			src.SetAntimatter(src.Antimatter() - size)
+		}
+	default:
+		return 0, 0
+	}
+	if size < 1 {
+		return 0, 0
+	}
+	if fid == src.PrimaryFaction() {
+		launched[src.Loc()] = [2]int{lCount[0] + size, lCount[1]}
+	} else {
+		launched[src.Loc()] = [2]int{lCount[0], lCount[1] + size}
+	}
+	return size, power
+}
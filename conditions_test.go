@@ -0,0 +1,100 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestConditionalOrders(t *testing.T) {
+	s := makeTestGalaxy(t, 2)
+	home := s.HomePlanet(1)
+	target := nearestFree(s, home.Loc)
+	onAttack := s.AddConditionalOrder(&memsource.ConditionalOrder{
+		FID:    1,
+		Kind:   overpower.CONDATTACKED,
+		Loc:    home.Loc,
+		Action: overpower.CONDLAUNCH,
+		Source: home.Loc,
+		Target: target.Loc,
+	})
+	onSight := s.AddConditionalOrder(&memsource.ConditionalOrder{
+		FID:    1,
+		Kind:   overpower.CONDSIGHTED,
+		Loc:    home.Loc,
+		Within: 3,
+		Action: overpower.CONDPOWER,
+		Target: home.Loc,
+		Power:  overpower.ANTIMATTER,
+	})
+	waiting := s.AddConditionalOrder(&memsource.ConditionalOrder{
+		FID:    1,
+		Kind:   overpower.CONDATTACKED,
+		Loc:    target.Loc,
+		Action: overpower.CONDPOWER,
+		Target: home.Loc,
+		Power:  overpower.TACHYONS,
+	})
+	if onAttack.CID != 1 || onSight.CID != 2 || waiting.CID != 3 {
+		t.Fatalf("conditional orders given cids %d %d %d, want 1 2 3", onAttack.CID, onSight.CID, waiting.CID)
+	}
+	home.PrimaryPower = overpower.TACHYONS
+	turn := s.GameItem.Turn
+	s.NewShip(2, 1, 1, overpower.ANTIMATTER, turn, home.Loc.Ring(1)[0].PathTo(home.Loc))
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if !onAttack.Deleted || !onSight.Deleted {
+		t.Error("fired conditional orders were kept")
+	}
+	if waiting.Deleted {
+		t.Error("conditional order fired without its condition")
+	}
+	if home.PrimaryPower != overpower.ANTIMATTER {
+		t.Errorf("sighting left home power %d, want antimatter", home.PrimaryPower)
+	}
+	var sent int
+	for _, sh := range s.ShipList {
+		if sh.FID == 1 && sh.Path[len(sh.Path)-1] == target.Loc {
+			if sh.Launched != turn+1 {
+				t.Errorf("conditional launch leaves turn %d, want %d", sh.Launched, turn+1)
+			}
+			sent += sh.Size
+			var viewed bool
+			for _, sv := range s.ShipViewList {
+				if sv.SID == sh.SID && sv.FID == 1 && sv.Turn == turn {
+					viewed = true
+					if !sv.Loc.Valid || sv.Loc.Coord != home.Loc || !sv.Dest.Valid || sv.Dest.Coord != target.Loc {
+						t.Errorf("conditional launch seen as %+v, want at home bound for target", *sv)
+					}
+				}
+			}
+			if !viewed {
+				t.Error("conditional launch unseen by its owner on the turn it fired")
+			}
+		}
+	}
+	if sent < 1 {
+		t.Error("attack on home launched no ships")
+	}
+	if len(s.ConditionRecords) != 2 {
+		t.Fatalf("%d condition records, want 2", len(s.ConditionRecords))
+	}
+	for _, cr := range s.ConditionRecords {
+		if cr.FID != 1 || cr.Turn != turn || cr.Cause != 2 {
+			t.Errorf("condition record %+v, want faction 1 on turn %d caused by 2", *cr, turn)
+		}
+		if cr.CID == onAttack.CID && cr.Size != sent {
+			t.Errorf("condition record launched %d, want %d", cr.Size, sent)
+		}
+	}
+	if len(s.LaunchRecordList) != 1 {
+		t.Fatalf("%d launch records, want 1: %+v", len(s.LaunchRecordList), s.LaunchRecordList)
+	}
+	if lr := s.LaunchRecordList[0]; lr.FID != 1 || lr.Turn != turn || lr.Source != home.Loc || lr.Target != target.Loc || lr.Size != sent {
+		t.Errorf("launch record %+v, want %d ships from faction 1 on turn %d", *lr, sent, turn)
+	}
+	if ar := s.Archive[len(s.Archive)-1]; len(ar.Conditionals) != 3 {
+		t.Errorf("archived %d conditional orders, want 3", len(ar.Conditionals))
+	}
+}
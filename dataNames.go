@@ -5,15 +5,15 @@ import (
 	"strings"
 )
 
-func GetNames(n int) []string {
-	names := GetAdj(n)
+func GetNames(rng *rand.Rand, n int) []string {
+	names := GetAdj(rng, n)
 	for i, adj := range names {
 		names[i] = "Planet " + strings.Title(adj)
 	}
 	return names
 }
 
-func GetAdj(n int) []string {
+func GetAdj(rng *rand.Rand, n int) []string {
 	list := []string{"whispering",
 		"complex",
 		"boiling",
@@ -220,7 +220,7 @@ func GetAdj(n int) []string {
 	//if n > len(list) {
 	//	panic("Calling for more planets than we have names for!")
 	//}
-	order := rand.Perm(len(list))
+	order := rng.Perm(len(list))
 	r := make([]string, n)
 	for i, _ := range r {
 		r[i] = list[order[i]]
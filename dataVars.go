@@ -1,5 +1,7 @@
 package overpower
 
+// SHIPSPEED and VISDIST are the standard game's values; each game reads
+// its own from its GameRules.
 const (
 	SHIPSPEED   = 10
 	VISDIST     = 20
@@ -7,3 +9,18 @@ const (
 	ANTIMATTER  = 1
 	TACHYONS    = -1
 )
+
+// Treaty kinds, each covering more than the last.
+const (
+	TREATYNONAGGRESSION = 1
+	TREATYPEACE         = 2
+	TREATYALLIANCE      = 3
+)
+
+// Conditional order kinds and actions.
+const (
+	CONDATTACKED = 1
+	CONDSIGHTED  = 2
+	CONDLAUNCH   = 1
+	CONDPOWER    = 2
+)
@@ -0,0 +1,76 @@
+package overpower
+
+// GrowPlanet runs a planet's economy for a turn.  Its resources regrow
+// first, then each occupant's presence grows, primary first, paid for
+// from the resource of the occupant's power.
+func GrowPlanet(pl PlanetDat, rules GameRules) {
+	size := pl.Size()
+	if size < 1 {
+		return
+	}
+	pFid, sFid := pl.PrimaryFaction(), pl.SecondaryFaction()
+	// ------ RESOURCES REGROW ------ //
+	if rules.ResourceGrowth > 0 {
+		rate := size * rules.ResourceGrowth / 100
+		if rate < 1 {
+			rate = 1
+		}
+		limit := rules.CapResource(size)
+		regrow := func(kind, have int) int {
+			if have >= limit {
+				return have
+			}
+			gain := rate
+			if (pFid != 0 && pl.PrimaryPower() == kind) || (sFid != 0 && pl.SecondaryPower() == kind) {
+				gain *= 2
+			}
+			if have+gain > limit {
+				return limit
+			}
+			return have + gain
+		}
+		pl.SetAntimatter(regrow(ANTIMATTER, pl.Antimatter()))
+		pl.SetTachyons(regrow(TACHYONS, pl.Tachyons()))
+	}
+	// ------ PRESENCE GROWS ------ //
+	if rules.PresenceGrowth < 1 {
+		return
+	}
+	grow := func(fid, pres, power int) int {
+		if fid == 0 || pres < 1 {
+			return 0
+		}
+		gain := rules.PresenceGrowth
+		if room := size - pl.PrimaryPresence() - pl.SecondaryPresence(); gain > room {
+			gain = room
+		}
+		switch power {
+		case ANTIMATTER:
+			if have := pl.Antimatter(); gain > have {
+				gain = have
+			}
+			if gain > 0 {
+				pl.SetAntimatter(pl.Antimatter() - gain)
+			}
+		case TACHYONS:
+			if have := pl.Tachyons(); gain > have {
+				gain = have
+			}
+			if gain > 0 {
+				pl.SetTachyons(pl.Tachyons() - gain)
+			}
+		default:
+			return 0
+		}
+		if gain < 0 {
+			return 0
+		}
+		return gain
+	}
+	if gain := grow(pFid, pl.PrimaryPresence(), pl.PrimaryPower()); gain > 0 {
+		pl.SetPrimaryPresence(pl.PrimaryPresence() + gain)
+	}
+	if gain := grow(sFid, pl.SecondaryPresence(), pl.SecondaryPower()); gain > 0 {
+		pl.SetSecondaryPresence(pl.SecondaryPresence() + gain)
+	}
+}
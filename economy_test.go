@@ -0,0 +1,65 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestGrowPlanet(t *testing.T) {
+	const A, T = overpower.ANTIMATTER, overpower.TACHYONS
+	for _, test := range []struct {
+		name   string
+		rules  func(*overpower.GameRules)
+		planet memsource.Planet
+		want   memsource.Planet
+	}{
+		{
+			name:   "neutral regrows",
+			planet: memsource.Planet{Size: 10, Antimatter: 2, Tachyons: 9},
+			want:   memsource.Planet{Size: 10, Antimatter: 4, Tachyons: 10},
+		},
+		{
+			name:   "aligned resource pays for growth",
+			rules:  func(r *overpower.GameRules) { r.PresenceGrowth = 2 },
+			planet: memsource.Planet{Size: 10, PrimaryFaction: 1, PrimaryPresence: 3, PrimaryPower: A},
+			want:   memsource.Planet{Size: 10, PrimaryFaction: 1, PrimaryPresence: 5, PrimaryPower: A, Antimatter: 2, Tachyons: 2},
+		},
+		{
+			name: "full planet",
+			planet: memsource.Planet{Size: 10, Antimatter: 10, Tachyons: 10,
+				PrimaryFaction: 1, PrimaryPresence: 6, PrimaryPower: T,
+				SecondaryFaction: 2, SecondaryPresence: 4, SecondaryPower: A},
+			want: memsource.Planet{Size: 10, Antimatter: 10, Tachyons: 10,
+				PrimaryFaction: 1, PrimaryPresence: 6, PrimaryPower: T,
+				SecondaryFaction: 2, SecondaryPresence: 4, SecondaryPower: A},
+		},
+		{
+			name:   "resource cap",
+			rules:  func(r *overpower.GameRules) { r.ResourceCap = 5 },
+			planet: memsource.Planet{Size: 10, Antimatter: 4, Tachyons: 7},
+			want:   memsource.Planet{Size: 10, Antimatter: 5, Tachyons: 7},
+		},
+		{
+			name:   "no growth without resources",
+			rules:  func(r *overpower.GameRules) { r.ResourceGrowth, r.PresenceGrowth = 0, 3 },
+			planet: memsource.Planet{Size: 10, PrimaryFaction: 1, PrimaryPresence: 3, PrimaryPower: T, Antimatter: 5, Tachyons: 1},
+			want:   memsource.Planet{Size: 10, PrimaryFaction: 1, PrimaryPresence: 4, PrimaryPower: T, Antimatter: 5},
+		},
+		{
+			name:   "old planets without size",
+			planet: memsource.Planet{PrimaryFaction: 1, PrimaryPresence: 3, PrimaryPower: A, Antimatter: 1},
+			want:   memsource.Planet{PrimaryFaction: 1, PrimaryPresence: 3, PrimaryPower: A, Antimatter: 1},
+		},
+	} {
+		rules := allMechanics()
+		if test.rules != nil {
+			test.rules(&rules)
+		}
+		pl := test.planet
+		overpower.GrowPlanet(pl.Intf(), rules)
+		if pl != test.want {
+			t.Errorf("%s: grew to %+v, want %+v", test.name, pl, test.want)
+		}
+	}
+}
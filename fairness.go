@@ -0,0 +1,140 @@
+package overpower
+
+import (
+	"math/rand"
+	"mule/hexagon"
+)
+
+// fairRerolls bounds how many times a galaxy is laid out again in search of
+// one within the rules' fairness tolerance.
+const fairRerolls = 20
+
+// fairSwaps bounds how many trades of resources between planets are tried
+// to bring the fairest layout within tolerance.
+const fairSwaps = 500
+
+// Reach sums the neutral planets within reach of one home planet.
+type Reach struct {
+	Slot       int
+	Loc        hexagon.Coord
+	Planets    int
+	Antimatter int
+	Tachyons   int
+}
+
+// FairnessReport describes how evenly a galaxy's homes are placed.  Spread
+// is the widest gap between the best and worst placed homes, in percent of
+// the best, taken over planets, antimatter and tachyons in reach.
+type FairnessReport struct {
+	Distance int
+	Homes    []Reach
+	Spread   int
+}
+
+// AnalyzeLayout reports what lies within the given number of hexes of each
+// home in a layout.
+func AnalyzeLayout(plans []PlanetPlan, distance int) FairnessReport {
+	report := FairnessReport{Distance: distance}
+	for _, home := range plans {
+		if home.Home == 0 {
+			continue
+		}
+		r := Reach{Slot: home.Home, Loc: home.Loc}
+		for _, pl := range plans {
+			if pl.Home != 0 || home.Loc.StepsTo(pl.Loc) > distance {
+				continue
+			}
+			r.Planets += 1
+			r.Antimatter += pl.Antimatter
+			r.Tachyons += pl.Tachyons
+		}
+		report.Homes = append(report.Homes, r)
+	}
+	report.Spread = ReachSpread(report.Homes)
+	return report
+}
+
+// ReachSpread gives the widest gap between homes over planets, antimatter
+// and tachyons in reach, in percent of the best home's count.
+func ReachSpread(homes []Reach) int {
+	var spread int
+	for _, get := range []func(Reach) int{
+		func(r Reach) int { return r.Planets },
+		func(r Reach) int { return r.Antimatter },
+		func(r Reach) int { return r.Tachyons },
+	} {
+		if len(homes) < 2 {
+			break
+		}
+		min, max := get(homes[0]), get(homes[0])
+		for _, r := range homes[1:] {
+			if x := get(r); x < min {
+				min = x
+			} else if x > max {
+				max = x
+			}
+		}
+		if max > 0 && (max-min)*100/max > spread {
+			spread = (max - min) * 100 / max
+		}
+	}
+	return spread
+}
+
+// fairLayout lays out a galaxy, laying it out again while the homes' spread
+// is over the rules' tolerance.  The fairest layout it made is kept, and
+// if still over tolerance has resources traded between its planets.
+func fairLayout(gen GalaxyGenerator, rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, FairnessReport, error) {
+	var best []PlanetPlan
+	var bestReport FairnessReport
+	for try := 0; try <= fairRerolls; try++ {
+		plans, err := gen.Layout(rules, players, rng)
+		if err != nil {
+			return nil, bestReport, err
+		}
+		if err := CheckLayout(plans, players); err != nil {
+			return nil, bestReport, err
+		}
+		report := AnalyzeLayout(plans, rules.FairTurns*rules.ShipSpeed)
+		if best == nil || report.Spread < bestReport.Spread {
+			best, bestReport = plans, report
+		}
+		if rules.FairTolerance == 0 || report.Spread <= rules.FairTolerance {
+			return best, bestReport, nil
+		}
+	}
+	return best, swapResources(best, bestReport, rules.FairTolerance, rng), nil
+}
+
+// swapResources trades the antimatter and tachyons of random pairs of
+// neutral planets, keeping each trade that narrows the spread, until the
+// spread is within tolerance or fairSwaps trades have been tried.  Only
+// planets sized by their resources trade, so no planet's size is left
+// out of step with what it holds.
+func swapResources(plans []PlanetPlan, report FairnessReport, tolerance int, rng *rand.Rand) FairnessReport {
+	var neutral []int
+	for i, pl := range plans {
+		if pl.Home == 0 && pl.Size == 0 {
+			neutral = append(neutral, i)
+		}
+	}
+	if len(neutral) < 2 {
+		return report
+	}
+	for try := 0; try < fairSwaps && report.Spread > tolerance; try++ {
+		a, b := &plans[neutral[rng.Intn(len(neutral))]], &plans[neutral[rng.Intn(len(neutral))]]
+		if a == b {
+			continue
+		}
+		a.Antimatter, b.Antimatter = b.Antimatter, a.Antimatter
+		a.Tachyons, b.Tachyons = b.Tachyons, a.Tachyons
+		next := AnalyzeLayout(plans, report.Distance)
+		if next.Spread < report.Spread {
+			report = next
+		} else {
+			a.Antimatter, b.Antimatter = b.Antimatter, a.Antimatter
+			a.Tachyons, b.Tachyons = b.Tachyons, a.Tachyons
+		}
+	}
+	return report
+}
@@ -0,0 +1,67 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeLayout(t *testing.T) {
+	plans := []overpower.PlanetPlan{
+		{Loc: hexagon.Coord{0, 0}, Antimatter: 15, Tachyons: 15},
+		{Loc: hexagon.Coord{10, 0}, Home: 1, Presence: 5},
+		{Loc: hexagon.Coord{-10, 0}, Home: 2, Presence: 5},
+		{Loc: hexagon.Coord{12, 0}, Antimatter: 4, Tachyons: 2},
+		{Loc: hexagon.Coord{-13, 0}, Antimatter: 2, Tachyons: 2},
+	}
+	report := overpower.AnalyzeLayout(plans, 5)
+	want := []overpower.Reach{
+		{Slot: 1, Loc: hexagon.Coord{10, 0}, Planets: 1, Antimatter: 4, Tachyons: 2},
+		{Slot: 2, Loc: hexagon.Coord{-10, 0}, Planets: 1, Antimatter: 2, Tachyons: 2},
+	}
+	if !reflect.DeepEqual(report.Homes, want) {
+		t.Errorf("reach %+v, want %+v", report.Homes, want)
+	}
+	if report.Spread != 50 {
+		t.Errorf("spread %d, want 50", report.Spread)
+	}
+	// with Borion in reach antimatter differs by 19 to 17
+	if got := overpower.AnalyzeLayout(plans, 10).Spread; got != 10 {
+		t.Errorf("spread with Borion in reach %d, want 10", got)
+	}
+}
+
+func TestFairGalaxies(t *testing.T) {
+	spread := func(tolerance int) int {
+		var total int
+		for seed := int64(1); seed <= 10; seed++ {
+			s := memsource.New(1)
+			s.GameItem.Seed = seed
+			s.GameItem.Rules.FairTolerance = tolerance
+			for i := 0; i < 3; i++ {
+				s.AddFaction("tester", "Faction")
+			}
+			if err := overpower.MakeGalaxy(s, "standard"); err != nil {
+				t.Fatal("make galaxy failed:", err)
+			}
+			if got := len(s.HomeReachList); got != 3 {
+				t.Fatalf("seed %d: %d home reaches recorded, want 3", seed, got)
+			}
+			var homes []overpower.Reach
+			for _, hr := range s.HomeReachList {
+				home := s.HomePlanet(hr.FID)
+				if home == nil || home.Loc != hr.Loc {
+					t.Errorf("seed %d: reach for faction %d not at its home", seed, hr.FID)
+				}
+				homes = append(homes, overpower.Reach{Planets: hr.Planets, Antimatter: hr.Antimatter, Tachyons: hr.Tachyons})
+			}
+			total += overpower.ReachSpread(homes)
+		}
+		return total
+	}
+	if loose, tight := spread(0), spread(30); tight >= loose {
+		t.Errorf("balanced galaxies spread %d in total, unbalanced %d", tight, loose)
+	}
+}
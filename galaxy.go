@@ -1,12 +1,39 @@
 package overpower
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"mule/hexagon"
+	"sort"
 )
 
-func MakeGalaxy(source Source, exodus bool) error {
+// MakeGalaxy builds the starting map for a game with the named generator,
+// using randomness drawn from the game's seed.
+func MakeGalaxy(source Source, generator string) error {
+	return MakeGalaxyRand(source, generator, nil)
+}
+
+// MakeGalaxyRand builds the starting map drawing all randomness from rng.
+// A nil rng uses TurnRand(game.Seed(), 0).
+func MakeGalaxyRand(source Source, generator string, rng *rand.Rand) error {
+	game, err := source.Game()
+	if my, bad := Check(err, "make galaxy resource failure"); bad {
+		return my
+	}
+	if rng == nil {
+		rng = TurnRand(game.Seed(), 0)
+	}
+	rules := game.Rules()
+	if err := rules.Validate(); err != nil {
+		my, _ := Check(err, "make galaxy bad rules", "rules", rules)
+		return my
+	}
+	gen, ok := GetGenerator(generator)
+	if !ok {
+		my, _ := Check(ErrBadArgs, "make galaxy unknown generator", "generator", generator)
+		return my
+	}
 	factions, err := source.Factions()
 	if my, bad := Check(err, "make galaxy resource failure"); bad {
 		return my
@@ -14,44 +41,95 @@ func MakeGalaxy(source Source, exodus bool) error {
 	if len(factions) < 1 {
 		return ErrBadArgs
 	}
+	sort.Sort(sortFactions(factions))
 	fids := make([]int, len(factions))
 	for i, f := range factions {
 		fids[i] = f.FID()
 		f.SetDoneBuffer(0)
 		f.SetScore(1)
 	}
-	// TESTING //
-	//fids = []int{fids[0], 0, 0, 0, 0,
-	//0, 0, 0, 0, 0}
-	// TESTING //
-	fids = shuffleInts(fids)
+	fids = shuffleInts(rng, fids)
 	// -------- GAME ---------- //
-	game, err := source.Game()
-	if my, bad := Check(err, "make galaxy resource failure"); bad {
-		return my
-	}
+	exodus := generator == "exodus"
 	game.SetTurn(1)
+	game.SetGenerator(generator)
+	game.SetExodus(exodus)
+	game.SetSeats(fids)
+	game.SetQuits(make([]int, len(fids)))
 	// -------- PLANETS -------- //
-	homes := len(fids)
-	bigPerPlayer := 3
-	littlePerPlayer := 12
+	plans, report, err := fairLayout(gen, rules, len(fids), rng)
+	if my, bad := Check(err, "make galaxy layout failure", "generator", generator); bad {
+		return my
+	}
+	planets := make([]PlanetDat, 0, len(plans))
+	for _, plan := range plans {
+		var fid int
+		if plan.Home > 0 {
+			fid = fids[plan.Home-1]
+		}
+		size := plan.Size
+		if size == 0 {
+			size = plan.Antimatter
+			if plan.Tachyons > size {
+				size = plan.Tachyons
+			}
+		}
+		p := source.NewPlanet(plan.Name,
+			fid, plan.Presence, plan.Power,
+			0, 0, 0,
+			plan.Antimatter, plan.Tachyons, size,
+			plan.Loc,
+		)
+		p.SetRadar(rules.RadarOf(p))
+		planets = append(planets, p)
+		if fid == 0 {
+			continue
+		}
+		source.NewMapView(fid, plan.Loc)
+	}
+	for _, r := range report.Homes {
+		source.NewHomeReach(fids[r.Slot-1], r.Loc, report.Distance, r.Planets, r.Antimatter, r.Tachyons)
+	}
+	// -------- VIEWS --------- //
+	for _, fid := range fids {
+		for _, p := range planets {
+			source.NewPlanetView(fid, p, exodus)
+		}
+	}
+	return nil
+}
+
+// RecordQuit notes on the game's seats that the faction quit this turn, so
+// a replay can take it out of the galaxy at the same point.
+func RecordQuit(game GameDat, fid int) {
+	seats, quits := game.Seats(), game.Quits()
+	for i, seat := range seats {
+		if seat != fid || i >= len(quits) {
+			continue
+		}
+		list := make([]int, len(quits))
+		copy(list, quits)
+		list[i] = game.Turn()
+		game.SetQuits(list)
+	}
+}
+
+// standardGenerator is the original layout: a crowded core of rich planets
+// round Planet Borion, poorer planets dealt out by sector beyond it, and
+// homes in the outer ring, or near the core for exodus games.
+type standardGenerator struct {
+	exodus bool
+}
+
+func (g standardGenerator) Layout(rules GameRules, homes int, rng *rand.Rand) ([]PlanetPlan, error) {
+	exodus := g.exodus
+	bigPerPlayer := rules.BigPerPlayer
+	littlePerPlayer := rules.LittlePerPlayer
 	bigN := homes * bigPerPlayer
 	littleN := homes * littlePerPlayer
-	names := GetNames(bigN + littleN + homes)
-	// ------- //
+	l := newLayout(rng, bigN+littleN+homes)
 	// ---- BORION ---- //
-	borion := source.NewPlanet("Planet Borion",
-		0, 10, 0,
-		0, 0, 0,
-		15, 15,
-		hexagon.Coord{0, 0},
-	)
-	planets := []PlanetDat{borion}
-	places := map[hexagon.Coord]bool{hexagon.Coord{0, 0}: true}
-	for _, pt := range borion.Loc().Ring(1) {
-		places[pt] = true
-	}
-	nameCount := 0
+	l.addBorion(rules)
 	// ------------------ INNER PLANETS ------------------ //
 	//area := bigN * HexArea(10)
 	area := bigN * hexagon.HexArea(7)
@@ -61,36 +139,25 @@ func MakeGalaxy(source Source, exodus bool) error {
 	}
 	//Ping("INNER RAD:", bigRadius)
 	for i := 0; i < bigN; i++ {
-		name := names[nameCount]
-		nameCount += 1
-		pres := pick(5)
-		anti := 4 + pick(5)
-		tach := 4 + pick(5)
-		var spot hexagon.Coord
-		for {
-			testP := hexagon.Polar{pick(bigRadius), 0}
-			//Ping("TEST SPOT RAD:", testP[0])
-			//Ping("THETA OUT OF:", testP[0]*6)
-			testP[1] = rand.Intn(testP[0] * 6)
-			//Ping("THETA", testP[1])
-			test := testP.Coord()
-			if _, ok := places[test]; !ok {
-				//Ping("AVAIL!")
-				spot = test
-				break
-			}
-		}
-		places[spot] = true
-		for _, pt := range spot.Ring(1) {
-			places[pt] = true
+		pres := pick(rng, 5)
+		anti := rules.CapResource(4 + pick(rng, 5))
+		tach := rules.CapResource(4 + pick(rng, 5))
+		spot, ok := l.place(rng, func() hexagon.Coord {
+			testP := hexagon.Polar{pick(rng, bigRadius), 0}
+			testP[1] = rng.Intn(testP[0] * 6)
+			return testP.Coord()
+		}, distRange(1, bigRadius), func(dist int) []hexagon.Coord {
+			return sectorRing(dist, 0, 1)
+		})
+		if !ok {
+			return nil, fmt.Errorf("no free hex for inner planet %d of %d within %d of the center", i+1, bigN, bigRadius)
 		}
-		p := source.NewPlanet(name,
-			0, pres, 0,
-			0, 0, 0,
-			anti, tach,
-			spot,
-		)
-		planets = append(planets, p)
+		l.add(PlanetPlan{
+			Loc:        spot,
+			Presence:   pres,
+			Antimatter: anti,
+			Tachyons:   tach,
+		})
 	}
 	// ------------------ OUTER PLANETS ------------------ //
 	//allArea := (littleN * HexArea(20)) + area
@@ -99,43 +166,28 @@ func MakeGalaxy(source Source, exodus bool) error {
 	for ; hexagon.HexArea(maxRadius) < allArea; maxRadius += 1 {
 	}
 	//Ping("OUTER RAD", maxRadius)
-	//Ping("PICKING", bigRadius, "PLUS", maxRadius-bigRadius)
 	for i := 0; i < homes; i++ {
 		for j := 0; j < littlePerPlayer; j++ {
-			name := names[nameCount]
-			nameCount += 1
-			pres := 0
-			anti := 1 + pick(5)
-			tach := 1 + pick(5)
-			var spot hexagon.Coord
-			for {
-				dist := bigRadius + pick(maxRadius-bigRadius)
-				//Ping("TEST DIST", dist)
+			anti := rules.CapResource(1 + pick(rng, 5))
+			tach := rules.CapResource(1 + pick(rng, 5))
+			spot, ok := l.place(rng, func() hexagon.Coord {
+				dist := bigRadius + pick(rng, maxRadius-bigRadius)
 				hexRange := float64(dist*6) / float64(homes)
-				//Ping("HEX RANGE:", hexRange)
-				//Ping("PICKING HEX RANGE:", hexRange*float64(i), "PLUS", hexRange)
-				hexFloat := RandF(hexRange) + hexRange*float64(i)
+				hexFloat := RandF(rng, hexRange) + hexRange*float64(i)
 				hexInt := int(math.Floor(hexFloat))
-				//Ping("PICKED", hexFloat, hexInt)
 				testP := hexagon.Polar{dist, hexInt}
-				test := testP.Coord()
-				if _, ok := places[test]; !ok {
-					//Ping("AVAIL")
-					spot = test
-					break
-				}
+				return testP.Coord()
+			}, distRange(bigRadius+1, maxRadius), func(dist int) []hexagon.Coord {
+				return sectorRing(dist, float64(i)/float64(homes), float64(i+1)/float64(homes))
+			})
+			if !ok {
+				return nil, fmt.Errorf("no free hex for outer planet %d of sector %d between %d and %d from the center", j+1, i+1, bigRadius+1, maxRadius)
 			}
-			places[spot] = true
-			for _, pt := range spot.Ring(1) {
-				places[pt] = true
-			}
-			p := source.NewPlanet(name,
-				0, pres, 0,
-				0, 0, 0,
-				anti, tach,
-				spot,
-			)
-			planets = append(planets, p)
+			l.add(PlanetPlan{
+				Loc:        spot,
+				Antimatter: anti,
+				Tachyons:   tach,
+			})
 		}
 	}
 	// ------------------ HOME PLANETS ------------------ //
@@ -149,58 +201,29 @@ func MakeGalaxy(source Source, exodus bool) error {
 		homeRadiusStart = (3 * maxRadius) / 5
 		homeRadiusEnd = (4 * maxRadius) / 5
 	}
+	if homeRadiusEnd <= homeRadiusStart {
+		homeRadiusEnd = homeRadiusStart + 1
+	}
 
-	for i, fid := range fids {
-		name := names[nameCount]
-		nameCount += 1
-		var spot hexagon.Coord
-		for {
-			dist := homeRadiusStart + pick(homeRadiusEnd-homeRadiusStart)
+	for i := 0; i < homes; i++ {
+		spot, ok := l.place(rng, func() hexagon.Coord {
+			dist := homeRadiusStart + pick(rng, homeRadiusEnd-homeRadiusStart)
 			hexRange := float64(dist*6) / float64(homes)
-			hexFloat := RandF(hexRange*.5) + hexRange*(float64(i)+.25)
+			hexFloat := RandF(rng, hexRange*.5) + hexRange*(float64(i)+.25)
 			hexInt := int(math.Floor(hexFloat))
 			testP := hexagon.Polar{dist, hexInt}
-			test := testP.Coord()
-			if _, ok := places[test]; !ok {
-				spot = test
-				break
-			}
-		}
-		places[spot] = true
-		for _, pt := range spot.Ring(1) {
-			places[pt] = true
+			return testP.Coord()
+		}, distRange(homeRadiusStart+1, homeRadiusEnd), func(dist int) []hexagon.Coord {
+			return sectorRing(dist, (float64(i)+.25)/float64(homes), (float64(i)+.75)/float64(homes))
+		})
+		if !ok {
+			return nil, fmt.Errorf("no free hex for home %d of %d between %d and %d from the center", i+1, homes, homeRadiusStart+1, homeRadiusEnd)
 		}
 		upD := 1
-		anti := 10
-		tach := 10
-		if coin() {
+		if coin(rng) {
 			upD = -1
 		}
-		p := source.NewPlanet(name,
-			fid, 5, upD,
-			0, 0, 0,
-			anti, tach,
-			spot,
-		)
-		planets = append(planets, p)
-		// TESTING //
-		if fid == 0 {
-			continue
-		}
-		// TESTING //
-		source.NewMapView(fid, spot)
-		source.NewPowerOrder(fid, p)
+		l.add(l.home(rules, i+1, spot, upD))
 	}
-	// -------- VIEWS --------- //
-	for _, fid := range fids {
-		// TESTING //
-		if fid == 0 {
-			continue
-		}
-		// TESTING //
-		for _, p := range planets {
-			source.NewPlanetView(fid, p, exodus)
-		}
-	}
-	return nil
+	return l.plans, nil
 }
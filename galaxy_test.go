@@ -0,0 +1,90 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+// makeTestGalaxy makes a standard galaxy from seed 1.
+func makeTestGalaxy(t testing.TB, players int) *memsource.Source {
+	return makeGalaxy(t, players, 1, "standard")
+}
+
+// makeGalaxy makes a galaxy with the named generator for a game of the
+// given players and seed, played to 100 planets.
+func makeGalaxy(t testing.TB, players int, seed int64, generator string) *memsource.Source {
+	return makeRulesGalaxy(t, overpower.DefaultRules(), players, seed, generator)
+}
+
+// makeRulesGalaxy is makeGalaxy for a game played by the given rules.
+func makeRulesGalaxy(t testing.TB, rules overpower.GameRules, players int, seed int64, generator string) *memsource.Source {
+	s := memsource.New(1)
+	s.GameItem.ToWin = 100
+	s.GameItem.Seed = seed
+	s.GameItem.Rules = rules
+	for i := 0; i < players; i++ {
+		s.AddFaction("tester", "Faction")
+	}
+	if err := overpower.MakeGalaxy(s, generator); err != nil {
+		t.Fatalf("make %s galaxy for %d players failed: %s", generator, players, err)
+	}
+	return s
+}
+
+// allMechanics gives the default rules with every mechanic games opt in
+// to turned on.
+func allMechanics() overpower.GameRules {
+	r := overpower.DefaultRules()
+	r.CombatMode = overpower.SIMULTANEOUS
+	r.PresenceGrowth, r.ResourceGrowth = 1, 20
+	r.TachyonSpeed, r.AntimatterStrike = 2, 25
+	r.RadarPresence, r.TachyonRadar = 20, 4
+	r.StealthSize, r.StealthRange = 3, 50
+	return r
+}
+
+// nearestFree gives the unheld, empty planet nearest from.
+func nearestFree(s *memsource.Source, from hexagon.Coord) *memsource.Planet {
+	var best *memsource.Planet
+	for _, pl := range s.PlanetList {
+		if pl.PrimaryFaction != 0 || pl.PrimaryPresence != 0 {
+			continue
+		}
+		if best == nil || from.StepsTo(pl.Loc) < from.StepsTo(best.Loc) {
+			best = pl
+		}
+	}
+	return best
+}
+
+func TestMakeGalaxy(t *testing.T) {
+	for players := 1; players < 9; players++ {
+		s := makeTestGalaxy(t, players)
+		if got, want := len(s.PlanetList), 1+16*players; got != want {
+			t.Errorf("%d players: made %d planets, want %d", players, got, want)
+		}
+		locs := map[hexagon.Coord]bool{}
+		for _, pl := range s.PlanetList {
+			if locs[pl.Loc] {
+				t.Errorf("%d players: two planets at %v", players, pl.Loc)
+			}
+			locs[pl.Loc] = true
+		}
+		for _, f := range s.FactionList {
+			if s.HomePlanet(f.FID) == nil {
+				t.Errorf("%d players: faction %d has no home planet", players, f.FID)
+			}
+			if n := len(s.PowerOrdersFor(f.FID)); n != 0 {
+				t.Errorf("%d players: faction %d starts with %d power orders", players, f.FID, n)
+			}
+		}
+		if got, want := len(s.PlanetViewList), players*len(s.PlanetList); got != want {
+			t.Errorf("%d players: made %d planetviews, want %d", players, got, want)
+		}
+		if got := s.GameItem.Turn; got != 1 {
+			t.Errorf("%d players: game starts on turn %d", players, got)
+		}
+	}
+}
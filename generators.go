@@ -0,0 +1,543 @@
+package overpower
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"mule/hexagon"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PlanetPlan is one planet of a galaxy layout.  Home is the 1-based slot
+// of the player starting there, or 0 for a neutral planet; MakeGalaxy deals
+// the slots out to factions at random.  A Size of 0 takes the larger of the
+// planet's starting antimatter and tachyons.
+type PlanetPlan struct {
+	Name       string        `json:"name"`
+	Loc        hexagon.Coord `json:"loc"`
+	Home       int           `json:"home"`
+	Presence   int           `json:"presence"`
+	Power      int           `json:"power"`
+	Antimatter int           `json:"antimatter"`
+	Tachyons   int           `json:"tachyons"`
+	Size       int           `json:"size"`
+}
+
+// GalaxyGenerator lays out the planets of a new galaxy.
+type GalaxyGenerator interface {
+	// Layout places the planets for the given number of players, drawing
+	// all randomness from rng.  Every slot from 1 to players needs exactly
+	// one home.
+	Layout(rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, error)
+}
+
+var generators = map[string]GalaxyGenerator{
+	"standard": standardGenerator{},
+	"exodus":   standardGenerator{exodus: true},
+	"mirrored": mirroredGenerator{},
+	"spiral":   spiralGenerator{},
+	"islands":  islandGenerator{},
+}
+
+// RegisterGenerator makes gen available under the given name.  It is meant
+// to be called at startup, before any galaxies are made.
+func RegisterGenerator(name string, gen GalaxyGenerator) {
+	generators[name] = gen
+}
+
+func GetGenerator(name string) (GalaxyGenerator, bool) {
+	gen, ok := generators[name]
+	return gen, ok
+}
+
+// GeneratorNames lists the registered generators in alphabetical order.
+func GeneratorNames() []string {
+	names := make([]string, 0, len(generators))
+	for name, _ := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GameGenerator gives the name of the generator a game was made with.
+// Games from before generators were recorded only noted exodus.
+func GameGenerator(g GameGet) string {
+	if g.Exodus() {
+		return "exodus"
+	}
+	if g.Generator() == "" {
+		return "standard"
+	}
+	return g.Generator()
+}
+
+// CheckLayout gives an error describing the first problem with a layout
+// for the given number of players, or nil.
+func CheckLayout(plans []PlanetPlan, players int) error {
+	homes := make([]int, players+1)
+	locs := map[hexagon.Coord]bool{}
+	for _, plan := range plans {
+		if locs[plan.Loc] {
+			return fmt.Errorf("two planets at %v", plan.Loc)
+		}
+		locs[plan.Loc] = true
+		if plan.Home < 0 || plan.Home > players {
+			return fmt.Errorf("planet at %v is home to slot %d of %d", plan.Loc, plan.Home, players)
+		}
+		homes[plan.Home] += 1
+		if plan.Home > 0 && plan.Presence < 1 {
+			return fmt.Errorf("home planet at %v has no presence", plan.Loc)
+		}
+		if plan.Home > 0 && plan.Power == 0 {
+			return fmt.Errorf("home planet at %v has no power", plan.Loc)
+		}
+		if plan.Presence < 0 || plan.Antimatter < 0 || plan.Tachyons < 0 || plan.Size < 0 {
+			return fmt.Errorf("planet at %v has negative presence, resources or size", plan.Loc)
+		}
+	}
+	for slot := 1; slot <= players; slot++ {
+		if homes[slot] != 1 {
+			return fmt.Errorf("slot %d has %d home planets", slot, homes[slot])
+		}
+	}
+	return nil
+}
+
+// layout collects planet plans, keeping each planet clear of the hexes
+// next to every other.
+type layout struct {
+	plans  []PlanetPlan
+	places map[hexagon.Coord]bool
+	names  []string
+}
+
+func newLayout(rng *rand.Rand, count int) *layout {
+	return &layout{
+		places: map[hexagon.Coord]bool{},
+		names:  GetNames(rng, count),
+	}
+}
+
+// add records a plan, naming it from the layout's names if it has no name.
+func (l *layout) add(plan PlanetPlan) {
+	if plan.Name == "" {
+		plan.Name = l.names[0]
+		l.names = l.names[1:]
+	}
+	l.plans = append(l.plans, plan)
+	l.places[plan.Loc] = true
+	for _, pt := range plan.Loc.Ring(1) {
+		l.places[pt] = true
+	}
+}
+
+func (l *layout) addBorion(rules GameRules) {
+	l.add(PlanetPlan{
+		Name:       "Planet Borion",
+		Presence:   10,
+		Antimatter: rules.CapResource(15),
+		Tachyons:   rules.CapResource(15),
+	})
+}
+
+func (l *layout) home(rules GameRules, slot int, loc hexagon.Coord, power int) PlanetPlan {
+	return PlanetPlan{
+		Loc:        loc,
+		Home:       slot,
+		Presence:   rules.HomePresence,
+		Power:      power,
+		Antimatter: rules.HomeAntimatter,
+		Tachyons:   rules.HomeTachyons,
+	}
+}
+
+// fits reports whether planets could go at all the given spots, which must
+// also keep clear of each other.
+func (l *layout) fits(spots []hexagon.Coord) bool {
+	for i, spot := range spots {
+		if l.places[spot] {
+			return false
+		}
+		for _, other := range spots[:i] {
+			if spot.StepsTo(other) < 2 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// placeTries bounds the random spots tried for any one planet before the
+// free hexes are searched in full.
+const placeTries = 1000
+
+// place finds a free hex for a planet.  Up to placeTries spots from probe
+// are tried first; after that the free hexes ring gives for each distance
+// in dists are considered, a distance at a time in random order, so a
+// crowded galaxy gives up rather than searching forever.
+func (l *layout) place(rng *rand.Rand, probe func() hexagon.Coord, dists []int, ring func(dist int) []hexagon.Coord) (hexagon.Coord, bool) {
+	for try := 0; try < placeTries; try++ {
+		if spot := probe(); !l.places[spot] {
+			return spot, true
+		}
+	}
+	dists = append([]int(nil), dists...)
+	for len(dists) > 0 {
+		k := rng.Intn(len(dists))
+		var free []hexagon.Coord
+		for _, spot := range ring(dists[k]) {
+			if !l.places[spot] {
+				free = append(free, spot)
+			}
+		}
+		if len(free) > 0 {
+			return free[rng.Intn(len(free))], true
+		}
+		dists = append(dists[:k], dists[k+1:]...)
+	}
+	return hexagon.Coord{}, false
+}
+
+// distRange lists the distances from min to max.
+func distRange(min, max int) []int {
+	var list []int
+	for d := min; d <= max; d++ {
+		list = append(list, d)
+	}
+	return list
+}
+
+// sectorRing lists the hexes at the given distance from the center lying
+// between the given fractions of a full turn round the ring.
+func sectorRing(dist int, from, to float64) []hexagon.Coord {
+	ring := dist * 6
+	var list []hexagon.Coord
+	for theta := 0; theta < ring; theta++ {
+		if float64(theta+1) > from*float64(ring) && float64(theta) < to*float64(ring) {
+			list = append(list, hexagon.Polar{dist, theta}.Coord())
+		}
+	}
+	return list
+}
+
+// galaxyRadii gives the radius of the core holding the inner planets and of
+// the whole galaxy, sized as the standard generator sizes them.
+func galaxyRadii(rules GameRules, players int) (bigRadius, maxRadius int) {
+	area := players * rules.BigPerPlayer * hexagon.HexArea(7)
+	bigRadius = 2
+	for ; hexagon.HexArea(bigRadius) < area; bigRadius += 1 {
+	}
+	allArea := area + players*rules.LittlePerPlayer*hexagon.HexArea(10)
+	maxRadius = bigRadius + 1
+	for ; hexagon.HexArea(maxRadius) < allArea; maxRadius += 1 {
+	}
+	return bigRadius, maxRadius
+}
+
+// ringSpot gives the hex at the given distance from the center, the given
+// fraction of a full turn round from the start of the ring.
+func ringSpot(dist int, turn float64) hexagon.Coord {
+	if dist < 1 {
+		return hexagon.Coord{0, 0}
+	}
+	ring := dist * 6
+	theta := int(math.Floor(turn*float64(ring))) % ring
+	if theta < 0 {
+		theta += ring
+	}
+	return hexagon.Polar{dist, theta}.Coord()
+}
+
+func innerPlan(rules GameRules, rng *rand.Rand) PlanetPlan {
+	return PlanetPlan{
+		Presence:   pick(rng, 5),
+		Antimatter: rules.CapResource(4 + pick(rng, 5)),
+		Tachyons:   rules.CapResource(4 + pick(rng, 5)),
+	}
+}
+
+func outerPlan(rules GameRules, rng *rand.Rand) PlanetPlan {
+	return PlanetPlan{
+		Antimatter: rules.CapResource(1 + pick(rng, 5)),
+		Tachyons:   rules.CapResource(1 + pick(rng, 5)),
+	}
+}
+
+// mirroredGenerator gives every player an identical wedge of the galaxy:
+// each planet is rolled once and copied into every wedge at the same
+// distance from the center, so no start is richer than another.
+type mirroredGenerator struct{}
+
+func (mirroredGenerator) Layout(rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, error) {
+	l := newLayout(rng, players*rules.PlanetsPerPlayer())
+	l.addBorion(rules)
+	bigRadius, maxRadius := galaxyRadii(rules, players)
+	// wedges gives the spots for copies of a planet at the given distance,
+	// the given fraction of the way round each player's wedge
+	wedges := func(dist int, offset float64) []hexagon.Coord {
+		spots := make([]hexagon.Coord, players)
+		for k := range spots {
+			spots[k] = ringSpot(dist, (float64(k)+offset)/float64(players))
+		}
+		return spots
+	}
+	place := func(plan PlanetPlan, minDist, maxDist int, home bool) error {
+		var found []hexagon.Coord
+		for try := 0; try < placeTries && found == nil; try++ {
+			spots := wedges(minDist+rng.Intn(maxDist-minDist+1), rng.Float64())
+			if l.fits(spots) {
+				found = spots
+			}
+		}
+		if found == nil {
+			// try every start within the first wedge
+			var options [][]hexagon.Coord
+			for dist := minDist; dist <= maxDist; dist++ {
+				ring := dist * 6
+				for theta := 0; theta*players < ring; theta++ {
+					spots := wedges(dist, (float64(theta)+.5)*float64(players)/float64(ring))
+					if l.fits(spots) {
+						options = append(options, spots)
+					}
+				}
+			}
+			if len(options) == 0 {
+				return fmt.Errorf("mirrored galaxy: no room for %d matching planets between %d and %d from the center", players, minDist, maxDist)
+			}
+			found = options[rng.Intn(len(options))]
+		}
+		for k, spot := range found {
+			p := plan
+			p.Loc = spot
+			if home {
+				p.Home = k + 1
+			}
+			l.add(p)
+		}
+		return nil
+	}
+	for i := 0; i < rules.BigPerPlayer; i++ {
+		if err := place(innerPlan(rules, rng), 2, bigRadius, false); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < rules.LittlePerPlayer; i++ {
+		if err := place(outerPlan(rules, rng), bigRadius+1, maxRadius, false); err != nil {
+			return nil, err
+		}
+	}
+	upD := 1
+	if coin(rng) {
+		upD = -1
+	}
+	home := l.home(rules, 0, hexagon.Coord{}, upD)
+	if err := place(home, (3*maxRadius)/5, (4*maxRadius)/5, true); err != nil {
+		return nil, err
+	}
+	return l.plans, nil
+}
+
+// spiralGenerator strings the planets along one spiral arm per player,
+// wound a quarter turn out from Planet Borion, with the rich planets on the inner half of
+// each arm and the player's home near its end.
+type spiralGenerator struct{}
+
+func (spiralGenerator) Layout(rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, error) {
+	l := newLayout(rng, players*rules.PlanetsPerPlayer())
+	l.addBorion(rules)
+	_, maxRadius := galaxyRadii(rules, players)
+	armLen := rules.BigPerPlayer + rules.LittlePerPlayer
+	// place puts a planet on an arm, the given fraction of the way out
+	place := func(plan PlanetPlan, arm int, along float64) error {
+		target := 2 + int(along*float64(maxRadius-2))
+		turn := float64(arm)/float64(players) + along*.25
+		probe := func() hexagon.Coord {
+			dist := target + int((rng.Float64()-.5)*2)
+			if dist < 2 {
+				dist = 2
+			}
+			return ringSpot(dist, turn+(rng.Float64()-.5)*2/float64(dist*6))
+		}
+		// failing that, the nearest free hex to the arm will do
+		center := ringSpot(target, turn)
+		spot, ok := l.place(rng, probe, nil, nil)
+		for d := 1; !ok && d <= maxRadius; d++ {
+			var free []hexagon.Coord
+			for _, pt := range center.Ring(d) {
+				if !l.places[pt] {
+					free = append(free, pt)
+				}
+			}
+			if len(free) > 0 {
+				spot, ok = free[rng.Intn(len(free))], true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("spiral galaxy: no room on arm %d at %.0f%% of its length", arm+1, along*100)
+		}
+		plan.Loc = spot
+		l.add(plan)
+		return nil
+	}
+	for arm := 0; arm < players; arm++ {
+		for j := 0; j < armLen; j++ {
+			plan := outerPlan(rules, rng)
+			if j < rules.BigPerPlayer {
+				plan = innerPlan(rules, rng)
+			}
+			if err := place(plan, arm, (float64(j)+.5)/float64(armLen+1)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for arm := 0; arm < players; arm++ {
+		upD := 1
+		if coin(rng) {
+			upD = -1
+		}
+		home := l.home(rules, arm+1, hexagon.Coord{}, upD)
+		if err := place(home, arm, (float64(armLen)+.5)/float64(armLen+1)); err != nil {
+			return nil, err
+		}
+	}
+	return l.plans, nil
+}
+
+// islandGenerator gathers each player's outer planets into an island round
+// their home, out past a core of rich planets round Planet Borion, with
+// open space between the islands.
+type islandGenerator struct{}
+
+func (islandGenerator) Layout(rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, error) {
+	l := newLayout(rng, players*rules.PlanetsPerPlayer())
+	l.addBorion(rules)
+	bigRadius, _ := galaxyRadii(rules, players)
+	islandRadius := 2
+	for ; hexagon.HexArea(islandRadius) < (rules.LittlePerPlayer+1)*hexagon.HexArea(2); islandRadius += 1 {
+	}
+	// islands sit far enough out for the core and their neighbours to
+	// stay clear of them
+	dist := bigRadius + islandRadius + 4
+	if around := (players*(2*islandRadius+4) + 5) / 6; dist < around {
+		dist = around
+	}
+	for i := 0; i < players*rules.BigPerPlayer; i++ {
+		plan := innerPlan(rules, rng)
+		spot, ok := l.place(rng, func() hexagon.Coord {
+			d := pick(rng, bigRadius)
+			return hexagon.Polar{d, rng.Intn(d * 6)}.Coord()
+		}, distRange(1, bigRadius), func(dist int) []hexagon.Coord {
+			return sectorRing(dist, 0, 1)
+		})
+		if !ok {
+			return nil, fmt.Errorf("island galaxy: no room for inner planet %d within %d of the center", i+1, bigRadius)
+		}
+		plan.Loc = spot
+		l.add(plan)
+	}
+	for slot := 1; slot <= players; slot++ {
+		center := ringSpot(dist, (float64(slot)-.5)/float64(players))
+		upD := 1
+		if coin(rng) {
+			upD = -1
+		}
+		l.add(l.home(rules, slot, center, upD))
+		for j := 0; j < rules.LittlePerPlayer; j++ {
+			plan := outerPlan(rules, rng)
+			spot, ok := l.place(rng, func() hexagon.Coord {
+				ring := center.Ring(pick(rng, islandRadius))
+				return ring[rng.Intn(len(ring))]
+			}, distRange(1, islandRadius), center.Ring)
+			if !ok {
+				return nil, fmt.Errorf("island galaxy: no room on island %d for planet %d", slot, j+1)
+			}
+			plan.Loc = spot
+			l.add(plan)
+		}
+	}
+	return l.plans, nil
+}
+
+// MapTemplate is a hand-authored galaxy.  Planets without names are named
+// at random, homes without presence start with the game's home presence
+// and resources, and homes without power are given one at random.
+type MapTemplate struct {
+	Name    string       `json:"name"`
+	Players int          `json:"players"`
+	Planets []PlanetPlan `json:"planets"`
+}
+
+// LoadMapTemplate reads a map template from JSON and checks its layout.
+func LoadMapTemplate(r io.Reader) (*MapTemplate, error) {
+	t := &MapTemplate{}
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, err
+	}
+	if t.Players < 1 {
+		return nil, fmt.Errorf("map %q has no players", t.Name)
+	}
+	plans := make([]PlanetPlan, len(t.Planets))
+	copy(plans, t.Planets)
+	for i, plan := range plans {
+		if plan.Home > 0 && plan.Presence == 0 {
+			plans[i].Presence = 1
+		}
+		if plan.Home > 0 && plan.Power == 0 {
+			plans[i].Power = 1
+		}
+	}
+	if err := CheckLayout(plans, t.Players); err != nil {
+		return nil, fmt.Errorf("map %q: %s", t.Name, err)
+	}
+	return t, nil
+}
+
+func (t *MapTemplate) Layout(rules GameRules, players int, rng *rand.Rand) ([]PlanetPlan, error) {
+	if players != t.Players {
+		return nil, fmt.Errorf("map %q is for %d players, not %d", t.Name, t.Players, players)
+	}
+	l := newLayout(rng, len(t.Planets))
+	for _, plan := range t.Planets {
+		if plan.Home > 0 && plan.Presence == 0 {
+			plan.Presence = rules.HomePresence
+			plan.Antimatter = rules.HomeAntimatter
+			plan.Tachyons = rules.HomeTachyons
+		}
+		if plan.Home > 0 && plan.Power == 0 {
+			plan.Power = 1
+			if coin(rng) {
+				plan.Power = -1
+			}
+		}
+		l.add(plan)
+	}
+	return l.plans, nil
+}
+
+// RegisterMapTemplates loads every .json map template in dir, registering
+// each as a generator named "map:" and its file name.
+func RegisterMapTemplates(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		t, err := LoadMapTemplate(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+		RegisterGenerator("map:"+strings.TrimSuffix(filepath.Base(file), ".json"), t)
+	}
+	return nil
+}
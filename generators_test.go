@@ -0,0 +1,133 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"strings"
+	"testing"
+)
+
+func TestGalaxyGenerators(t *testing.T) {
+	for _, gen := range []string{"standard", "exodus", "mirrored", "spiral", "islands"} {
+		for players := 1; players < 9; players++ {
+			s := makeGalaxy(t, players, int64(players), gen)
+			if got, want := len(s.PlanetList), 1+16*players; got != want {
+				t.Errorf("%s, %d players: made %d planets, want %d", gen, players, got, want)
+			}
+			for _, f := range s.FactionList {
+				if s.HomePlanet(f.FID) == nil {
+					t.Errorf("%s, %d players: faction %d has no home planet", gen, players, f.FID)
+				}
+			}
+			if got := overpower.GameGenerator(s.GameItem.Intf()); got != gen {
+				t.Errorf("%s, %d players: game records generator %q", gen, players, got)
+			}
+		}
+	}
+	// a mirrored galaxy holds every planet once per player, at the same
+	// distance from the center and with the same resources
+	center := hexagon.Coord{0, 0}
+	s := makeGalaxy(t, 5, 9, "mirrored")
+	counts := map[[4]int]int{}
+	for _, pl := range s.PlanetList {
+		if pl.Loc != center {
+			counts[[4]int{pl.Loc.StepsTo(center), pl.PrimaryPresence, pl.Antimatter, pl.Tachyons}] += 1
+		}
+	}
+	for key, n := range counts {
+		if n%5 != 0 {
+			t.Errorf("mirrored galaxy has %d planets like %v", n, key)
+		}
+	}
+}
+
+func TestMapTemplate(t *testing.T) {
+	const src = `{"name": "duel", "players": 2, "planets": [
+		{"name": "Planet Middle", "loc": [0, 0], "presence": 10, "antimatter": 15, "tachyons": 15},
+		{"loc": [4, 0], "home": 1},
+		{"loc": [-4, 0], "home": 2},
+		{"loc": [2, 2], "antimatter": 3, "tachyons": 3}
+	]}`
+	tmpl, err := overpower.LoadMapTemplate(strings.NewReader(src))
+	if err != nil {
+		t.Fatal("load template failed:", err)
+	}
+	overpower.RegisterGenerator("map:duel", tmpl)
+	s := makeGalaxy(t, 2, 1, "map:duel")
+	if got := len(s.PlanetList); got != 4 {
+		t.Errorf("template made %d planets, want 4", got)
+	}
+	for _, f := range s.FactionList {
+		home := s.HomePlanet(f.FID)
+		if home == nil || home.PrimaryPresence != 5 || home.Name == "" {
+			t.Errorf("faction %d home planet %+v", f.FID, home)
+		} else if home.PrimaryPower != 1 && home.PrimaryPower != -1 {
+			t.Errorf("faction %d home planet has power %d", f.FID, home.PrimaryPower)
+		}
+	}
+	home := s.HomePlanet(1)
+	s.AddLaunchOrder(1, 3, home.Loc, nearestFree(s, home.Loc).Loc)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if len(s.ShipList) != 1 || s.ShipList[0].Size != 3 {
+		t.Errorf("launch from template home made ships %+v", s.ShipList)
+	}
+	s = memsource.New(1)
+	s.AddFaction("tester", "Faction")
+	if err := overpower.MakeGalaxy(s, "map:duel"); err == nil {
+		t.Error("two player map made for one player")
+	}
+	bad := strings.Replace(src, `"home": 2`, `"home": 1`, 1)
+	if _, err := overpower.LoadMapTemplate(strings.NewReader(bad)); err == nil {
+		t.Error("template with two homes in one slot loaded")
+	}
+}
+
+// TestGalaxyStress makes a thousand galaxies for each generator and
+// player count, a tenth of them crowded, as layouts that fail do so only
+// for a rare seed.  It takes the best part of a minute.
+func TestGalaxyStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("galaxy stress test skipped in short mode")
+	}
+	sparse := overpower.DefaultRules()
+	sparse.FairTolerance = 0
+	crowded := sparse
+	crowded.BigPerPlayer, crowded.LittlePerPlayer = 10, 30
+	for _, gen := range []string{"standard", "exodus", "mirrored", "spiral", "islands"} {
+		for players := 1; players < 9; players++ {
+			for seed := int64(0); seed < 1000; seed++ {
+				rules := sparse
+				if seed%10 == 0 {
+					rules = crowded
+				}
+				s := memsource.New(1)
+				s.GameItem.Seed = seed
+				s.GameItem.Rules = rules
+				for i := 0; i < players; i++ {
+					s.AddFaction("tester", "Faction")
+				}
+				if err := overpower.MakeGalaxy(s, gen); err != nil {
+					t.Fatalf("%s, %d players, seed %d: %s", gen, players, seed, err)
+				}
+				if got, want := len(s.PlanetList), 1+players*rules.PlanetsPerPlayer(); got != want {
+					t.Fatalf("%s, %d players, seed %d: made %d planets, want %d", gen, players, seed, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGalaxyNoRoom(t *testing.T) {
+	// with no inner planets an exodus galaxy puts every home beside Borion
+	rules := overpower.DefaultRules()
+	rules.BigPerPlayer = 0
+	s := memsource.New(1)
+	s.GameItem.Rules = rules
+	s.AddFaction("tester", "Faction")
+	if err := overpower.MakeGalaxy(s, "exodus"); err == nil {
+		t.Error("exodus galaxy with no room for homes was made")
+	}
+}
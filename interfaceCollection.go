@@ -12,33 +12,44 @@ type Source interface {
 	LaunchOrders() ([]LaunchOrderDat, error)
 	Ships() ([]ShipDat, error)
 	Truces() ([]TruceDat, error)
+	Treaties() ([]TreatyDat, error)
+	VisionGrants() ([]VisionGrantDat, error)
 	PowerOrders() ([]PowerOrderDat, error)
+	ShipOrders() ([]ShipOrderDat, error)
+	StandingOrders() ([]StandingOrderDat, error)
+	ConditionalOrders() ([]ConditionalOrderDat, error)
 	// ------- MAKE ------- //
 	NewPlanet(name string,
 		primaryFac, prPres, prPower,
 		secondaryFac, sePres, sePower,
-		antimatter, tachyons int,
+		antimatter, tachyons, size int,
 		loc hexagon.Coord,
 	) PlanetDat
-	NewPlanetView(turn int, planet PlanetDat, exodus bool) PlanetViewDat
+	NewPlanetView(fid int, planet PlanetDat, exodus bool) PlanetViewDat
 	NewMapView(fac int, center hexagon.Coord) MapViewDat
-	NewShip(fid, sid, size, turn int, path hexagon.CoordList) ShipDat
+	NewShip(fid, sid, size, power, turn int, path hexagon.CoordList) ShipDat
 	NewShipView(
 		ship ShipDat, fid, turn int,
 		loc, dest hexagon.NullCoord, trail hexagon.CoordList) ShipViewDat
-	NewLaunchRecord(turn int, order LaunchOrderDat, ship ShipDat)
+	NewLaunchRecord(turn int, order LaunchOrderGet, ship ShipDat)
+	NewConditionRecord(turn int, order ConditionalOrderDat, cause, size int)
+	NewTreatyBreak(turn, fid, partner, kind int)
 	NewBattleRecord(ship ShipDat, fid, turn,
 		initPrimaryFac, initPrPres,
 		initSecondaryFac, initSePres int,
 		result PlanetDat,
 		betrayals [][2]int,
+		events []CombatEvent,
 	)
-	NewPowerOrder(fid int, planet PlanetDat) PowerOrderDat
+	NewStanding(fid, rank, planets, presence, ships int) StandingDat
+	NewHomeReach(fid int, loc hexagon.Coord, reach, planets, antimatter, tachyons int) HomeReachDat
+	ArchiveOrders(turn int, launches []LaunchOrderDat, shipOrders []ShipOrderDat, powers []PowerOrderDat, truces []TruceDat, treaties []TreatyDat, grants []VisionGrantDat, conditions []ConditionalOrderDat)
 	// ------ CHANGE ----- //
 	UpdatePlanetView(fid, turn int, planet PlanetDat) PlanetViewDat
 	// ------- DROP ------ //
 	ClearLaunchOrders() error
-	//ClearPowerOrders() error
+	ClearShipOrders() error
+	ClearPowerOrders() error
 }
 
 type BattleRecordGet interface {
@@ -60,6 +71,7 @@ type BattleRecordGet interface {
 	InitSecondaryFaction() int
 	InitSecondaryPresence() int
 	Betrayals() [][2]int
+	Events() []CombatEvent
 }
 type BattleRecordSet interface {
 	UnmarshalJSON([]byte) error
@@ -79,6 +91,9 @@ type FactionGet interface {
 	FID() int
 	Owner() string
 	Name() string
+	// Bot names the strategy playing the faction, or is empty for a
+	// faction with a human owner.
+	Bot() string
 	DoneBuffer() int
 	Score() int
 	IsDone() bool
@@ -87,6 +102,8 @@ type FactionSet interface {
 	UnmarshalJSON([]byte) error
 	DELETE()
 
+	SetOwner(string)
+	SetBot(string)
 	SetDoneBuffer(int)
 	SetScore(int)
 }
@@ -112,6 +129,13 @@ type GameGet interface {
 	ToWin() int
 	HighScore() int
 	Winner() string
+	TieBreak() string
+	Seed() int64
+	Exodus() bool
+	Generator() string
+	Rules() GameRules
+	Seats() []int
+	Quits() []int
 }
 type GameSet interface {
 	UnmarshalJSON([]byte) error
@@ -127,6 +151,14 @@ type GameSet interface {
 	//SetPassword(sql.NullString)
 	SetToWin(int)
 	SetHighScore(int)
+	SetWinner(string)
+	SetTieBreak(string)
+	SetSeed(int64)
+	SetExodus(bool)
+	SetGenerator(string)
+	SetRules(GameRules)
+	SetSeats([]int)
+	SetQuits([]int)
 }
 
 type GameDat interface {
@@ -174,6 +206,49 @@ type MapViewDat interface {
 	MapViewSet
 }
 
+// Message is sent by FID on Turn to Recipient, or to every faction in
+// the game when Recipient is 0.  MIDs are given by the database and rise with
+// each message sent, in any game.
+type MessageGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	MID() int
+	Turn() int
+	FID() int
+	Recipient() int
+	Body() string
+}
+type MessageSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type MessageDat interface {
+	MessageGet
+	MessageSet
+}
+
+// MessageRead holds the highest MID that FID has read.
+type MessageReadGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	LastRead() int
+}
+type MessageReadSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+
+	SetLastRead(int)
+}
+
+type MessageReadDat interface {
+	MessageReadGet
+	MessageReadSet
+}
+
 type LaunchOrderGet interface {
 	MarshalJSON() ([]byte, error)
 
@@ -195,6 +270,118 @@ type LaunchOrderDat interface {
 	LaunchOrderSet
 }
 
+// ShipOrder changes a ship in flight: with Merge set the ship joins the
+// ship of that SID, otherwise it turns for the planet at Target.
+type ShipOrderGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	SID() int
+	Target() hexagon.Coord
+	Merge() int
+}
+type ShipOrderSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+
+	SetTarget(hexagon.Coord)
+	SetMerge(int)
+}
+
+type ShipOrderDat interface {
+	ShipOrderGet
+	ShipOrderSet
+}
+
+// StandingOrder launches from Source to Target every turn: Size ships, or
+// with Percent set that percent of the faction's presence at Source.  With
+// Turns set it lasts that many more turns, otherwise until cancelled or
+// until the faction loses Source.
+type StandingOrderGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Source() hexagon.Coord
+	Target() hexagon.Coord
+	Size() int
+	Percent() int
+	Turns() int
+}
+type StandingOrderSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+
+	SetSize(int)
+	SetPercent(int)
+	SetTurns(int)
+}
+
+type StandingOrderDat interface {
+	StandingOrderGet
+	StandingOrderSet
+}
+
+// ConditionalOrder waits for its condition to come about at Loc, then
+// fires once and is gone.  CONDATTACKED fires when a ship the faction has
+// no truce with lands on Loc while the faction is there; CONDSIGHTED when
+// another faction's ship is seen within Within hexes of Loc.  CONDLAUNCH
+// launches Size ships, or all it can, from Source to Target; CONDPOWER
+// switches the faction's power at Target to Power.
+type ConditionalOrderGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	CID() int
+	Kind() int
+	Loc() hexagon.Coord
+	Within() int
+	Action() int
+	Source() hexagon.Coord
+	Target() hexagon.Coord
+	Size() int
+	Power() int
+}
+type ConditionalOrderSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type ConditionalOrderDat interface {
+	ConditionalOrderGet
+	ConditionalOrderSet
+}
+
+// ConditionRecord notes a conditional order firing: Cause is the faction
+// whose ship set it off, and Size the ships a launch actually sent.
+type ConditionRecordGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Turn() int
+	CID() int
+	Kind() int
+	Loc() hexagon.Coord
+	Action() int
+	Source() hexagon.Coord
+	Target() hexagon.Coord
+	Size() int
+	Power() int
+	Cause() int
+}
+type ConditionRecordSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type ConditionRecordDat interface {
+	ConditionRecordGet
+	ConditionRecordSet
+}
+
 type PlanetGet interface {
 	MarshalJSON() ([]byte, error)
 
@@ -209,6 +396,12 @@ type PlanetGet interface {
 	SecondaryPower() int
 	Antimatter() int
 	Tachyons() int
+	// Size caps the presence a planet supports and the resources it
+	// regrows.
+	Size() int
+	// Radar is how many hexes from the planet its occupants saw ships on
+	// the last turn.
+	Radar() int
 	//
 	ControlLevel(fid int) (level int)
 	PowerType(fid int) (kind int)
@@ -228,6 +421,7 @@ type PlanetSet interface {
 	SetSecondaryPower(int)
 	SetAntimatter(int)
 	SetTachyons(int)
+	SetRadar(int)
 }
 
 type PlanetDat interface {
@@ -251,6 +445,7 @@ type PlanetViewGet interface {
 	SecondaryPower() int
 	Antimatter() int
 	Tachyons() int
+	Radar() int
 }
 type PlanetViewSet interface {
 	UnmarshalJSON([]byte) error
@@ -265,6 +460,7 @@ type PlanetViewSet interface {
 	SetSecondaryPower(int)
 	SetAntimatter(int)
 	SetTachyons(int)
+	SetRadar(int)
 }
 
 type PlanetViewDat interface {
@@ -283,7 +479,6 @@ type PowerOrderGet interface {
 
 type PowerOrderSet interface {
 	UnmarshalJSON([]byte) error
-	SetLoc(hexagon.Coord)
 	SetUpPower(int)
 	DELETE()
 }
@@ -300,12 +495,19 @@ type ShipGet interface {
 	FID() int
 	SID() int
 	Size() int
+	// Power is the power the ship was launched on, or 0 for ships from
+	// before powers mattered in flight.
+	Power() int
 	Launched() int
 	Path() hexagon.CoordList
 }
 type ShipSet interface {
 	UnmarshalJSON([]byte) error
 	DELETE()
+
+	SetSize(int)
+	SetLaunched(int)
+	SetPath(hexagon.CoordList)
 }
 
 type ShipDat interface {
@@ -322,6 +524,7 @@ type ShipViewGet interface {
 	SID() int
 	Controller() int
 	Size() int
+	Power() int
 	Loc() hexagon.NullCoord
 	Dest() hexagon.NullCoord
 	Trail() hexagon.CoordList
@@ -343,13 +546,126 @@ type TruceGet interface {
 	FID() int
 	Loc() hexagon.Coord
 	Trucee() int
+	// Hostile withdraws, at this one planet, the truce a treaty would
+	// give toward Trucee.
+	Hostile() bool
 }
 type TruceSet interface {
 	UnmarshalJSON([]byte) error
 	DELETE()
+
+	SetHostile(bool)
 }
 
 type TruceDat interface {
 	TruceGet
 	TruceSet
 }
+
+// Treaty is FID's offer to Partner, in force once Accepted.  Each kind
+// gives truces both ways on a wider set of planets: TREATYNONAGGRESSION
+// on planets both hold, TREATYPEACE on every planet, so the two never
+// fight wherever they meet, and TREATYALLIANCE as peace with each sharing
+// the other's radar.
+type TreatyGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Partner() int
+	Kind() int
+	Accepted() bool
+}
+type TreatySet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+
+	SetKind(int)
+	SetAccepted(bool)
+}
+
+type TreatyDat interface {
+	TreatyGet
+	TreatySet
+}
+
+// TreatyBreak notes FID ending its treaty of Kind with Partner on Turn,
+// by betraying it in a fight or by walking away from it.
+type TreatyBreakGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	Turn() int
+	FID() int
+	Partner() int
+	Kind() int
+}
+type TreatyBreakSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type TreatyBreakDat interface {
+	TreatyBreakGet
+	TreatyBreakSet
+}
+
+// VisionGrant lets Grantee see what FID sees: FID's radar joins
+// Grantee's, and Grantee's views follow every planet FID's views do.
+type VisionGrantGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Grantee() int
+}
+type VisionGrantSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type VisionGrantDat interface {
+	VisionGrantGet
+	VisionGrantSet
+}
+
+type StandingGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Rank() int
+	Planets() int
+	Presence() int
+	Ships() int
+}
+type StandingSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type StandingDat interface {
+	StandingGet
+	StandingSet
+}
+
+type HomeReachGet interface {
+	MarshalJSON() ([]byte, error)
+
+	GID() int
+	FID() int
+	Loc() hexagon.Coord
+	Reach() int
+	Planets() int
+	Antimatter() int
+	Tachyons() int
+}
+type HomeReachSet interface {
+	UnmarshalJSON([]byte) error
+	DELETE()
+}
+
+type HomeReachDat interface {
+	HomeReachGet
+	HomeReachSet
+}
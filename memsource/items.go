@@ -0,0 +1,1451 @@
+package memsource
+
+import (
+	"encoding/json"
+	"mule/hexagon"
+	"mule/overpower"
+)
+
+// ------------------ GAME ------------------ //
+
+type Game struct {
+	GID       int                 `json:"gid"`
+	Owner     string              `json:"owner"`
+	Name      string              `json:"name"`
+	Turn      int                 `json:"turn"`
+	Autoturn  int                 `json:"-"`
+	FreeAutos int                 `json:"freeautos"`
+	Password  string              `json:"-"`
+	ToWin     int                 `json:"towin"`
+	HighScore int                 `json:"highscore"`
+	Winner    string              `json:"winner,omitempty"`
+	TieBreak  string              `json:"tiebreak,omitempty"`
+	Seed      int64               `json:"-"`
+	Exodus    bool                `json:"exodus"`
+	Generator string              `json:"generator"`
+	Rules     overpower.GameRules `json:"rules"`
+	Seats     []int               `json:"-"`
+	Quits     []int               `json:"-"`
+	Deleted   bool                `json:"-"`
+}
+
+type GameIntf struct {
+	item *Game
+}
+
+func (item *Game) Intf() overpower.GameDat {
+	return GameIntf{item}
+}
+
+func (i GameIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		*Game
+		HasPassword bool    `json:"haspassword"`
+		AutoDays    [7]bool `json:"autodays"`
+	}{
+		Game:        i.item,
+		HasPassword: i.HasPassword(),
+		AutoDays:    i.AutoDays(),
+	})
+}
+func (i GameIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i GameIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i GameIntf) GID() int {
+	return i.item.GID
+}
+func (i GameIntf) Owner() string {
+	return i.item.Owner
+}
+func (i GameIntf) SetOwner(x string) {
+	i.item.Owner = x
+}
+func (i GameIntf) Name() string {
+	return i.item.Name
+}
+func (i GameIntf) SetName(x string) {
+	i.item.Name = x
+}
+func (i GameIntf) Turn() int {
+	return i.item.Turn
+}
+func (i GameIntf) SetTurn(x int) {
+	i.item.Turn = x
+}
+func (i GameIntf) IncTurn() {
+	i.item.Turn += 1
+}
+func (i GameIntf) AutoDays() (days [7]bool) {
+	sum := i.item.Autoturn
+	for j := 0; j < 7; j++ {
+		if sum%2 == 1 {
+			days[j] = true
+		}
+		sum = sum / 2
+	}
+	return
+}
+func (i GameIntf) SetAutoDays(days [7]bool) {
+	var sum int
+	for j, b := range days {
+		if b {
+			sum += 1 << uint32(j)
+		}
+	}
+	i.item.Autoturn = sum
+}
+func (i GameIntf) FreeAutos() int {
+	return i.item.FreeAutos
+}
+func (i GameIntf) SetFreeAutos(x int) {
+	i.item.FreeAutos = x
+}
+func (i GameIntf) HasPassword() bool {
+	return i.item.Password != ""
+}
+func (i GameIntf) IsPassword(x string) bool {
+	return i.item.Password == x
+}
+func (i GameIntf) ToWin() int {
+	return i.item.ToWin
+}
+func (i GameIntf) SetToWin(x int) {
+	i.item.ToWin = x
+}
+func (i GameIntf) HighScore() int {
+	return i.item.HighScore
+}
+func (i GameIntf) SetHighScore(x int) {
+	i.item.HighScore = x
+}
+func (i GameIntf) Winner() string {
+	return i.item.Winner
+}
+func (i GameIntf) SetWinner(x string) {
+	i.item.Winner = x
+}
+func (i GameIntf) TieBreak() string {
+	return i.item.TieBreak
+}
+func (i GameIntf) SetTieBreak(x string) {
+	i.item.TieBreak = x
+}
+func (i GameIntf) Seed() int64 {
+	return i.item.Seed
+}
+func (i GameIntf) SetSeed(x int64) {
+	i.item.Seed = x
+}
+func (i GameIntf) Exodus() bool {
+	return i.item.Exodus
+}
+func (i GameIntf) SetExodus(x bool) {
+	i.item.Exodus = x
+}
+func (i GameIntf) Generator() string {
+	return i.item.Generator
+}
+func (i GameIntf) SetGenerator(x string) {
+	i.item.Generator = x
+}
+func (i GameIntf) Rules() overpower.GameRules {
+	return i.item.Rules
+}
+func (i GameIntf) SetRules(x overpower.GameRules) {
+	i.item.Rules = x
+}
+func (i GameIntf) Seats() []int {
+	return i.item.Seats
+}
+func (i GameIntf) SetSeats(x []int) {
+	i.item.Seats = x
+}
+func (i GameIntf) Quits() []int {
+	return i.item.Quits
+}
+func (i GameIntf) SetQuits(x []int) {
+	i.item.Quits = x
+}
+
+// ------------------ FACTION ------------------ //
+
+type Faction struct {
+	GID        int    `json:"gid"`
+	FID        int    `json:"fid"`
+	Owner      string `json:"owner"`
+	Name       string `json:"name"`
+	DoneBuffer int    `json:"donebuffer"`
+	Score      int    `json:"score"`
+	Bot        string `json:"bot"`
+	Deleted    bool   `json:"-"`
+}
+
+type FactionIntf struct {
+	item *Faction
+}
+
+func (item *Faction) Intf() overpower.FactionDat {
+	return FactionIntf{item}
+}
+
+func (i FactionIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i FactionIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i FactionIntf) SetFullJSON() {
+}
+func (i FactionIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i FactionIntf) GID() int {
+	return i.item.GID
+}
+func (i FactionIntf) FID() int {
+	return i.item.FID
+}
+func (i FactionIntf) Owner() string {
+	return i.item.Owner
+}
+func (i FactionIntf) Name() string {
+	return i.item.Name
+}
+func (i FactionIntf) Bot() string {
+	return i.item.Bot
+}
+func (i FactionIntf) SetOwner(x string) {
+	i.item.Owner = x
+}
+func (i FactionIntf) SetBot(x string) {
+	i.item.Bot = x
+}
+func (i FactionIntf) IsDone() bool {
+	return i.item.DoneBuffer != 0
+}
+func (i FactionIntf) DoneBuffer() int {
+	return i.item.DoneBuffer
+}
+func (i FactionIntf) SetDoneBuffer(x int) {
+	i.item.DoneBuffer = x
+}
+func (i FactionIntf) Score() int {
+	return i.item.Score
+}
+func (i FactionIntf) SetScore(x int) {
+	i.item.Score = x
+}
+
+// ------------------ PLANET ------------------ //
+
+type Planet struct {
+	GID               int           `json:"gid"`
+	Loc               hexagon.Coord `json:"loc"`
+	Name              string        `json:"name"`
+	PrimaryFaction    int           `json:"primaryfaction"`
+	PrimaryPresence   int           `json:"primarypresence"`
+	PrimaryPower      int           `json:"primarypower"`
+	SecondaryFaction  int           `json:"secondaryfaction"`
+	SecondaryPresence int           `json:"secondarypresence"`
+	SecondaryPower    int           `json:"secondarypower"`
+	Antimatter        int           `json:"antimatter"`
+	Tachyons          int           `json:"tachyons"`
+	Size              int           `json:"size"`
+	Radar             int           `json:"radar"`
+	Deleted           bool          `json:"-"`
+}
+
+type PlanetIntf struct {
+	item *Planet
+}
+
+func (item *Planet) Intf() overpower.PlanetDat {
+	return PlanetIntf{item}
+}
+
+func (i PlanetIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i PlanetIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i PlanetIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i PlanetIntf) GID() int {
+	return i.item.GID
+}
+func (i PlanetIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i PlanetIntf) Name() string {
+	return i.item.Name
+}
+func (i PlanetIntf) PrimaryFaction() int {
+	return i.item.PrimaryFaction
+}
+func (i PlanetIntf) SetPrimaryFaction(x int) {
+	i.item.PrimaryFaction = x
+}
+func (i PlanetIntf) PrimaryPresence() int {
+	return i.item.PrimaryPresence
+}
+func (i PlanetIntf) SetPrimaryPresence(x int) {
+	i.item.PrimaryPresence = x
+}
+func (i PlanetIntf) PrimaryPower() int {
+	return i.item.PrimaryPower
+}
+func (i PlanetIntf) SetPrimaryPower(x int) {
+	i.item.PrimaryPower = x
+}
+func (i PlanetIntf) SecondaryFaction() int {
+	return i.item.SecondaryFaction
+}
+func (i PlanetIntf) SetSecondaryFaction(x int) {
+	i.item.SecondaryFaction = x
+}
+func (i PlanetIntf) SecondaryPresence() int {
+	return i.item.SecondaryPresence
+}
+func (i PlanetIntf) SetSecondaryPresence(x int) {
+	i.item.SecondaryPresence = x
+}
+func (i PlanetIntf) SecondaryPower() int {
+	return i.item.SecondaryPower
+}
+func (i PlanetIntf) SetSecondaryPower(x int) {
+	i.item.SecondaryPower = x
+}
+func (i PlanetIntf) Antimatter() int {
+	return i.item.Antimatter
+}
+func (i PlanetIntf) SetAntimatter(x int) {
+	i.item.Antimatter = x
+}
+func (i PlanetIntf) Tachyons() int {
+	return i.item.Tachyons
+}
+func (i PlanetIntf) Size() int {
+	return i.item.Size
+}
+func (i PlanetIntf) SetTachyons(x int) {
+	i.item.Tachyons = x
+}
+func (i PlanetIntf) Radar() int {
+	return i.item.Radar
+}
+func (i PlanetIntf) SetRadar(x int) {
+	i.item.Radar = x
+}
+
+func (i PlanetIntf) ControlLevel(fid int) (level int) {
+	if fid == 0 {
+		return 0
+	}
+	if i.item.PrimaryFaction == fid {
+		return 1
+	}
+	if i.item.SecondaryFaction == fid {
+		return 2
+	}
+	return 0
+}
+func (i PlanetIntf) PresenceLevel(fid int) (amount int) {
+	ctrl := i.ControlLevel(fid)
+	if ctrl == 1 {
+		return i.item.PrimaryPresence
+	} else if ctrl == 2 {
+		return i.item.SecondaryPresence
+	}
+	return 0
+}
+func (i PlanetIntf) PowerType(fid int) (kind int) {
+	lvl := i.ControlLevel(fid)
+	if lvl == 1 {
+		return i.item.PrimaryPower
+	} else if lvl == 2 {
+		return i.item.SecondaryPower
+	}
+	return 0
+}
+func (i PlanetIntf) ResourceCount(kind int) (amount int) {
+	if kind == overpower.ANTIMATTER {
+		return i.item.Antimatter
+	}
+	if kind == overpower.TACHYONS {
+		return i.item.Tachyons
+	}
+	return 0
+}
+func (i PlanetIntf) LaunchAvail(fid int) (amount int) {
+	lvl := i.ResourceCount(i.PowerType(fid))
+	pres := i.PresenceLevel(fid)
+	if pres < lvl {
+		return pres
+	}
+	return lvl
+}
+
+// ------------------ PLANETVIEW ------------------ //
+
+type PlanetView struct {
+	GID               int           `json:"gid"`
+	FID               int           `json:"fid"`
+	Loc               hexagon.Coord `json:"loc"`
+	Name              string        `json:"name"`
+	Turn              int           `json:"turn"`
+	PrimaryFaction    int           `json:"primaryfaction"`
+	PrimaryPresence   int           `json:"primarypresence"`
+	PrimaryPower      int           `json:"primarypower"`
+	SecondaryFaction  int           `json:"secondaryfaction"`
+	SecondaryPresence int           `json:"secondarypresence"`
+	SecondaryPower    int           `json:"secondarypower"`
+	Antimatter        int           `json:"antimatter"`
+	Tachyons          int           `json:"tachyons"`
+	Radar             int           `json:"radar"`
+	Deleted           bool          `json:"-"`
+}
+
+type PlanetViewIntf struct {
+	item *PlanetView
+}
+
+func (item *PlanetView) Intf() overpower.PlanetViewDat {
+	return PlanetViewIntf{item}
+}
+
+func (i PlanetViewIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i PlanetViewIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i PlanetViewIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i PlanetViewIntf) GID() int {
+	return i.item.GID
+}
+func (i PlanetViewIntf) FID() int {
+	return i.item.FID
+}
+func (i PlanetViewIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i PlanetViewIntf) Name() string {
+	return i.item.Name
+}
+func (i PlanetViewIntf) Turn() int {
+	return i.item.Turn
+}
+func (i PlanetViewIntf) SetTurn(x int) {
+	i.item.Turn = x
+}
+func (i PlanetViewIntf) PrimaryFaction() int {
+	return i.item.PrimaryFaction
+}
+func (i PlanetViewIntf) SetPrimaryFaction(x int) {
+	i.item.PrimaryFaction = x
+}
+func (i PlanetViewIntf) PrimaryPresence() int {
+	return i.item.PrimaryPresence
+}
+func (i PlanetViewIntf) SetPrimaryPresence(x int) {
+	i.item.PrimaryPresence = x
+}
+func (i PlanetViewIntf) PrimaryPower() int {
+	return i.item.PrimaryPower
+}
+func (i PlanetViewIntf) SetPrimaryPower(x int) {
+	i.item.PrimaryPower = x
+}
+func (i PlanetViewIntf) SecondaryFaction() int {
+	return i.item.SecondaryFaction
+}
+func (i PlanetViewIntf) SetSecondaryFaction(x int) {
+	i.item.SecondaryFaction = x
+}
+func (i PlanetViewIntf) SecondaryPresence() int {
+	return i.item.SecondaryPresence
+}
+func (i PlanetViewIntf) SetSecondaryPresence(x int) {
+	i.item.SecondaryPresence = x
+}
+func (i PlanetViewIntf) SecondaryPower() int {
+	return i.item.SecondaryPower
+}
+func (i PlanetViewIntf) SetSecondaryPower(x int) {
+	i.item.SecondaryPower = x
+}
+func (i PlanetViewIntf) Antimatter() int {
+	return i.item.Antimatter
+}
+func (i PlanetViewIntf) SetAntimatter(x int) {
+	i.item.Antimatter = x
+}
+func (i PlanetViewIntf) Tachyons() int {
+	return i.item.Tachyons
+}
+func (i PlanetViewIntf) SetTachyons(x int) {
+	i.item.Tachyons = x
+}
+func (i PlanetViewIntf) Radar() int {
+	return i.item.Radar
+}
+func (i PlanetViewIntf) SetRadar(x int) {
+	i.item.Radar = x
+}
+
+// ------------------ MAPVIEW ------------------ //
+
+type MapView struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Center  hexagon.Coord `json:"center"`
+	Deleted bool          `json:"-"`
+}
+
+type MapViewIntf struct {
+	item *MapView
+}
+
+func (item *MapView) Intf() overpower.MapViewDat {
+	return MapViewIntf{item}
+}
+
+func (i MapViewIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i MapViewIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i MapViewIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i MapViewIntf) GID() int {
+	return i.item.GID
+}
+func (i MapViewIntf) FID() int {
+	return i.item.FID
+}
+func (i MapViewIntf) Center() hexagon.Coord {
+	return i.item.Center
+}
+func (i MapViewIntf) SetCenter(x hexagon.Coord) {
+	i.item.Center = x
+}
+
+// ------------------ SHIP ------------------ //
+
+type Ship struct {
+	GID      int               `json:"gid"`
+	FID      int               `json:"fid"`
+	SID      int               `json:"sid"`
+	Size     int               `json:"size"`
+	Power    int               `json:"power"`
+	Launched int               `json:"launched"`
+	Path     hexagon.CoordList `json:"path"`
+	Deleted  bool              `json:"-"`
+}
+
+type ShipIntf struct {
+	item *Ship
+}
+
+func (item *Ship) Intf() overpower.ShipDat {
+	return ShipIntf{item}
+}
+
+func (i ShipIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ShipIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i ShipIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i ShipIntf) GID() int {
+	return i.item.GID
+}
+func (i ShipIntf) FID() int {
+	return i.item.FID
+}
+func (i ShipIntf) SID() int {
+	return i.item.SID
+}
+func (i ShipIntf) Size() int {
+	return i.item.Size
+}
+func (i ShipIntf) Power() int {
+	return i.item.Power
+}
+func (i ShipIntf) Launched() int {
+	return i.item.Launched
+}
+func (i ShipIntf) Path() hexagon.CoordList {
+	return i.item.Path
+}
+func (i ShipIntf) SetSize(x int) {
+	i.item.Size = x
+}
+func (i ShipIntf) SetLaunched(x int) {
+	i.item.Launched = x
+}
+func (i ShipIntf) SetPath(x hexagon.CoordList) {
+	i.item.Path = x
+}
+
+// ------------------ SHIPVIEW ------------------ //
+
+type ShipView struct {
+	GID        int               `json:"gid"`
+	Turn       int               `json:"turn"`
+	FID        int               `json:"fid"`
+	SID        int               `json:"sid"`
+	Controller int               `json:"controller"`
+	Size       int               `json:"size"`
+	Power      int               `json:"power"`
+	Loc        hexagon.NullCoord `json:"loc"`
+	Dest       hexagon.NullCoord `json:"dest"`
+	Trail      hexagon.CoordList `json:"trail"`
+	Deleted    bool              `json:"-"`
+}
+
+type ShipViewIntf struct {
+	item *ShipView
+}
+
+func (item *ShipView) Intf() overpower.ShipViewDat {
+	return ShipViewIntf{item}
+}
+
+func (i ShipViewIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ShipViewIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i ShipViewIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i ShipViewIntf) GID() int {
+	return i.item.GID
+}
+func (i ShipViewIntf) Turn() int {
+	return i.item.Turn
+}
+func (i ShipViewIntf) FID() int {
+	return i.item.FID
+}
+func (i ShipViewIntf) SID() int {
+	return i.item.SID
+}
+func (i ShipViewIntf) Controller() int {
+	return i.item.Controller
+}
+func (i ShipViewIntf) Size() int {
+	return i.item.Size
+}
+func (i ShipViewIntf) Power() int {
+	return i.item.Power
+}
+func (i ShipViewIntf) Loc() hexagon.NullCoord {
+	return i.item.Loc
+}
+func (i ShipViewIntf) Dest() hexagon.NullCoord {
+	return i.item.Dest
+}
+func (i ShipViewIntf) Trail() hexagon.CoordList {
+	return i.item.Trail
+}
+
+// ------------------ LAUNCHORDER ------------------ //
+
+type LaunchOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Source  hexagon.Coord `json:"source"`
+	Target  hexagon.Coord `json:"target"`
+	Size    int           `json:"size"`
+	Deleted bool          `json:"-"`
+}
+
+type LaunchOrderIntf struct {
+	item *LaunchOrder
+}
+
+func (item *LaunchOrder) Intf() overpower.LaunchOrderDat {
+	return LaunchOrderIntf{item}
+}
+
+func (i LaunchOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i LaunchOrderIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i LaunchOrderIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i LaunchOrderIntf) GID() int {
+	return i.item.GID
+}
+func (i LaunchOrderIntf) FID() int {
+	return i.item.FID
+}
+func (i LaunchOrderIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+func (i LaunchOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i LaunchOrderIntf) Size() int {
+	return i.item.Size
+}
+func (i LaunchOrderIntf) SetSize(x int) {
+	i.item.Size = x
+}
+
+// ------------------ SHIPORDER ------------------ //
+
+type ShipOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	SID     int           `json:"sid"`
+	Target  hexagon.Coord `json:"target"`
+	Merge   int           `json:"merge"`
+	Deleted bool          `json:"-"`
+}
+
+type ShipOrderIntf struct {
+	item *ShipOrder
+}
+
+func (item *ShipOrder) Intf() overpower.ShipOrderDat {
+	return ShipOrderIntf{item}
+}
+
+func (i ShipOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ShipOrderIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i ShipOrderIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i ShipOrderIntf) GID() int {
+	return i.item.GID
+}
+func (i ShipOrderIntf) FID() int {
+	return i.item.FID
+}
+func (i ShipOrderIntf) SID() int {
+	return i.item.SID
+}
+func (i ShipOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i ShipOrderIntf) Merge() int {
+	return i.item.Merge
+}
+func (i ShipOrderIntf) SetTarget(x hexagon.Coord) {
+	i.item.Target = x
+}
+func (i ShipOrderIntf) SetMerge(x int) {
+	i.item.Merge = x
+}
+
+// ------------------ STANDINGORDER ------------------ //
+
+type StandingOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Source  hexagon.Coord `json:"source"`
+	Target  hexagon.Coord `json:"target"`
+	Size    int           `json:"size"`
+	Percent int           `json:"percent"`
+	Turns   int           `json:"turns"`
+	Deleted bool          `json:"-"`
+}
+
+type StandingOrderIntf struct {
+	item *StandingOrder
+}
+
+func (item *StandingOrder) Intf() overpower.StandingOrderDat {
+	return StandingOrderIntf{item}
+}
+
+func (i StandingOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i StandingOrderIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i StandingOrderIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i StandingOrderIntf) GID() int {
+	return i.item.GID
+}
+func (i StandingOrderIntf) FID() int {
+	return i.item.FID
+}
+func (i StandingOrderIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+func (i StandingOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i StandingOrderIntf) Size() int {
+	return i.item.Size
+}
+func (i StandingOrderIntf) Percent() int {
+	return i.item.Percent
+}
+func (i StandingOrderIntf) Turns() int {
+	return i.item.Turns
+}
+func (i StandingOrderIntf) SetSize(x int) {
+	i.item.Size = x
+}
+func (i StandingOrderIntf) SetPercent(x int) {
+	i.item.Percent = x
+}
+func (i StandingOrderIntf) SetTurns(x int) {
+	i.item.Turns = x
+}
+
+// ------------------ CONDITIONALORDER ------------------ //
+
+type ConditionalOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	CID     int           `json:"cid"`
+	Kind    int           `json:"kind"`
+	Loc     hexagon.Coord `json:"loc"`
+	Within  int           `json:"within"`
+	Action  int           `json:"action"`
+	Source  hexagon.Coord `json:"source"`
+	Target  hexagon.Coord `json:"target"`
+	Size    int           `json:"size"`
+	Power   int           `json:"power"`
+	Deleted bool          `json:"-"`
+}
+
+type ConditionalOrderIntf struct {
+	item *ConditionalOrder
+}
+
+func (item *ConditionalOrder) Intf() overpower.ConditionalOrderDat {
+	return ConditionalOrderIntf{item}
+}
+
+func (i ConditionalOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ConditionalOrderIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i ConditionalOrderIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i ConditionalOrderIntf) GID() int {
+	return i.item.GID
+}
+func (i ConditionalOrderIntf) FID() int {
+	return i.item.FID
+}
+func (i ConditionalOrderIntf) CID() int {
+	return i.item.CID
+}
+func (i ConditionalOrderIntf) Kind() int {
+	return i.item.Kind
+}
+func (i ConditionalOrderIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i ConditionalOrderIntf) Within() int {
+	return i.item.Within
+}
+func (i ConditionalOrderIntf) Action() int {
+	return i.item.Action
+}
+func (i ConditionalOrderIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+func (i ConditionalOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i ConditionalOrderIntf) Size() int {
+	return i.item.Size
+}
+func (i ConditionalOrderIntf) Power() int {
+	return i.item.Power
+}
+
+// ------------------ LAUNCHRECORD ------------------ //
+
+type LaunchRecord struct {
+	GID       int           `json:"gid"`
+	FID       int           `json:"fid"`
+	Turn      int           `json:"turn"`
+	Source    hexagon.Coord `json:"source"`
+	Target    hexagon.Coord `json:"target"`
+	OrderSize int           `json:"ordersize"`
+	Size      int           `json:"size"`
+	Deleted   bool          `json:"-"`
+}
+
+type LaunchRecordIntf struct {
+	item *LaunchRecord
+}
+
+func (item *LaunchRecord) Intf() overpower.LaunchRecordDat {
+	return LaunchRecordIntf{item}
+}
+
+func (i LaunchRecordIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i LaunchRecordIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i LaunchRecordIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i LaunchRecordIntf) GID() int {
+	return i.item.GID
+}
+func (i LaunchRecordIntf) FID() int {
+	return i.item.FID
+}
+func (i LaunchRecordIntf) Turn() int {
+	return i.item.Turn
+}
+func (i LaunchRecordIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+func (i LaunchRecordIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i LaunchRecordIntf) OrderSize() int {
+	return i.item.OrderSize
+}
+func (i LaunchRecordIntf) Size() int {
+	return i.item.Size
+}
+
+// ------------------ CONDITIONRECORD ------------------ //
+
+type ConditionRecord struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Turn    int           `json:"turn"`
+	CID     int           `json:"cid"`
+	Kind    int           `json:"kind"`
+	Loc     hexagon.Coord `json:"loc"`
+	Action  int           `json:"action"`
+	Source  hexagon.Coord `json:"source"`
+	Target  hexagon.Coord `json:"target"`
+	Size    int           `json:"size"`
+	Power   int           `json:"power"`
+	Cause   int           `json:"cause"`
+	Deleted bool          `json:"-"`
+}
+
+type ConditionRecordIntf struct {
+	item *ConditionRecord
+}
+
+func (item *ConditionRecord) Intf() overpower.ConditionRecordDat {
+	return ConditionRecordIntf{item}
+}
+
+func (i ConditionRecordIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ConditionRecordIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i ConditionRecordIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i ConditionRecordIntf) GID() int {
+	return i.item.GID
+}
+func (i ConditionRecordIntf) FID() int {
+	return i.item.FID
+}
+func (i ConditionRecordIntf) Turn() int {
+	return i.item.Turn
+}
+func (i ConditionRecordIntf) CID() int {
+	return i.item.CID
+}
+func (i ConditionRecordIntf) Kind() int {
+	return i.item.Kind
+}
+func (i ConditionRecordIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i ConditionRecordIntf) Action() int {
+	return i.item.Action
+}
+func (i ConditionRecordIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+func (i ConditionRecordIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+func (i ConditionRecordIntf) Size() int {
+	return i.item.Size
+}
+func (i ConditionRecordIntf) Power() int {
+	return i.item.Power
+}
+func (i ConditionRecordIntf) Cause() int {
+	return i.item.Cause
+}
+
+// ------------------ BATTLERECORD ------------------ //
+
+type BattleRecord struct {
+	GID   int           `json:"gid"`
+	FID   int           `json:"fid"`
+	Loc   hexagon.Coord `json:"loc"`
+	Turn  int           `json:"turn"`
+	Index int           `json:"index"`
+
+	InitPrimaryFaction    int `json:"initprimaryfaction"`
+	InitPrimaryPresence   int `json:"initprimarypresence"`
+	InitSecondaryFaction  int `json:"initsecondaryfaction"`
+	InitSecondaryPresence int `json:"initsecondarypresence"`
+	ShipFaction           int `json:"shipfaction"`
+	ShipSize              int `json:"shipsize"`
+
+	Betrayals [][2]int                `json:"betrayals"`
+	Events    []overpower.CombatEvent `json:"events"`
+
+	PrimaryFaction    int  `json:"primaryfaction"`
+	PrimaryPresence   int  `json:"primarypresence"`
+	SecondaryFaction  int  `json:"secondaryfaction"`
+	SecondaryPresence int  `json:"secondarypresence"`
+	Deleted           bool `json:"-"`
+}
+
+type BattleRecordIntf struct {
+	item *BattleRecord
+}
+
+func (item *BattleRecord) Intf() overpower.BattleRecordDat {
+	return BattleRecordIntf{item}
+}
+
+func (i BattleRecordIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i BattleRecordIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i BattleRecordIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i BattleRecordIntf) GID() int {
+	return i.item.GID
+}
+func (i BattleRecordIntf) FID() int {
+	return i.item.FID
+}
+func (i BattleRecordIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i BattleRecordIntf) Turn() int {
+	return i.item.Turn
+}
+func (i BattleRecordIntf) Index() int {
+	return i.item.Index
+}
+func (i BattleRecordIntf) PrimaryFaction() int {
+	return i.item.PrimaryFaction
+}
+func (i BattleRecordIntf) PrimaryPresence() int {
+	return i.item.PrimaryPresence
+}
+func (i BattleRecordIntf) SecondaryFaction() int {
+	return i.item.SecondaryFaction
+}
+func (i BattleRecordIntf) SecondaryPresence() int {
+	return i.item.SecondaryPresence
+}
+func (i BattleRecordIntf) ShipFaction() int {
+	return i.item.ShipFaction
+}
+func (i BattleRecordIntf) ShipSize() int {
+	return i.item.ShipSize
+}
+func (i BattleRecordIntf) InitPrimaryFaction() int {
+	return i.item.InitPrimaryFaction
+}
+func (i BattleRecordIntf) InitPrimaryPresence() int {
+	return i.item.InitPrimaryPresence
+}
+func (i BattleRecordIntf) InitSecondaryFaction() int {
+	return i.item.InitSecondaryFaction
+}
+func (i BattleRecordIntf) InitSecondaryPresence() int {
+	return i.item.InitSecondaryPresence
+}
+func (i BattleRecordIntf) Betrayals() [][2]int {
+	return i.item.Betrayals
+}
+func (i BattleRecordIntf) Events() []overpower.CombatEvent {
+	return i.item.Events
+}
+
+// ------------------ POWERORDER ------------------ //
+
+type PowerOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Loc     hexagon.Coord `json:"loc"`
+	UpPower int           `json:"uppower"`
+	Deleted bool          `json:"-"`
+}
+
+type PowerOrderIntf struct {
+	item *PowerOrder
+}
+
+func (item *PowerOrder) Intf() overpower.PowerOrderDat {
+	return PowerOrderIntf{item}
+}
+
+func (i PowerOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i PowerOrderIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i PowerOrderIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i PowerOrderIntf) GID() int {
+	return i.item.GID
+}
+func (i PowerOrderIntf) FID() int {
+	return i.item.FID
+}
+func (i PowerOrderIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i PowerOrderIntf) UpPower() int {
+	return i.item.UpPower
+}
+func (i PowerOrderIntf) SetUpPower(x int) {
+	i.item.UpPower = x
+}
+
+// ------------------ TRUCE ------------------ //
+
+type Truce struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Loc     hexagon.Coord `json:"loc"`
+	Trucee  int           `json:"trucee"`
+	Hostile bool          `json:"hostile"`
+	Deleted bool          `json:"-"`
+}
+
+type TruceIntf struct {
+	item *Truce
+}
+
+func (item *Truce) Intf() overpower.TruceDat {
+	return TruceIntf{item}
+}
+
+func (i TruceIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i TruceIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i TruceIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i TruceIntf) GID() int {
+	return i.item.GID
+}
+func (i TruceIntf) FID() int {
+	return i.item.FID
+}
+func (i TruceIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i TruceIntf) Trucee() int {
+	return i.item.Trucee
+}
+func (i TruceIntf) Hostile() bool {
+	return i.item.Hostile
+}
+func (i TruceIntf) SetHostile(x bool) {
+	i.item.Hostile = x
+}
+
+// ------------------ TREATY ------------------ //
+
+type Treaty struct {
+	GID      int  `json:"gid"`
+	FID      int  `json:"fid"`
+	Partner  int  `json:"partner"`
+	Kind     int  `json:"kind"`
+	Accepted bool `json:"accepted"`
+	Deleted  bool `json:"-"`
+}
+
+type TreatyIntf struct {
+	item *Treaty
+}
+
+func (item *Treaty) Intf() overpower.TreatyDat {
+	return TreatyIntf{item}
+}
+
+func (i TreatyIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i TreatyIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i TreatyIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i TreatyIntf) GID() int {
+	return i.item.GID
+}
+func (i TreatyIntf) FID() int {
+	return i.item.FID
+}
+func (i TreatyIntf) Partner() int {
+	return i.item.Partner
+}
+func (i TreatyIntf) Kind() int {
+	return i.item.Kind
+}
+func (i TreatyIntf) Accepted() bool {
+	return i.item.Accepted
+}
+func (i TreatyIntf) SetKind(x int) {
+	i.item.Kind = x
+}
+func (i TreatyIntf) SetAccepted(x bool) {
+	i.item.Accepted = x
+}
+
+// ------------------ TREATYBREAK ------------------ //
+
+type TreatyBreak struct {
+	GID     int  `json:"gid"`
+	Turn    int  `json:"turn"`
+	FID     int  `json:"fid"`
+	Partner int  `json:"partner"`
+	Kind    int  `json:"kind"`
+	Deleted bool `json:"-"`
+}
+
+type TreatyBreakIntf struct {
+	item *TreatyBreak
+}
+
+func (item *TreatyBreak) Intf() overpower.TreatyBreakDat {
+	return TreatyBreakIntf{item}
+}
+
+func (i TreatyBreakIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i TreatyBreakIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i TreatyBreakIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i TreatyBreakIntf) GID() int {
+	return i.item.GID
+}
+func (i TreatyBreakIntf) Turn() int {
+	return i.item.Turn
+}
+func (i TreatyBreakIntf) FID() int {
+	return i.item.FID
+}
+func (i TreatyBreakIntf) Partner() int {
+	return i.item.Partner
+}
+func (i TreatyBreakIntf) Kind() int {
+	return i.item.Kind
+}
+
+// ------------------ VISIONGRANT ------------------ //
+
+type VisionGrant struct {
+	GID     int  `json:"gid"`
+	FID     int  `json:"fid"`
+	Grantee int  `json:"grantee"`
+	Deleted bool `json:"-"`
+}
+
+type VisionGrantIntf struct {
+	item *VisionGrant
+}
+
+func (item *VisionGrant) Intf() overpower.VisionGrantDat {
+	return VisionGrantIntf{item}
+}
+
+func (i VisionGrantIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i VisionGrantIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i VisionGrantIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i VisionGrantIntf) GID() int {
+	return i.item.GID
+}
+func (i VisionGrantIntf) FID() int {
+	return i.item.FID
+}
+func (i VisionGrantIntf) Grantee() int {
+	return i.item.Grantee
+}
+
+// ------------------ STANDING ------------------ //
+
+type Standing struct {
+	GID      int  `json:"gid"`
+	FID      int  `json:"fid"`
+	Rank     int  `json:"rank"`
+	Planets  int  `json:"planets"`
+	Presence int  `json:"presence"`
+	Ships    int  `json:"ships"`
+	Deleted  bool `json:"-"`
+}
+
+type StandingIntf struct {
+	item *Standing
+}
+
+func (item *Standing) Intf() overpower.StandingDat {
+	return StandingIntf{item}
+}
+
+func (i StandingIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i StandingIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i StandingIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i StandingIntf) GID() int {
+	return i.item.GID
+}
+func (i StandingIntf) FID() int {
+	return i.item.FID
+}
+func (i StandingIntf) Rank() int {
+	return i.item.Rank
+}
+func (i StandingIntf) Planets() int {
+	return i.item.Planets
+}
+func (i StandingIntf) Presence() int {
+	return i.item.Presence
+}
+func (i StandingIntf) Ships() int {
+	return i.item.Ships
+}
+
+// ------------------ HOME REACH ------------------ //
+
+type HomeReach struct {
+	GID        int           `json:"gid"`
+	FID        int           `json:"fid"`
+	Loc        hexagon.Coord `json:"loc"`
+	Reach      int           `json:"reach"`
+	Planets    int           `json:"planets"`
+	Antimatter int           `json:"antimatter"`
+	Tachyons   int           `json:"tachyons"`
+	Deleted    bool          `json:"-"`
+}
+
+type HomeReachIntf struct {
+	item *HomeReach
+}
+
+func (item *HomeReach) Intf() overpower.HomeReachDat {
+	return HomeReachIntf{item}
+}
+
+func (i HomeReachIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i HomeReachIntf) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, i.item)
+}
+func (i HomeReachIntf) DELETE() {
+	i.item.Deleted = true
+}
+
+func (i HomeReachIntf) GID() int {
+	return i.item.GID
+}
+func (i HomeReachIntf) FID() int {
+	return i.item.FID
+}
+func (i HomeReachIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+func (i HomeReachIntf) Reach() int {
+	return i.item.Reach
+}
+func (i HomeReachIntf) Planets() int {
+	return i.item.Planets
+}
+func (i HomeReachIntf) Antimatter() int {
+	return i.item.Antimatter
+}
+func (i HomeReachIntf) Tachyons() int {
+	return i.item.Tachyons
+}
@@ -0,0 +1,200 @@
+package memsource
+
+import (
+	"fmt"
+	"mule/hexagon"
+	"mule/overpower"
+	"sort"
+)
+
+// Replay rebuilds a game from its start: the galaxy is made again from the
+// game's seed for the factions in the game's seats and each archived turn
+// is run with the orders it had, with factions that quit taken out on the
+// turn they quit.  The factions given only lend their names; games from
+// before seats were recorded must give their full list, since the FIDs
+// decide placement.
+func Replay(game overpower.GameDat, factions []overpower.FactionDat, archive []*TurnOrders) (*Source, error) {
+	s := New(game.GID())
+	s.GameItem.Owner = game.Owner()
+	s.GameItem.Name = game.Name()
+	s.GameItem.ToWin = game.ToWin()
+	s.GameItem.Seed = game.Seed()
+	s.GameItem.Rules = game.Rules()
+	known := map[int]overpower.FactionDat{}
+	for _, f := range factions {
+		known[f.FID()] = f
+	}
+	seats := game.Seats()
+	if len(seats) == 0 {
+		for _, f := range factions {
+			seats = append(seats, f.FID())
+		}
+	}
+	for _, fid := range seats {
+		f := &Faction{GID: s.GID, FID: fid}
+		if k, ok := known[fid]; ok {
+			f.Owner = k.Owner()
+			f.Name = k.Name()
+		}
+		s.FactionList = append(s.FactionList, f)
+	}
+	if err := overpower.MakeGalaxy(s, overpower.GameGenerator(game)); err != nil {
+		return nil, err
+	}
+	if len(game.Seats()) > 0 && !sameInts(s.GameItem.Seats, game.Seats()) {
+		return nil, fmt.Errorf("replay seated %v, game seated %v", s.GameItem.Seats, game.Seats())
+	}
+	quit := func(turn int) {
+		quits := game.Quits()
+		for i, fid := range game.Seats() {
+			if i < len(quits) && quits[i] == turn {
+				overpower.RecordQuit(s.GameItem.Intf(), fid)
+				s.Quit(fid)
+			}
+		}
+	}
+	turns := make([]*TurnOrders, len(archive))
+	copy(turns, archive)
+	sort.Sort(sortTurnOrders(turns))
+	for _, ar := range turns {
+		if ar.Turn != s.GameItem.Turn {
+			return nil, fmt.Errorf("replay at turn %d found orders for turn %d", s.GameItem.Turn, ar.Turn)
+		}
+		quit(ar.Turn)
+		s.LaunchOrderList = s.LaunchOrderList[:0]
+		for _, o := range ar.LaunchOrders {
+			cp := *o
+			s.LaunchOrderList = append(s.LaunchOrderList, &cp)
+		}
+		s.ShipOrderList = s.ShipOrderList[:0]
+		for _, o := range ar.ShipOrders {
+			cp := *o
+			s.ShipOrderList = append(s.ShipOrderList, &cp)
+		}
+		s.PowerOrderList = s.PowerOrderList[:0]
+		for _, po := range ar.PowerOrders {
+			cp := *po
+			s.PowerOrderList = append(s.PowerOrderList, &cp)
+		}
+		s.TruceList = s.TruceList[:0]
+		for _, tr := range ar.Truces {
+			cp := *tr
+			s.TruceList = append(s.TruceList, &cp)
+		}
+		s.TreatyList = s.TreatyList[:0]
+		for _, tr := range ar.Treaties {
+			cp := *tr
+			s.TreatyList = append(s.TreatyList, &cp)
+		}
+		s.VisionGrantList = s.VisionGrantList[:0]
+		for _, vg := range ar.VisionGrants {
+			cp := *vg
+			s.VisionGrantList = append(s.VisionGrantList, &cp)
+		}
+		s.ConditionalList = s.ConditionalList[:0]
+		for _, o := range ar.Conditionals {
+			cp := *o
+			s.ConditionalList = append(s.ConditionalList, &cp)
+		}
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			return nil, failE
+		}
+	}
+	quit(s.GameItem.Turn)
+	return s, nil
+}
+
+// Diff lists each way the given planets and ships differ from those held
+// by s.
+func (s *Source) Diff(planets []overpower.PlanetDat, ships []overpower.ShipDat) []string {
+	var diffs []string
+	mine := map[hexagon.Coord]*Planet{}
+	for _, pl := range s.PlanetList {
+		if !pl.Deleted {
+			mine[pl.Loc] = pl
+		}
+	}
+	for _, pl := range planets {
+		loc := pl.Loc()
+		want, ok := mine[loc]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("planet %v: not in replay", loc))
+			continue
+		}
+		delete(mine, loc)
+		got := &Planet{
+			GID:               want.GID,
+			Loc:               loc,
+			Name:              pl.Name(),
+			PrimaryFaction:    pl.PrimaryFaction(),
+			PrimaryPresence:   pl.PrimaryPresence(),
+			PrimaryPower:      pl.PrimaryPower(),
+			SecondaryFaction:  pl.SecondaryFaction(),
+			SecondaryPresence: pl.SecondaryPresence(),
+			SecondaryPower:    pl.SecondaryPower(),
+			Antimatter:        pl.Antimatter(),
+			Tachyons:          pl.Tachyons(),
+			Size:              pl.Size(),
+			Radar:             pl.Radar(),
+		}
+		if *got != *want {
+			diffs = append(diffs, fmt.Sprintf("planet %v: stored %+v, replay %+v", loc, *got, *want))
+		}
+	}
+	for loc, _ := range mine {
+		diffs = append(diffs, fmt.Sprintf("planet %v: only in replay", loc))
+	}
+	myShips := map[int]*Ship{}
+	for _, sh := range s.ShipList {
+		if !sh.Deleted {
+			myShips[sh.SID] = sh
+		}
+	}
+	for _, sh := range ships {
+		sid := sh.SID()
+		want, ok := myShips[sid]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("ship %d: not in replay", sid))
+			continue
+		}
+		delete(myShips, sid)
+		if sh.FID() != want.FID || sh.Size() != want.Size || sh.Power() != want.Power || sh.Launched() != want.Launched || !samePath(sh.Path(), want.Path) {
+			diffs = append(diffs, fmt.Sprintf("ship %d: stored fid %d size %d power %d launched %d path %v, replay %+v", sid, sh.FID(), sh.Size(), sh.Power(), sh.Launched(), sh.Path(), *want))
+		}
+	}
+	for sid, _ := range myShips {
+		diffs = append(diffs, fmt.Sprintf("ship %d: only in replay", sid))
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, x := range a {
+		if x != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func samePath(a, b hexagon.CoordList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, pt := range a {
+		if pt != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type sortTurnOrders []*TurnOrders
+
+func (s sortTurnOrders) Len() int           { return len(s) }
+func (s sortTurnOrders) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortTurnOrders) Less(i, j int) bool { return s[i].Turn < s[j].Turn }
@@ -0,0 +1,782 @@
+// Package memsource is an in-memory overpower.Source, letting galaxy
+// creation and turn resolution run without a database.  Every item the
+// engine creates or changes is kept in the exported lists of the Source so
+// tests and simulations can inspect the results directly.
+package memsource
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+)
+
+var _ overpower.Source = &Source{}
+
+type Source struct {
+	GID               int
+	GameItem          *Game
+	FactionList       []*Faction
+	PlanetList        []*Planet
+	PlanetViewList    []*PlanetView
+	MapViewList       []*MapView
+	ShipList          []*Ship
+	ShipViewList      []*ShipView
+	LaunchOrderList   []*LaunchOrder
+	ShipOrderList     []*ShipOrder
+	StandingOrderList []*StandingOrder
+	ConditionalList   []*ConditionalOrder
+	ConditionRecords  []*ConditionRecord
+	LaunchRecordList  []*LaunchRecord
+	BattleRecordList  []*BattleRecord
+	PowerOrderList    []*PowerOrder
+	TruceList         []*Truce
+	TreatyList        []*Treaty
+	TreatyBreakList   []*TreatyBreak
+	VisionGrantList   []*VisionGrant
+	StandingList      []*Standing
+	HomeReachList     []*HomeReach
+	Archive           []*TurnOrders
+}
+
+// TurnOrders holds the orders in place when a turn was run.
+type TurnOrders struct {
+	Turn         int
+	LaunchOrders []*LaunchOrder
+	ShipOrders   []*ShipOrder
+	PowerOrders  []*PowerOrder
+	Truces       []*Truce
+	Treaties     []*Treaty
+	VisionGrants []*VisionGrant
+	Conditionals []*ConditionalOrder
+}
+
+func New(gid int) *Source {
+	return &Source{
+		GID:      gid,
+		GameItem: &Game{GID: gid, Rules: overpower.DefaultRules()},
+	}
+}
+
+// ------------ SETUP ------------ //
+
+func (s *Source) AddFaction(owner, name string) *Faction {
+	var fid int
+	for _, f := range s.FactionList {
+		if f.FID > fid {
+			fid = f.FID
+		}
+	}
+	f := &Faction{
+		GID:   s.GID,
+		FID:   fid + 1,
+		Owner: owner,
+		Name:  name,
+	}
+	s.FactionList = append(s.FactionList, f)
+	return f
+}
+
+func (s *Source) AddLaunchOrder(fid, size int, source, target hexagon.Coord) *LaunchOrder {
+	o := &LaunchOrder{
+		GID:    s.GID,
+		FID:    fid,
+		Source: source,
+		Target: target,
+		Size:   size,
+	}
+	s.LaunchOrderList = append(s.LaunchOrderList, o)
+	return o
+}
+
+// AddRedirect orders the ship to turn for the planet at target.
+func (s *Source) AddRedirect(fid, sid int, target hexagon.Coord) *ShipOrder {
+	o := &ShipOrder{
+		GID:    s.GID,
+		FID:    fid,
+		SID:    sid,
+		Target: target,
+	}
+	s.ShipOrderList = append(s.ShipOrderList, o)
+	return o
+}
+
+// AddMerge orders the ship to join the ship of SID into.
+func (s *Source) AddMerge(fid, sid, into int) *ShipOrder {
+	o := &ShipOrder{
+		GID:   s.GID,
+		FID:   fid,
+		SID:   sid,
+		Merge: into,
+	}
+	s.ShipOrderList = append(s.ShipOrderList, o)
+	return o
+}
+
+func (s *Source) AddPowerOrder(fid, upPower int, loc hexagon.Coord) *PowerOrder {
+	po := &PowerOrder{
+		GID:     s.GID,
+		FID:     fid,
+		Loc:     loc,
+		UpPower: upPower,
+	}
+	s.PowerOrderList = append(s.PowerOrderList, po)
+	return po
+}
+
+// AddStandingOrder launches size ships, or with percent set that percent
+// of the faction's presence, from source to target every turn, for turns
+// turns or until cancelled if turns is 0.
+func (s *Source) AddStandingOrder(fid, size, percent, turns int, source, target hexagon.Coord) *StandingOrder {
+	o := &StandingOrder{
+		GID:     s.GID,
+		FID:     fid,
+		Source:  source,
+		Target:  target,
+		Size:    size,
+		Percent: percent,
+		Turns:   turns,
+	}
+	s.StandingOrderList = append(s.StandingOrderList, o)
+	return o
+}
+
+// AddConditionalOrder queues o for its faction under the next free CID.
+func (s *Source) AddConditionalOrder(o *ConditionalOrder) *ConditionalOrder {
+	var cid int
+	for _, test := range s.ConditionalList {
+		if test.FID == o.FID && test.CID > cid {
+			cid = test.CID
+		}
+	}
+	o.GID = s.GID
+	o.CID = cid + 1
+	s.ConditionalList = append(s.ConditionalList, o)
+	return o
+}
+
+func (s *Source) AddTruce(fid, trucee int, loc hexagon.Coord) *Truce {
+	tr := &Truce{
+		GID:    s.GID,
+		FID:    fid,
+		Loc:    loc,
+		Trucee: trucee,
+	}
+	s.TruceList = append(s.TruceList, tr)
+	return tr
+}
+
+// AddTreaty offers a treaty of kind from fid to partner, already accepted
+// if accepted is set.
+func (s *Source) AddTreaty(fid, partner, kind int, accepted bool) *Treaty {
+	tr := &Treaty{
+		GID:      s.GID,
+		FID:      fid,
+		Partner:  partner,
+		Kind:     kind,
+		Accepted: accepted,
+	}
+	s.TreatyList = append(s.TreatyList, tr)
+	return tr
+}
+
+// AddVisionGrant lets grantee see what fid sees.
+func (s *Source) AddVisionGrant(fid, grantee int) *VisionGrant {
+	vg := &VisionGrant{
+		GID:     s.GID,
+		FID:     fid,
+		Grantee: grantee,
+	}
+	s.VisionGrantList = append(s.VisionGrantList, vg)
+	return vg
+}
+
+// Quit takes a faction out of the game as the database does when its row
+// is deleted: its ships, views and orders go, as do truces, treaties and
+// grants with it, while planets keep its presence with no faction named.
+func (s *Source) Quit(fid int) {
+	for _, f := range s.FactionList {
+		if f.FID == fid {
+			f.Deleted = true
+		}
+	}
+	for _, pl := range s.PlanetList {
+		if pl.PrimaryFaction == fid {
+			pl.PrimaryFaction = 0
+		}
+		if pl.SecondaryFaction == fid {
+			pl.SecondaryFaction = 0
+		}
+	}
+	for _, pv := range s.PlanetViewList {
+		if pv.FID == fid {
+			pv.Deleted = true
+		}
+		if pv.PrimaryFaction == fid {
+			pv.PrimaryFaction = 0
+		}
+		if pv.SecondaryFaction == fid {
+			pv.SecondaryFaction = 0
+		}
+	}
+	for _, mv := range s.MapViewList {
+		if mv.FID == fid {
+			mv.Deleted = true
+		}
+	}
+	for _, sh := range s.ShipList {
+		if sh.FID == fid {
+			sh.Deleted = true
+		}
+	}
+	for _, sv := range s.ShipViewList {
+		if sv.FID == fid || sv.Controller == fid {
+			sv.Deleted = true
+		}
+	}
+	for _, o := range s.LaunchOrderList {
+		if o.FID == fid {
+			o.Deleted = true
+		}
+	}
+	for _, o := range s.ShipOrderList {
+		if o.FID == fid {
+			o.Deleted = true
+		}
+	}
+	for _, o := range s.StandingOrderList {
+		if o.FID == fid {
+			o.Deleted = true
+		}
+	}
+	for _, o := range s.ConditionalList {
+		if o.FID == fid {
+			o.Deleted = true
+		}
+	}
+	for _, po := range s.PowerOrderList {
+		if po.FID == fid {
+			po.Deleted = true
+		}
+	}
+	for _, tr := range s.TruceList {
+		if tr.FID == fid || tr.Trucee == fid {
+			tr.Deleted = true
+		}
+	}
+	for _, tr := range s.TreatyList {
+		if tr.FID == fid || tr.Partner == fid {
+			tr.Deleted = true
+		}
+	}
+	for _, vg := range s.VisionGrantList {
+		if vg.FID == fid || vg.Grantee == fid {
+			vg.Deleted = true
+		}
+	}
+}
+
+func (s *Source) PlanetAt(loc hexagon.Coord) *Planet {
+	for _, pl := range s.PlanetList {
+		if pl.Loc == loc && !pl.Deleted {
+			return pl
+		}
+	}
+	return nil
+}
+
+func (s *Source) HomePlanet(fid int) *Planet {
+	for _, pl := range s.PlanetList {
+		if pl.PrimaryFaction == fid && !pl.Deleted {
+			return pl
+		}
+	}
+	return nil
+}
+
+// PowerOrdersFor lists the faction's power orders for the turn.
+func (s *Source) PowerOrdersFor(fid int) []*PowerOrder {
+	var list []*PowerOrder
+	for _, po := range s.PowerOrderList {
+		if po.FID == fid && !po.Deleted {
+			list = append(list, po)
+		}
+	}
+	return list
+}
+
+// ------------ GET ------------ //
+
+func (s *Source) Game() (overpower.GameDat, error) {
+	if s.GameItem == nil || s.GameItem.Deleted {
+		return nil, overpower.ErrBadArgs
+	}
+	return s.GameItem.Intf(), nil
+}
+
+func (s *Source) Factions() ([]overpower.FactionDat, error) {
+	list := make([]overpower.FactionDat, 0, len(s.FactionList))
+	kept := s.FactionList[:0]
+	for _, item := range s.FactionList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.FactionList = kept
+	return list, nil
+}
+
+func (s *Source) Planets() ([]overpower.PlanetDat, error) {
+	list := make([]overpower.PlanetDat, 0, len(s.PlanetList))
+	kept := s.PlanetList[:0]
+	for _, item := range s.PlanetList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.PlanetList = kept
+	return list, nil
+}
+
+func (s *Source) LaunchOrders() ([]overpower.LaunchOrderDat, error) {
+	list := make([]overpower.LaunchOrderDat, 0, len(s.LaunchOrderList))
+	kept := s.LaunchOrderList[:0]
+	for _, item := range s.LaunchOrderList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.LaunchOrderList = kept
+	return list, nil
+}
+
+func (s *Source) Ships() ([]overpower.ShipDat, error) {
+	list := make([]overpower.ShipDat, 0, len(s.ShipList))
+	kept := s.ShipList[:0]
+	for _, item := range s.ShipList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.ShipList = kept
+	return list, nil
+}
+
+func (s *Source) Truces() ([]overpower.TruceDat, error) {
+	list := make([]overpower.TruceDat, 0, len(s.TruceList))
+	kept := s.TruceList[:0]
+	for _, item := range s.TruceList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.TruceList = kept
+	return list, nil
+}
+
+func (s *Source) Treaties() ([]overpower.TreatyDat, error) {
+	list := make([]overpower.TreatyDat, 0, len(s.TreatyList))
+	kept := s.TreatyList[:0]
+	for _, item := range s.TreatyList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.TreatyList = kept
+	return list, nil
+}
+
+func (s *Source) VisionGrants() ([]overpower.VisionGrantDat, error) {
+	list := make([]overpower.VisionGrantDat, 0, len(s.VisionGrantList))
+	kept := s.VisionGrantList[:0]
+	for _, item := range s.VisionGrantList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.VisionGrantList = kept
+	return list, nil
+}
+
+func (s *Source) PowerOrders() ([]overpower.PowerOrderDat, error) {
+	list := make([]overpower.PowerOrderDat, 0, len(s.PowerOrderList))
+	kept := s.PowerOrderList[:0]
+	for _, item := range s.PowerOrderList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.PowerOrderList = kept
+	return list, nil
+}
+
+func (s *Source) StandingOrders() ([]overpower.StandingOrderDat, error) {
+	list := make([]overpower.StandingOrderDat, 0, len(s.StandingOrderList))
+	kept := s.StandingOrderList[:0]
+	for _, item := range s.StandingOrderList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.StandingOrderList = kept
+	return list, nil
+}
+
+func (s *Source) ConditionalOrders() ([]overpower.ConditionalOrderDat, error) {
+	list := make([]overpower.ConditionalOrderDat, 0, len(s.ConditionalList))
+	kept := s.ConditionalList[:0]
+	for _, item := range s.ConditionalList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.ConditionalList = kept
+	return list, nil
+}
+
+func (s *Source) ShipOrders() ([]overpower.ShipOrderDat, error) {
+	list := make([]overpower.ShipOrderDat, 0, len(s.ShipOrderList))
+	kept := s.ShipOrderList[:0]
+	for _, item := range s.ShipOrderList {
+		if item.Deleted {
+			continue
+		}
+		kept = append(kept, item)
+		list = append(list, item.Intf())
+	}
+	s.ShipOrderList = kept
+	return list, nil
+}
+
+// ------------ MAKE ------------ //
+
+func (s *Source) NewPlanet(name string,
+	primaryFac, prPres, prPower,
+	secondaryFac, sePres, sePower,
+	antimatter, tachyons, size int,
+	loc hexagon.Coord,
+) overpower.PlanetDat {
+	pl := &Planet{
+		GID:               s.GID,
+		Name:              name,
+		Loc:               loc,
+		PrimaryFaction:    primaryFac,
+		PrimaryPresence:   prPres,
+		PrimaryPower:      prPower,
+		SecondaryFaction:  secondaryFac,
+		SecondaryPresence: sePres,
+		SecondaryPower:    sePower,
+		Antimatter:        antimatter,
+		Tachyons:          tachyons,
+		Size:              size,
+	}
+	s.PlanetList = append(s.PlanetList, pl)
+	return pl.Intf()
+}
+
+func (s *Source) NewPlanetView(fid int, pl overpower.PlanetDat, exodus bool) overpower.PlanetViewDat {
+	pv := &PlanetView{
+		GID:  s.GID,
+		FID:  fid,
+		Loc:  pl.Loc(),
+		Name: pl.Name(),
+	}
+	if pl.PrimaryFaction() == fid || pl.SecondaryFaction() == fid {
+		pv.Turn = 1
+		setPlanetView(pv, pl)
+	}
+	s.PlanetViewList = append(s.PlanetViewList, pv)
+	return pv.Intf()
+}
+
+func (s *Source) NewMapView(fid int, center hexagon.Coord) overpower.MapViewDat {
+	mv := &MapView{
+		GID:    s.GID,
+		FID:    fid,
+		Center: center,
+	}
+	s.MapViewList = append(s.MapViewList, mv)
+	return mv.Intf()
+}
+
+func (s *Source) NewShip(fid, sid, size, power, turn int, path hexagon.CoordList) overpower.ShipDat {
+	sh := &Ship{
+		GID:      s.GID,
+		FID:      fid,
+		SID:      sid,
+		Size:     size,
+		Power:    power,
+		Launched: turn,
+		Path:     path,
+	}
+	s.ShipList = append(s.ShipList, sh)
+	return sh.Intf()
+}
+
+func (s *Source) NewShipView(sh overpower.ShipDat, fid, turn int, loc, dest hexagon.NullCoord, trail hexagon.CoordList) overpower.ShipViewDat {
+	sv := &ShipView{
+		GID:        s.GID,
+		FID:        fid,
+		Turn:       turn,
+		Loc:        loc,
+		Dest:       dest,
+		Trail:      trail,
+		Controller: sh.FID(),
+		SID:        sh.SID(),
+		Size:       sh.Size(),
+		Power:      sh.Power(),
+	}
+	s.ShipViewList = append(s.ShipViewList, sv)
+	return sv.Intf()
+}
+
+func (s *Source) NewLaunchRecord(turn int, o overpower.LaunchOrderGet, ship overpower.ShipDat) {
+	lr := &LaunchRecord{
+		GID:       s.GID,
+		FID:       o.FID(),
+		Turn:      turn,
+		Source:    o.Source(),
+		Target:    o.Target(),
+		OrderSize: o.Size(),
+	}
+	if ship != nil {
+		lr.Size = ship.Size()
+	}
+	s.LaunchRecordList = append(s.LaunchRecordList, lr)
+}
+
+func (s *Source) NewConditionRecord(turn int, o overpower.ConditionalOrderDat, cause, size int) {
+	s.ConditionRecords = append(s.ConditionRecords, &ConditionRecord{
+		GID:    s.GID,
+		FID:    o.FID(),
+		Turn:   turn,
+		CID:    o.CID(),
+		Kind:   o.Kind(),
+		Loc:    o.Loc(),
+		Action: o.Action(),
+		Source: o.Source(),
+		Target: o.Target(),
+		Size:   size,
+		Power:  o.Power(),
+		Cause:  cause,
+	})
+}
+
+func (s *Source) NewTreatyBreak(turn, fid, partner, kind int) {
+	s.TreatyBreakList = append(s.TreatyBreakList, &TreatyBreak{
+		GID:     s.GID,
+		Turn:    turn,
+		FID:     fid,
+		Partner: partner,
+		Kind:    kind,
+	})
+}
+
+func (s *Source) NewBattleRecord(ship overpower.ShipDat, fid, turn,
+	initPrimaryFac, initPrPres,
+	initSecondaryFac, initSePres int,
+	result overpower.PlanetDat,
+	betrayals [][2]int,
+	events []overpower.CombatEvent,
+) {
+	var index int
+	for _, test := range s.BattleRecordList {
+		if test.FID == fid && test.Turn == turn {
+			index += 1
+		}
+	}
+	br := &BattleRecord{
+		GID:       s.GID,
+		FID:       fid,
+		Turn:      turn,
+		Index:     index,
+		Loc:       result.Loc(),
+		Betrayals: append([][2]int{}, betrayals...),
+		Events:    append([]overpower.CombatEvent{}, events...),
+
+		InitPrimaryFaction:    initPrimaryFac,
+		InitPrimaryPresence:   initPrPres,
+		InitSecondaryFaction:  initSecondaryFac,
+		InitSecondaryPresence: initSePres,
+
+		PrimaryFaction:    result.PrimaryFaction(),
+		PrimaryPresence:   result.PrimaryPresence(),
+		SecondaryFaction:  result.SecondaryFaction(),
+		SecondaryPresence: result.SecondaryPresence(),
+	}
+	if ship != nil {
+		br.ShipFaction = ship.FID()
+		br.ShipSize = ship.Size()
+	}
+	s.BattleRecordList = append(s.BattleRecordList, br)
+}
+
+func (s *Source) NewStanding(fid, rank, planets, presence, ships int) overpower.StandingDat {
+	st := &Standing{
+		GID:      s.GID,
+		FID:      fid,
+		Rank:     rank,
+		Planets:  planets,
+		Presence: presence,
+		Ships:    ships,
+	}
+	s.StandingList = append(s.StandingList, st)
+	return st.Intf()
+}
+
+func (s *Source) NewHomeReach(fid int, loc hexagon.Coord, reach, planets, antimatter, tachyons int) overpower.HomeReachDat {
+	hr := &HomeReach{
+		GID:        s.GID,
+		FID:        fid,
+		Loc:        loc,
+		Reach:      reach,
+		Planets:    planets,
+		Antimatter: antimatter,
+		Tachyons:   tachyons,
+	}
+	s.HomeReachList = append(s.HomeReachList, hr)
+	return hr.Intf()
+}
+
+func (s *Source) ArchiveOrders(turn int, launches []overpower.LaunchOrderDat, shipOrders []overpower.ShipOrderDat, powers []overpower.PowerOrderDat, truces []overpower.TruceDat, treaties []overpower.TreatyDat, grants []overpower.VisionGrantDat, conditions []overpower.ConditionalOrderDat) {
+	ar := &TurnOrders{Turn: turn}
+	for _, o := range launches {
+		ar.LaunchOrders = append(ar.LaunchOrders, &LaunchOrder{
+			GID:    s.GID,
+			FID:    o.FID(),
+			Source: o.Source(),
+			Target: o.Target(),
+			Size:   o.Size(),
+		})
+	}
+	for _, o := range shipOrders {
+		ar.ShipOrders = append(ar.ShipOrders, &ShipOrder{
+			GID:    s.GID,
+			FID:    o.FID(),
+			SID:    o.SID(),
+			Target: o.Target(),
+			Merge:  o.Merge(),
+		})
+	}
+	for _, po := range powers {
+		ar.PowerOrders = append(ar.PowerOrders, &PowerOrder{
+			GID:     s.GID,
+			FID:     po.FID(),
+			Loc:     po.Loc(),
+			UpPower: po.UpPower(),
+		})
+	}
+	for _, tr := range truces {
+		ar.Truces = append(ar.Truces, &Truce{
+			GID:     s.GID,
+			FID:     tr.FID(),
+			Loc:     tr.Loc(),
+			Trucee:  tr.Trucee(),
+			Hostile: tr.Hostile(),
+		})
+	}
+	for _, tr := range treaties {
+		ar.Treaties = append(ar.Treaties, &Treaty{
+			GID:      s.GID,
+			FID:      tr.FID(),
+			Partner:  tr.Partner(),
+			Kind:     tr.Kind(),
+			Accepted: tr.Accepted(),
+		})
+	}
+	for _, vg := range grants {
+		ar.VisionGrants = append(ar.VisionGrants, &VisionGrant{
+			GID:     s.GID,
+			FID:     vg.FID(),
+			Grantee: vg.Grantee(),
+		})
+	}
+	for _, o := range conditions {
+		ar.Conditionals = append(ar.Conditionals, &ConditionalOrder{
+			GID:    s.GID,
+			FID:    o.FID(),
+			CID:    o.CID(),
+			Kind:   o.Kind(),
+			Loc:    o.Loc(),
+			Within: o.Within(),
+			Action: o.Action(),
+			Source: o.Source(),
+			Target: o.Target(),
+			Size:   o.Size(),
+			Power:  o.Power(),
+		})
+	}
+	s.Archive = append(s.Archive, ar)
+}
+
+// ------------ CHANGE ------------ //
+
+func (s *Source) UpdatePlanetView(fid, turn int, planet overpower.PlanetDat) overpower.PlanetViewDat {
+	loc := planet.Loc()
+	var pv *PlanetView
+	for _, test := range s.PlanetViewList {
+		if test.FID == fid && test.Loc == loc && !test.Deleted {
+			pv = test
+			break
+		}
+	}
+	if pv == nil {
+		pv = &PlanetView{
+			GID:  s.GID,
+			FID:  fid,
+			Loc:  loc,
+			Name: planet.Name(),
+		}
+		s.PlanetViewList = append(s.PlanetViewList, pv)
+	}
+	pv.Turn = turn
+	setPlanetView(pv, planet)
+	return pv.Intf()
+}
+
+// ------------ DROP ------------ //
+
+func (s *Source) ClearLaunchOrders() error {
+	s.LaunchOrderList = nil
+	return nil
+}
+
+func (s *Source) ClearShipOrders() error {
+	s.ShipOrderList = nil
+	return nil
+}
+
+func (s *Source) ClearPowerOrders() error {
+	s.PowerOrderList = nil
+	return nil
+}
+
+func setPlanetView(pv *PlanetView, pl overpower.PlanetDat) {
+	pv.PrimaryFaction = pl.PrimaryFaction()
+	pv.PrimaryPresence = pl.PrimaryPresence()
+	pv.PrimaryPower = pl.PrimaryPower()
+	pv.SecondaryFaction = pl.SecondaryFaction()
+	pv.SecondaryPresence = pl.SecondaryPresence()
+	pv.SecondaryPower = pl.SecondaryPower()
+	pv.Antimatter = pl.Antimatter()
+	pv.Tachyons = pl.Tachyons()
+	pv.Radar = pl.Radar()
+}
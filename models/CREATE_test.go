@@ -7,6 +7,7 @@ import (
 )
 
 var RUNUPDATE byte = 1
+var RUNMIGRATE byte = 0
 
 func TestUpdateTables(t *testing.T) {
 	if RUNUPDATE == 1 {
@@ -22,6 +23,47 @@ func TestUpdateTables(t *testing.T) {
 	}
 }
 
+func TestMigrateTables(t *testing.T) {
+	if RUNMIGRATE == 1 {
+		log.Println("MIGRATING TABLES")
+		db, err := LoadDB()
+		ErrCheck(err)
+		err = PowerOrderTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Power orders migrated!")
+		err = GameTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Games migrated!")
+		err = FactionTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Factions migrated!")
+		err = BattleRecordTableMigrate(db)
+		ErrCheck(err)
+		log.Println("BattleRecords migrated!")
+		err = PlanetTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Planets migrated!")
+		err = ShipTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Ships migrated!")
+		err = ShipViewTableMigrate(db)
+		ErrCheck(err)
+		log.Println("ShipViews migrated!")
+		err = TruceTableMigrate(db)
+		ErrCheck(err)
+		log.Println("Truces migrated!")
+		err = PlanetViewTableMigrate(db)
+		ErrCheck(err)
+		log.Println("PlanetViews migrated!")
+		err = ArchiveTablesMigrate(db)
+		ErrCheck(err)
+		log.Println("Archives migrated!")
+		err = LaunchOrderArchiveTableMigrate(db)
+		ErrCheck(err)
+		log.Println("LaunchOrderArchives migrated!")
+	}
+}
+
 func ErrCheck(err error) {
 	if err == nil {
 		return
@@ -84,5 +84,5 @@ func MakeTest(m *Manager) (logE, failE error) {
 	}
 	log.Println("MADE FACTIONS", madeF)
 	source := NewSource(m, g.GID)
-	return nil, overpower.MakeGalaxy(source, false)
+	return nil, overpower.MakeGalaxy(source, "standard")
 }
@@ -27,6 +27,7 @@ type BattleRecord struct {
 	ShipSize              int           `json:"shipsize"`
 
 	Betrayals db.IntList `json:"betrayals"`
+	Events    db.IntList `json:"-"`
 
 	PrimaryFaction    sql.NullInt64 `json:"primaryfaction"`
 	PrimaryPresence   int           `json:"primarypresence"`
@@ -91,6 +92,8 @@ func (item *BattleRecord) SQLVal(name string) interface{} {
 		return item.InitSecondaryPresence
 	case "betrayals":
 		return item.Betrayals
+	case "events":
+		return item.Events
 	}
 	return nil
 }
@@ -131,6 +134,8 @@ func (item *BattleRecord) SQLPtr(name string) interface{} {
 		return &item.InitSecondaryPresence
 	case "betrayals":
 		return &item.Betrayals
+	case "events":
+		return &item.Events
 	}
 	return nil
 }
@@ -141,11 +146,12 @@ func (item *BattleRecord) SQLTable() string {
 func (i BattleRecordIntf) MarshalJSON() ([]byte, error) {
 	s := struct {
 		*BattleRecord
-		InitPrimaryFaction   int `json:"initprimaryfaction"`
-		InitSecondaryFaction int `json:"initsecondaryfaction"`
-		PrimaryFaction       int `json:"primaryfaction"`
-		SecondaryFaction     int `json:"secondaryfaction"`
-		ShipFaction          int `json:"shipfaction"`
+		InitPrimaryFaction   int                     `json:"initprimaryfaction"`
+		InitSecondaryFaction int                     `json:"initsecondaryfaction"`
+		PrimaryFaction       int                     `json:"primaryfaction"`
+		SecondaryFaction     int                     `json:"secondaryfaction"`
+		ShipFaction          int                     `json:"shipfaction"`
+		Events               []overpower.CombatEvent `json:"events"`
 	}{
 		BattleRecord:         i.item,
 		InitPrimaryFaction:   i.InitPrimaryFaction(),
@@ -153,6 +159,7 @@ func (i BattleRecordIntf) MarshalJSON() ([]byte, error) {
 		PrimaryFaction:       i.PrimaryFaction(),
 		SecondaryFaction:     i.SecondaryFaction(),
 		ShipFaction:          i.ShipFaction(),
+		Events:               i.Events(),
 	}
 	return json.Marshal(s)
 }
@@ -335,6 +342,10 @@ func (i BattleRecordIntf) Betrayals() [][2]int {
 	return r
 }
 
+func (i BattleRecordIntf) Events() []overpower.CombatEvent {
+	return overpower.DecodeCombatEvents(i.item.Events)
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -419,6 +430,7 @@ func (group *BattleRecordGroup) InsertCols() []string {
 		"initsecondaryfaction",
 		"initsecondarypresence",
 		"betrayals",
+		"events",
 	}
 }
 
@@ -445,6 +457,7 @@ func (group *BattleRecordGroup) SelectCols() []string {
 		"initsecondaryfaction",
 		"initsecondarypresence",
 		"betrayals",
+		"events",
 	}
 }
 
@@ -535,6 +548,7 @@ func BattleRecordTableCreate(d db.DBer) error {
 	secondarypresence int NOT NULL,
 
 	betrayals int[],
+	events int[] NOT NULL DEFAULT '{}',
 
 	FOREIGN KEY(gid, locx, locy) REFERENCES planet ON DELETE CASCADE,
 	PRIMARY KEY(gid, fid, turn, index)
@@ -546,6 +560,13 @@ func BattleRecordTableCreate(d db.DBer) error {
 	return nil
 }
 
+// BattleRecordTableMigrate adds the columns a battlerecord table made before them lacks.
+func BattleRecordTableMigrate(d db.DBer) error {
+	return addColumns(d, "battlerecord",
+		"events int[] NOT NULL DEFAULT '{}'",
+	)
+}
+
 func BattleRecordTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS battlerecord CASCADE"
 	err := db.Exec(d, false, query)
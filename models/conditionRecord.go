@@ -0,0 +1,386 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type ConditionRecord struct {
+	GID    int           `json:"gid"`
+	FID    int           `json:"fid"`
+	Turn   int           `json:"turn"`
+	CID    int           `json:"cid"`
+	Kind   int           `json:"kind"`
+	Loc    hexagon.Coord `json:"loc"`
+	Action int           `json:"action"`
+	Source hexagon.Coord `json:"source"`
+	Target hexagon.Coord `json:"target"`
+	Size   int           `json:"size"`
+	Power  int           `json:"power"`
+	Cause  int           `json:"cause"`
+	sql    gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewConditionRecord() *ConditionRecord {
+	return &ConditionRecord{
+	//
+	}
+}
+
+type ConditionRecordIntf struct {
+	item *ConditionRecord
+}
+
+func (item *ConditionRecord) Intf() overpower.ConditionRecordDat {
+	return &ConditionRecordIntf{item}
+}
+
+func (i ConditionRecordIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *ConditionRecord) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "turn":
+		return item.Turn
+	case "cid":
+		return item.CID
+	case "kind":
+		return item.Kind
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "action":
+		return item.Action
+	case "sourcex":
+		return item.Source[0]
+	case "sourcey":
+		return item.Source[1]
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "size":
+		return item.Size
+	case "power":
+		return item.Power
+	case "cause":
+		return item.Cause
+	}
+	return nil
+}
+
+func (item *ConditionRecord) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "turn":
+		return &item.Turn
+	case "cid":
+		return &item.CID
+	case "kind":
+		return &item.Kind
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "action":
+		return &item.Action
+	case "sourcex":
+		return &item.Source[0]
+	case "sourcey":
+		return &item.Source[1]
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "size":
+		return &item.Size
+	case "power":
+		return &item.Power
+	case "cause":
+		return &item.Cause
+	}
+	return nil
+}
+func (item *ConditionRecord) SQLTable() string {
+	return "conditionrecord"
+}
+
+func (i ConditionRecordIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ConditionRecordIntf) UnmarshalJSON(data []byte) error {
+	i.item = &ConditionRecord{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i ConditionRecordIntf) GID() int {
+	return i.item.GID
+}
+
+func (i ConditionRecordIntf) FID() int {
+	return i.item.FID
+}
+
+func (i ConditionRecordIntf) Turn() int {
+	return i.item.Turn
+}
+
+func (i ConditionRecordIntf) CID() int {
+	return i.item.CID
+}
+
+func (i ConditionRecordIntf) Kind() int {
+	return i.item.Kind
+}
+
+func (i ConditionRecordIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+
+func (i ConditionRecordIntf) Action() int {
+	return i.item.Action
+}
+
+func (i ConditionRecordIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+
+func (i ConditionRecordIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+
+func (i ConditionRecordIntf) Size() int {
+	return i.item.Size
+}
+
+func (i ConditionRecordIntf) Power() int {
+	return i.item.Power
+}
+
+func (i ConditionRecordIntf) Cause() int {
+	return i.item.Cause
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type ConditionRecordGroup struct {
+	List []*ConditionRecord
+}
+
+func NewConditionRecordGroup() *ConditionRecordGroup {
+	return &ConditionRecordGroup{
+		List: []*ConditionRecord{},
+	}
+}
+
+func (item *ConditionRecord) SQLGroup() gp.SQLGrouper {
+	return NewConditionRecordGroup()
+}
+
+func (group *ConditionRecordGroup) New() gp.SQLer {
+	item := NewConditionRecord()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *ConditionRecordGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *ConditionRecordGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionRecordGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionRecordGroup) SQLTable() string {
+	return "conditionrecord"
+}
+
+func (group *ConditionRecordGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"turn",
+		"cid",
+	}
+}
+
+func (group *ConditionRecordGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"turn",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+		"cause",
+	}
+}
+
+func (group *ConditionRecordGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *ConditionRecordGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"turn",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+		"cause",
+	}
+}
+
+func (group *ConditionRecordGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type ConditionRecordSession struct {
+	*ConditionRecordGroup
+	*gp.Session
+}
+
+func NewConditionRecordSession(d db.DBer) *ConditionRecordSession {
+	group := NewConditionRecordGroup()
+	return &ConditionRecordSession{
+		ConditionRecordGroup: group,
+		Session:              gp.NewSession(group, d),
+	}
+}
+
+func (s *ConditionRecordSession) Select(conditions ...interface{}) ([]overpower.ConditionRecordDat, error) {
+	cur := len(s.ConditionRecordGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "ConditionRecord select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertConditionRecord2Intf(s.ConditionRecordGroup.List[cur:]...), nil
+}
+
+func (s *ConditionRecordSession) SelectWhere(where sq.Condition) ([]overpower.ConditionRecordDat, error) {
+	cur := len(s.ConditionRecordGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "ConditionRecord SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertConditionRecord2Intf(s.ConditionRecordGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertConditionRecord2Struct(list ...overpower.ConditionRecordDat) ([]*ConditionRecord, error) {
+	mylist := make([]*ConditionRecord, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(ConditionRecordIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad ConditionRecord struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertConditionRecord2Intf(list ...*ConditionRecord) []overpower.ConditionRecordDat {
+	converted := make([]overpower.ConditionRecordDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func ConditionRecordTableCreate(d db.DBer) error {
+	query := `create table conditionrecord(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	turn integer NOT NULL,
+	cid integer NOT NULL,
+	kind integer NOT NULL,
+	locx integer NOT NULL,
+	locy integer NOT NULL,
+	action integer NOT NULL,
+	sourcex integer NOT NULL,
+	sourcey integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	size integer NOT NULL,
+	power integer NOT NULL,
+	cause integer NOT NULL,
+	PRIMARY KEY(gid, fid, turn, cid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionRecord table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func ConditionRecordTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS conditionrecord CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionRecord table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
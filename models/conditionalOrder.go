@@ -0,0 +1,375 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type ConditionalOrder struct {
+	GID    int           `json:"gid"`
+	FID    int           `json:"fid"`
+	CID    int           `json:"cid"`
+	Kind   int           `json:"kind"`
+	Loc    hexagon.Coord `json:"loc"`
+	Within int           `json:"within"`
+	Action int           `json:"action"`
+	Source hexagon.Coord `json:"source"`
+	Target hexagon.Coord `json:"target"`
+	Size   int           `json:"size"`
+	Power  int           `json:"power"`
+	sql    gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewConditionalOrder() *ConditionalOrder {
+	return &ConditionalOrder{
+	//
+	}
+}
+
+type ConditionalOrderIntf struct {
+	item *ConditionalOrder
+}
+
+func (item *ConditionalOrder) Intf() overpower.ConditionalOrderDat {
+	return &ConditionalOrderIntf{item}
+}
+
+func (i ConditionalOrderIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *ConditionalOrder) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "cid":
+		return item.CID
+	case "kind":
+		return item.Kind
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "within":
+		return item.Within
+	case "action":
+		return item.Action
+	case "sourcex":
+		return item.Source[0]
+	case "sourcey":
+		return item.Source[1]
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "size":
+		return item.Size
+	case "power":
+		return item.Power
+	}
+	return nil
+}
+
+func (item *ConditionalOrder) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "cid":
+		return &item.CID
+	case "kind":
+		return &item.Kind
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "within":
+		return &item.Within
+	case "action":
+		return &item.Action
+	case "sourcex":
+		return &item.Source[0]
+	case "sourcey":
+		return &item.Source[1]
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "size":
+		return &item.Size
+	case "power":
+		return &item.Power
+	}
+	return nil
+}
+func (item *ConditionalOrder) SQLTable() string {
+	return "conditionalorder"
+}
+
+func (i ConditionalOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ConditionalOrderIntf) UnmarshalJSON(data []byte) error {
+	i.item = &ConditionalOrder{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i ConditionalOrderIntf) GID() int {
+	return i.item.GID
+}
+
+func (i ConditionalOrderIntf) FID() int {
+	return i.item.FID
+}
+
+func (i ConditionalOrderIntf) CID() int {
+	return i.item.CID
+}
+
+func (i ConditionalOrderIntf) Kind() int {
+	return i.item.Kind
+}
+
+func (i ConditionalOrderIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+
+func (i ConditionalOrderIntf) Within() int {
+	return i.item.Within
+}
+
+func (i ConditionalOrderIntf) Action() int {
+	return i.item.Action
+}
+
+func (i ConditionalOrderIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+
+func (i ConditionalOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+
+func (i ConditionalOrderIntf) Size() int {
+	return i.item.Size
+}
+
+func (i ConditionalOrderIntf) Power() int {
+	return i.item.Power
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type ConditionalOrderGroup struct {
+	List []*ConditionalOrder
+}
+
+func NewConditionalOrderGroup() *ConditionalOrderGroup {
+	return &ConditionalOrderGroup{
+		List: []*ConditionalOrder{},
+	}
+}
+
+func (item *ConditionalOrder) SQLGroup() gp.SQLGrouper {
+	return NewConditionalOrderGroup()
+}
+
+func (group *ConditionalOrderGroup) New() gp.SQLer {
+	item := NewConditionalOrder()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *ConditionalOrderGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *ConditionalOrderGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionalOrderGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionalOrderGroup) SQLTable() string {
+	return "conditionalorder"
+}
+
+func (group *ConditionalOrderGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"cid",
+	}
+}
+
+func (group *ConditionalOrderGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"within",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+	}
+}
+
+func (group *ConditionalOrderGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *ConditionalOrderGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"within",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+	}
+}
+
+func (group *ConditionalOrderGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type ConditionalOrderSession struct {
+	*ConditionalOrderGroup
+	*gp.Session
+}
+
+func NewConditionalOrderSession(d db.DBer) *ConditionalOrderSession {
+	group := NewConditionalOrderGroup()
+	return &ConditionalOrderSession{
+		ConditionalOrderGroup: group,
+		Session:               gp.NewSession(group, d),
+	}
+}
+
+func (s *ConditionalOrderSession) Select(conditions ...interface{}) ([]overpower.ConditionalOrderDat, error) {
+	cur := len(s.ConditionalOrderGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "ConditionalOrder select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertConditionalOrder2Intf(s.ConditionalOrderGroup.List[cur:]...), nil
+}
+
+func (s *ConditionalOrderSession) SelectWhere(where sq.Condition) ([]overpower.ConditionalOrderDat, error) {
+	cur := len(s.ConditionalOrderGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "ConditionalOrder SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertConditionalOrder2Intf(s.ConditionalOrderGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertConditionalOrder2Struct(list ...overpower.ConditionalOrderDat) ([]*ConditionalOrder, error) {
+	mylist := make([]*ConditionalOrder, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(ConditionalOrderIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad ConditionalOrder struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertConditionalOrder2Intf(list ...*ConditionalOrder) []overpower.ConditionalOrderDat {
+	converted := make([]overpower.ConditionalOrderDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func ConditionalOrderTableCreate(d db.DBer) error {
+	query := `create table conditionalorder(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	cid integer NOT NULL,
+	kind integer NOT NULL,
+	locx integer NOT NULL,
+	locy integer NOT NULL,
+	within integer NOT NULL,
+	action integer NOT NULL,
+	sourcex integer NOT NULL,
+	sourcey integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	size integer NOT NULL,
+	power integer NOT NULL,
+	FOREIGN KEY(gid, locx, locy) REFERENCES planet ON DELETE CASCADE,
+	FOREIGN KEY(gid, targetx, targety) REFERENCES planet ON DELETE CASCADE,
+	PRIMARY KEY(gid, fid, cid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionalOrder table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func ConditionalOrderTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS conditionalorder CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionalOrder table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,292 @@
+package models
+
+import (
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type ConditionalOrderArchive struct {
+	GID    int           `json:"gid"`
+	Turn   int           `json:"turn"`
+	FID    int           `json:"fid"`
+	CID    int           `json:"cid"`
+	Kind   int           `json:"kind"`
+	Loc    hexagon.Coord `json:"loc"`
+	Within int           `json:"within"`
+	Action int           `json:"action"`
+	Source hexagon.Coord `json:"source"`
+	Target hexagon.Coord `json:"target"`
+	Size   int           `json:"size"`
+	Power  int           `json:"power"`
+	sql    gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewConditionalOrderArchive() *ConditionalOrderArchive {
+	return &ConditionalOrderArchive{
+	//
+	}
+}
+
+func (item *ConditionalOrderArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "cid":
+		return item.CID
+	case "kind":
+		return item.Kind
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "within":
+		return item.Within
+	case "action":
+		return item.Action
+	case "sourcex":
+		return item.Source[0]
+	case "sourcey":
+		return item.Source[1]
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "size":
+		return item.Size
+	case "power":
+		return item.Power
+	}
+	return nil
+}
+
+func (item *ConditionalOrderArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "cid":
+		return &item.CID
+	case "kind":
+		return &item.Kind
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "within":
+		return &item.Within
+	case "action":
+		return &item.Action
+	case "sourcex":
+		return &item.Source[0]
+	case "sourcey":
+		return &item.Source[1]
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "size":
+		return &item.Size
+	case "power":
+		return &item.Power
+	}
+	return nil
+}
+func (item *ConditionalOrderArchive) SQLTable() string {
+	return "conditionalorderarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type ConditionalOrderArchiveGroup struct {
+	List []*ConditionalOrderArchive
+}
+
+func NewConditionalOrderArchiveGroup() *ConditionalOrderArchiveGroup {
+	return &ConditionalOrderArchiveGroup{
+		List: []*ConditionalOrderArchive{},
+	}
+}
+
+func (item *ConditionalOrderArchive) SQLGroup() gp.SQLGrouper {
+	return NewConditionalOrderArchiveGroup()
+}
+
+func (group *ConditionalOrderArchiveGroup) New() gp.SQLer {
+	item := NewConditionalOrderArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *ConditionalOrderArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *ConditionalOrderArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionalOrderArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ConditionalOrderArchiveGroup) SQLTable() string {
+	return "conditionalorderarchive"
+}
+
+func (group *ConditionalOrderArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"cid",
+	}
+}
+
+func (group *ConditionalOrderArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"within",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+	}
+}
+
+func (group *ConditionalOrderArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *ConditionalOrderArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"cid",
+		"kind",
+		"locx",
+		"locy",
+		"within",
+		"action",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"power",
+	}
+}
+
+func (group *ConditionalOrderArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type ConditionalOrderArchiveSession struct {
+	*ConditionalOrderArchiveGroup
+	*gp.Session
+}
+
+func NewConditionalOrderArchiveSession(d db.DBer) *ConditionalOrderArchiveSession {
+	group := NewConditionalOrderArchiveGroup()
+	return &ConditionalOrderArchiveSession{
+		ConditionalOrderArchiveGroup: group,
+		Session:                      gp.NewSession(group, d),
+	}
+}
+
+func (s *ConditionalOrderArchiveSession) Select(conditions ...interface{}) ([]*ConditionalOrderArchive, error) {
+	cur := len(s.ConditionalOrderArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "ConditionalOrderArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.ConditionalOrderArchiveGroup.List[cur:], nil
+}
+
+func (s *ConditionalOrderArchiveSession) SelectWhere(where sq.Condition) ([]*ConditionalOrderArchive, error) {
+	cur := len(s.ConditionalOrderArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "ConditionalOrderArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.ConditionalOrderArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func ConditionalOrderArchiveTableCreate(d db.DBer) error {
+	query := `create table conditionalorderarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	cid integer NOT NULL,
+	kind integer NOT NULL,
+	locx integer NOT NULL,
+	locy integer NOT NULL,
+	within integer NOT NULL,
+	action integer NOT NULL,
+	sourcex integer NOT NULL,
+	sourcey integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	size integer NOT NULL,
+	power integer NOT NULL,
+	PRIMARY KEY(gid, turn, fid, cid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionalOrderArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func ConditionalOrderArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS conditionalorderarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ConditionalOrderArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"mule/mydb/db"
 	"mule/overpower"
 )
@@ -70,3 +71,70 @@ func (d *DB) SourceTransact(gid int, f func(overpower.Source) (logE, revertE err
 	}
 	return logErr, nil
 }
+
+// TurnTransact is SourceTransact for changes that start a new turn: once f
+// succeeds the game is snapshotted so it can later be rewound to this turn.
+func (d *DB) TurnTransact(gid int, f func(overpower.Source) (logE, revertE error)) (logErr, failErr error) {
+	g := func(d db.DBer) error {
+		m := NewManager(d)
+		s := NewSource(m, gid)
+		var revertE error
+		logErr, revertE = f(s)
+		if my, bad := Check(revertE, "turn transaction failure on execution"); bad {
+			return my
+		}
+		revertE = m.Close()
+		if my, bad := Check(revertE, "turn transaction failure on closure"); bad {
+			return my
+		}
+		revertE = SnapshotGame(d, gid)
+		if my, bad := Check(revertE, "turn transaction failure on snapshot"); bad {
+			return my
+		}
+		return nil
+	}
+	err := db.Transact(d.DB, g)
+	if my, bad := Check(err, "turn transaction failed on db transact"); bad {
+		return logErr, my
+	}
+	return logErr, nil
+}
+
+// addColumns gives a table made before the given columns any of them it
+// lacks, and its snapshot table too.  Each column is defined as in the
+// table's creation, so rows already there take its default.
+func addColumns(d db.DBer, table string, cols ...string) error {
+	for _, t := range []string{table, snapshotTable(table)} {
+		for _, col := range cols {
+			query := fmt.Sprintf("ALTER TABLE IF EXISTS %s ADD COLUMN IF NOT EXISTS %s", t, col)
+			err := db.Exec(d, false, query)
+			if my, bad := Check(err, "failed column migration", "query", query); bad {
+				return my
+			}
+		}
+	}
+	return nil
+}
+
+// ArchiveTablesMigrate drops the faction references from the archive
+// tables, so the orders of a faction that quits stay archived for replays.
+func ArchiveTablesMigrate(d db.DBer) error {
+	refs := [][2]string{
+		{"launchorderarchive", "fid"},
+		{"shiporderarchive", "fid"},
+		{"powerorderarchive", "fid"},
+		{"conditionalorderarchive", "fid"},
+		{"treatyarchive", "fid"},
+		{"visiongrantarchive", "fid"},
+		{"trucearchive", "fid"},
+		{"trucearchive", "trucee"},
+	}
+	for _, ref := range refs {
+		query := fmt.Sprintf("ALTER TABLE IF EXISTS %s DROP CONSTRAINT IF EXISTS %s_%s_fkey", ref[0], ref[0], ref[1])
+		err := db.Exec(d, false, query)
+		if my, bad := Check(err, "failed archive migration", "query", query); bad {
+			return my
+		}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ type Faction struct {
 	Name       string `json:"name"`
 	DoneBuffer int    `json:"donebuffer"`
 	Score      int    `json:"score"`
+	Bot        string `json:"bot"`
 	sql        gp.SQLStruct
 	FullJSON   bool `json:"-"`
 }
@@ -54,6 +55,8 @@ func (item *Faction) SQLVal(name string) interface{} {
 		return item.DoneBuffer
 	case "score":
 		return item.Score
+	case "bot":
+		return item.Bot
 	}
 	return nil
 }
@@ -72,6 +75,8 @@ func (item *Faction) SQLPtr(name string) interface{} {
 		return &item.DoneBuffer
 	case "score":
 		return &item.Score
+	case "bot":
+		return &item.Bot
 	}
 	return nil
 }
@@ -97,12 +102,14 @@ func (i FactionIntf) MarshalJSON() ([]byte, error) {
 			FID      int    `json:"fid"`
 			Owner    string `json:"owner"`
 			Name     string `json:"name"`
+			Bot      string `json:"bot"`
 			TurnDone bool   `json:"turndone"`
 		}{
 			GID:      i.GID(),
 			FID:      i.FID(),
 			Owner:    i.Owner(),
 			Name:     i.Name(),
+			Bot:      i.Bot(),
 			TurnDone: i.DoneBuffer() != 0,
 		})
 	}
@@ -128,6 +135,26 @@ func (i FactionIntf) Name() string {
 	return i.item.Name
 }
 
+func (i FactionIntf) SetOwner(x string) {
+	if i.item.Owner == x {
+		return
+	}
+	i.item.Owner = x
+	i.item.sql.UPDATE = true
+}
+
+func (i FactionIntf) Bot() string {
+	return i.item.Bot
+}
+
+func (i FactionIntf) SetBot(x string) {
+	if i.item.Bot == x {
+		return
+	}
+	i.item.Bot = x
+	i.item.sql.UPDATE = true
+}
+
 func (i FactionIntf) IsDone() bool {
 	return i.item.DoneBuffer != 0
 }
@@ -230,6 +257,7 @@ func (group *FactionGroup) InsertCols() []string {
 		"name",
 		"donebuffer",
 		"score",
+		"bot",
 	}
 }
 
@@ -247,13 +275,16 @@ func (group *FactionGroup) SelectCols() []string {
 		"name",
 		"donebuffer",
 		"score",
+		"bot",
 	}
 }
 
 func (group *FactionGroup) UpdateCols() []string {
 	return []string{
+		"owner",
 		"donebuffer",
 		"score",
+		"bot",
 	}
 }
 
@@ -328,6 +359,7 @@ func FactionTableCreate(d db.DBer) error {
 	name varchar(20) NOT NULL,
 	donebuffer int NOT NULL DEFAULT 0,
 	score int NOT NULL DEFAULT 0,
+	bot varchar(20) NOT NULL DEFAULT '',
 	UNIQUE(gid, owner)
 );`
 	err := db.Exec(d, false, query)
@@ -337,6 +369,13 @@ func FactionTableCreate(d db.DBer) error {
 	return nil
 }
 
+// FactionTableMigrate adds the columns a faction table made before them lacks.
+func FactionTableMigrate(d db.DBer) error {
+	return addColumns(d, "faction",
+		"bot varchar(20) NOT NULL DEFAULT ''",
+	)
+}
+
 func FactionTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS faction CASCADE"
 	err := db.Exec(d, false, query)
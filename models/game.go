@@ -4,23 +4,32 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"mule/mydb/db"
 	gp "mule/mydb/group"
 	sq "mule/mydb/sql"
 	"mule/overpower"
+	"strings"
 )
 
 type Game struct {
-	GID       int            `json:"gid"`
-	Owner     string         `json:"owner"`
-	Name      string         `json:"name"`
-	Turn      int            `json:"turn"`
-	Autoturn  int            `json:"-"`
-	FreeAutos int            `json:"freeautos"`
-	Password  sql.NullString `json:"-"`
-	ToWin     int            `json:"towin"`
-	HighScore int            `json:"highscore"`
-	Winner    sql.NullString `json:"winner,omitempty"`
+	GID       int                 `json:"gid"`
+	Owner     string              `json:"owner"`
+	Name      string              `json:"name"`
+	Turn      int                 `json:"turn"`
+	Autoturn  int                 `json:"-"`
+	FreeAutos int                 `json:"freeautos"`
+	Password  sql.NullString      `json:"-"`
+	ToWin     int                 `json:"towin"`
+	HighScore int                 `json:"highscore"`
+	Winner    sql.NullString      `json:"winner,omitempty"`
+	TieBreak  string              `json:"tiebreak,omitempty"`
+	Seed      int64               `json:"-"`
+	Exodus    bool                `json:"exodus"`
+	Generator string              `json:"generator"`
+	Rules     overpower.GameRules `json:"rules"`
+	Seats     db.IntList          `json:"-"`
+	Quits     db.IntList          `json:"-"`
 	sql       gp.SQLStruct
 }
 
@@ -66,6 +75,21 @@ func (item *Game) SQLVal(name string) interface{} {
 		return item.HighScore
 	case "winner":
 		return item.Winner
+	case "tiebreak":
+		return item.TieBreak
+	case "seed":
+		return item.Seed
+	case "exodus":
+		return item.Exodus
+	case "generator":
+		return item.Generator
+	case "seats":
+		return item.Seats
+	case "quits":
+		return item.Quits
+	}
+	if p := item.rule(name); p != nil {
+		return *p
 	}
 	return nil
 }
@@ -92,6 +116,31 @@ func (item *Game) SQLPtr(name string) interface{} {
 		return &item.HighScore
 	case "winner":
 		return &item.Winner
+	case "tiebreak":
+		return &item.TieBreak
+	case "seed":
+		return &item.Seed
+	case "exodus":
+		return &item.Exodus
+	case "generator":
+		return &item.Generator
+	case "seats":
+		return &item.Seats
+	case "quits":
+		return &item.Quits
+	}
+	if p := item.rule(name); p != nil {
+		return p
+	}
+	return nil
+}
+
+// rule gives the rule stored in the named column, or nil.
+func (item *Game) rule(name string) *int {
+	for _, f := range overpower.RuleFields {
+		if f.Name == name {
+			return f.Of(&item.Rules)
+		}
 	}
 	return nil
 }
@@ -269,6 +318,84 @@ func (i GameIntf) SetWinner(x string) {
 	i.item.sql.UPDATE = true
 }
 
+func (i GameIntf) TieBreak() string {
+	return i.item.TieBreak
+}
+
+func (i GameIntf) SetTieBreak(x string) {
+	if i.item.TieBreak == x {
+		return
+	}
+	i.item.TieBreak = x
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Seed() int64 {
+	return i.item.Seed
+}
+
+func (i GameIntf) SetSeed(x int64) {
+	if i.item.Seed == x {
+		return
+	}
+	i.item.Seed = x
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Exodus() bool {
+	return i.item.Exodus
+}
+
+func (i GameIntf) SetExodus(x bool) {
+	if i.item.Exodus == x {
+		return
+	}
+	i.item.Exodus = x
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Generator() string {
+	return i.item.Generator
+}
+
+func (i GameIntf) SetGenerator(x string) {
+	if i.item.Generator == x {
+		return
+	}
+	i.item.Generator = x
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Rules() overpower.GameRules {
+	return i.item.Rules
+}
+
+func (i GameIntf) SetRules(x overpower.GameRules) {
+	if i.item.Rules == x {
+		return
+	}
+	i.item.Rules = x
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Seats() []int {
+	return []int(i.item.Seats)
+}
+
+func (i GameIntf) SetSeats(x []int) {
+	i.item.Seats = db.IntList(x)
+	i.item.sql.UPDATE = true
+}
+
+func (i GameIntf) Quits() []int {
+	return []int(i.item.Quits)
+}
+
+func (i GameIntf) SetQuits(x []int) {
+	i.item.Quits = db.IntList(x)
+	i.item.sql.UPDATE = true
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -336,7 +463,7 @@ func (group *GameGroup) PKCols() []string {
 }
 
 func (group *GameGroup) InsertCols() []string {
-	return []string{
+	return append([]string{
 		"owner",
 		"name",
 		"turn",
@@ -345,7 +472,12 @@ func (group *GameGroup) InsertCols() []string {
 		"password",
 		"towin",
 		"highscore",
-	}
+		"seed",
+		"exodus",
+		"generator",
+		"seats",
+		"quits",
+	}, ruleCols()...)
 }
 
 func (group *GameGroup) InsertScanCols() []string {
@@ -355,7 +487,7 @@ func (group *GameGroup) InsertScanCols() []string {
 }
 
 func (group *GameGroup) SelectCols() []string {
-	return []string{
+	return append([]string{
 		"gid",
 		"owner",
 		"name",
@@ -366,11 +498,17 @@ func (group *GameGroup) SelectCols() []string {
 		"towin",
 		"highscore",
 		"winner",
-	}
+		"tiebreak",
+		"seed",
+		"exodus",
+		"generator",
+		"seats",
+		"quits",
+	}, ruleCols()...)
 }
 
 func (group *GameGroup) UpdateCols() []string {
-	return []string{
+	return append([]string{
 		"owner",
 		"name",
 		"turn",
@@ -380,7 +518,13 @@ func (group *GameGroup) UpdateCols() []string {
 		"towin",
 		"highscore",
 		"winner",
-	}
+		"tiebreak",
+		"seed",
+		"exodus",
+		"generator",
+		"seats",
+		"quits",
+	}, ruleCols()...)
 }
 
 // --------- END GROUP ------------ //
@@ -442,6 +586,25 @@ func convertGame2Intf(list ...*Game) []overpower.GameDat {
 	return converted
 }
 
+// ruleCols gives the columns the rules are stored in.
+func ruleCols() []string {
+	cols := make([]string, len(overpower.RuleFields))
+	for i, f := range overpower.RuleFields {
+		cols[i] = f.Name
+	}
+	return cols
+}
+
+// ruleColumns gives the table definitions of the rule columns, each
+// defaulting to what games from before its rule played by.
+func ruleColumns() []string {
+	defs := make([]string, len(overpower.RuleFields))
+	for i, f := range overpower.RuleFields {
+		defs[i] = fmt.Sprintf("%s int NOT NULL DEFAULT %d", f.Name, f.Before)
+	}
+	return defs
+}
+
 func GameTableCreate(d db.DBer) error {
 	query := `create table game(
 	gid SERIAL PRIMARY KEY,
@@ -453,6 +616,13 @@ func GameTableCreate(d db.DBer) error {
 	towin int NOT NULL,
 	highscore int NOT NULL DEFAULT 0,
 	winner text DEFAULT NULL,
+	tiebreak text NOT NULL DEFAULT '',
+	seed bigint NOT NULL DEFAULT 0,
+	exodus bool NOT NULL DEFAULT false,
+	generator text NOT NULL DEFAULT 'standard',
+	seats int[] NOT NULL DEFAULT '{}',
+	quits int[] NOT NULL DEFAULT '{}',
+	` + strings.Join(ruleColumns(), ",\n\t") + `,
 	password varchar(20) DEFAULT NULL
 );`
 	err := db.Exec(d, false, query)
@@ -462,6 +632,19 @@ func GameTableCreate(d db.DBer) error {
 	return nil
 }
 
+// GameTableMigrate adds the columns a game table made before them lacks,
+// rules included.
+func GameTableMigrate(d db.DBer) error {
+	return addColumns(d, "game", append([]string{
+		"seed bigint NOT NULL DEFAULT 0",
+		"exodus bool NOT NULL DEFAULT false",
+		"tiebreak text NOT NULL DEFAULT ''",
+		"generator text NOT NULL DEFAULT 'standard'",
+		"seats int[] NOT NULL DEFAULT '{}'",
+		"quits int[] NOT NULL DEFAULT '{}'",
+	}, ruleColumns()...)...)
+}
+
 func GameTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS game CASCADE"
 	err := db.Exec(d, false, query)
@@ -0,0 +1,309 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type HomeReach struct {
+	GID        int           `json:"gid"`
+	FID        int           `json:"fid"`
+	Loc        hexagon.Coord `json:"loc"`
+	Reach      int           `json:"reach"`
+	Planets    int           `json:"planets"`
+	Antimatter int           `json:"antimatter"`
+	Tachyons   int           `json:"tachyons"`
+	sql        gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewHomeReach() *HomeReach {
+	return &HomeReach{
+	//
+	}
+}
+
+type HomeReachIntf struct {
+	item *HomeReach
+}
+
+func (item *HomeReach) Intf() overpower.HomeReachDat {
+	return &HomeReachIntf{item}
+}
+
+func (i HomeReachIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *HomeReach) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "reach":
+		return item.Reach
+	case "planets":
+		return item.Planets
+	case "antimatter":
+		return item.Antimatter
+	case "tachyons":
+		return item.Tachyons
+	}
+	return nil
+}
+
+func (item *HomeReach) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "reach":
+		return &item.Reach
+	case "planets":
+		return &item.Planets
+	case "antimatter":
+		return &item.Antimatter
+	case "tachyons":
+		return &item.Tachyons
+	}
+	return nil
+}
+func (item *HomeReach) SQLTable() string {
+	return "homereach"
+}
+
+func (i HomeReachIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i HomeReachIntf) UnmarshalJSON(data []byte) error {
+	i.item = &HomeReach{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i HomeReachIntf) GID() int {
+	return i.item.GID
+}
+
+func (i HomeReachIntf) FID() int {
+	return i.item.FID
+}
+
+func (i HomeReachIntf) Loc() hexagon.Coord {
+	return i.item.Loc
+}
+
+func (i HomeReachIntf) Reach() int {
+	return i.item.Reach
+}
+
+func (i HomeReachIntf) Planets() int {
+	return i.item.Planets
+}
+
+func (i HomeReachIntf) Antimatter() int {
+	return i.item.Antimatter
+}
+
+func (i HomeReachIntf) Tachyons() int {
+	return i.item.Tachyons
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type HomeReachGroup struct {
+	List []*HomeReach
+}
+
+func NewHomeReachGroup() *HomeReachGroup {
+	return &HomeReachGroup{
+		List: []*HomeReach{},
+	}
+}
+
+func (item *HomeReach) SQLGroup() gp.SQLGrouper {
+	return NewHomeReachGroup()
+}
+
+func (group *HomeReachGroup) New() gp.SQLer {
+	item := NewHomeReach()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *HomeReachGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *HomeReachGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *HomeReachGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *HomeReachGroup) SQLTable() string {
+	return "homereach"
+}
+
+func (group *HomeReachGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+	}
+}
+
+func (group *HomeReachGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"locx",
+		"locy",
+		"reach",
+		"planets",
+		"antimatter",
+		"tachyons",
+	}
+}
+
+func (group *HomeReachGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *HomeReachGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"locx",
+		"locy",
+		"reach",
+		"planets",
+		"antimatter",
+		"tachyons",
+	}
+}
+
+func (group *HomeReachGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type HomeReachSession struct {
+	*HomeReachGroup
+	*gp.Session
+}
+
+func NewHomeReachSession(d db.DBer) *HomeReachSession {
+	group := NewHomeReachGroup()
+	return &HomeReachSession{
+		HomeReachGroup: group,
+		Session:        gp.NewSession(group, d),
+	}
+}
+
+func (s *HomeReachSession) Select(conditions ...interface{}) ([]overpower.HomeReachDat, error) {
+	cur := len(s.HomeReachGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "HomeReach select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertHomeReach2Intf(s.HomeReachGroup.List[cur:]...), nil
+}
+
+func (s *HomeReachSession) SelectWhere(where sq.Condition) ([]overpower.HomeReachDat, error) {
+	cur := len(s.HomeReachGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "HomeReach SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertHomeReach2Intf(s.HomeReachGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertHomeReach2Struct(list ...overpower.HomeReachDat) ([]*HomeReach, error) {
+	mylist := make([]*HomeReach, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(HomeReachIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad HomeReach struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertHomeReach2Intf(list ...*HomeReach) []overpower.HomeReachDat {
+	converted := make([]overpower.HomeReachDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func HomeReachTableCreate(d db.DBer) error {
+	query := `create table homereach(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	locx int NOT NULL,
+	locy int NOT NULL,
+	reach int NOT NULL,
+	planets int NOT NULL,
+	antimatter int NOT NULL,
+	tachyons int NOT NULL,
+	PRIMARY KEY(gid, fid)
+);`
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed HomeReach table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func HomeReachTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS homereach CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed HomeReach table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type LaunchOrderArchive struct {
+	GID      int           `json:"gid"`
+	Turn     int           `json:"turn"`
+	FID      int           `json:"fid"`
+	Source   hexagon.Coord `json:"source"`
+	Target   hexagon.Coord `json:"target"`
+	Size     int           `json:"size"`
+	Standing bool          `json:"standing"`
+	sql      gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewLaunchOrderArchive() *LaunchOrderArchive {
+	return &LaunchOrderArchive{
+		//
+	}
+}
+
+func (item *LaunchOrderArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "sourcex":
+		return item.Source[0]
+	case "sourcey":
+		return item.Source[1]
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "size":
+		return item.Size
+	case "standing":
+		return item.Standing
+	}
+	return nil
+}
+
+func (item *LaunchOrderArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "sourcex":
+		return &item.Source[0]
+	case "sourcey":
+		return &item.Source[1]
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "size":
+		return &item.Size
+	case "standing":
+		return &item.Standing
+	}
+	return nil
+}
+func (item *LaunchOrderArchive) SQLTable() string {
+	return "launchorderarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type LaunchOrderArchiveGroup struct {
+	List []*LaunchOrderArchive
+}
+
+func NewLaunchOrderArchiveGroup() *LaunchOrderArchiveGroup {
+	return &LaunchOrderArchiveGroup{
+		List: []*LaunchOrderArchive{},
+	}
+}
+
+func (item *LaunchOrderArchive) SQLGroup() gp.SQLGrouper {
+	return NewLaunchOrderArchiveGroup()
+}
+
+func (group *LaunchOrderArchiveGroup) New() gp.SQLer {
+	item := NewLaunchOrderArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *LaunchOrderArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *LaunchOrderArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *LaunchOrderArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *LaunchOrderArchiveGroup) SQLTable() string {
+	return "launchorderarchive"
+}
+
+func (group *LaunchOrderArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+	}
+}
+
+func (group *LaunchOrderArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"standing",
+	}
+}
+
+func (group *LaunchOrderArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *LaunchOrderArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"standing",
+	}
+}
+
+func (group *LaunchOrderArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type LaunchOrderArchiveSession struct {
+	*LaunchOrderArchiveGroup
+	*gp.Session
+}
+
+func NewLaunchOrderArchiveSession(d db.DBer) *LaunchOrderArchiveSession {
+	group := NewLaunchOrderArchiveGroup()
+	return &LaunchOrderArchiveSession{
+		LaunchOrderArchiveGroup: group,
+		Session:                 gp.NewSession(group, d),
+	}
+}
+
+func (s *LaunchOrderArchiveSession) Select(conditions ...interface{}) ([]*LaunchOrderArchive, error) {
+	cur := len(s.LaunchOrderArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "LaunchOrderArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.LaunchOrderArchiveGroup.List[cur:], nil
+}
+
+func (s *LaunchOrderArchiveSession) SelectWhere(where sq.Condition) ([]*LaunchOrderArchive, error) {
+	cur := len(s.LaunchOrderArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "LaunchOrderArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.LaunchOrderArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func LaunchOrderArchiveTableCreate(d db.DBer) error {
+	query := `create table launchorderarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	sourcex integer NOT NULL,
+	sourcey integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	size integer NOT NULL,
+	standing bool NOT NULL DEFAULT false,
+	PRIMARY KEY(gid, turn, fid, sourcex, sourcey, targetx, targety)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed LaunchOrderArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// LaunchOrderArchiveTableMigrate adds the columns a launchorderarchive table made before them lacks.
+func LaunchOrderArchiveTableMigrate(d db.DBer) error {
+	return addColumns(d, "launchorderarchive", "standing bool NOT NULL DEFAULT false")
+}
+
+func LaunchOrderArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS launchorderarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed LaunchOrderArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,297 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type Message struct {
+	GID       int    `json:"gid"`
+	MID       int    `json:"mid"`
+	Turn      int    `json:"turn"`
+	FID       int    `json:"fid"`
+	Recipient int    `json:"recipient"`
+	Body      string `json:"body"`
+	sql       gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewMessage() *Message {
+	return &Message{
+	//
+	}
+}
+
+type MessageIntf struct {
+	item *Message
+}
+
+func (item *Message) Intf() overpower.MessageDat {
+	return &MessageIntf{item}
+}
+
+func (i MessageIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *Message) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "mid":
+		return item.MID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "recipient":
+		return item.Recipient
+	case "body":
+		return item.Body
+	}
+	return nil
+}
+
+func (item *Message) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "mid":
+		return &item.MID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "recipient":
+		return &item.Recipient
+	case "body":
+		return &item.Body
+	}
+	return nil
+}
+func (item *Message) SQLTable() string {
+	return "message"
+}
+
+func (i MessageIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i MessageIntf) UnmarshalJSON(data []byte) error {
+	i.item = &Message{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i MessageIntf) GID() int {
+	return i.item.GID
+}
+
+func (i MessageIntf) MID() int {
+	return i.item.MID
+}
+
+func (i MessageIntf) Turn() int {
+	return i.item.Turn
+}
+
+func (i MessageIntf) FID() int {
+	return i.item.FID
+}
+
+func (i MessageIntf) Recipient() int {
+	return i.item.Recipient
+}
+
+func (i MessageIntf) Body() string {
+	return i.item.Body
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type MessageGroup struct {
+	List []*Message
+}
+
+func NewMessageGroup() *MessageGroup {
+	return &MessageGroup{
+		List: []*Message{},
+	}
+}
+
+func (item *Message) SQLGroup() gp.SQLGrouper {
+	return NewMessageGroup()
+}
+
+func (group *MessageGroup) New() gp.SQLer {
+	item := NewMessage()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *MessageGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *MessageGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *MessageGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *MessageGroup) SQLTable() string {
+	return "message"
+}
+
+func (group *MessageGroup) PKCols() []string {
+	return []string{
+		"mid",
+	}
+}
+
+func (group *MessageGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		//		"mid",
+		"turn",
+		"fid",
+		"recipient",
+		"body",
+	}
+}
+
+func (group *MessageGroup) InsertScanCols() []string {
+	return []string{
+		"mid",
+	}
+}
+
+func (group *MessageGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"mid",
+		"turn",
+		"fid",
+		"recipient",
+		"body",
+	}
+}
+
+func (group *MessageGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type MessageSession struct {
+	*MessageGroup
+	*gp.Session
+}
+
+func NewMessageSession(d db.DBer) *MessageSession {
+	group := NewMessageGroup()
+	return &MessageSession{
+		MessageGroup: group,
+		Session:      gp.NewSession(group, d),
+	}
+}
+
+func (s *MessageSession) Select(conditions ...interface{}) ([]overpower.MessageDat, error) {
+	cur := len(s.MessageGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "Message select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertMessage2Intf(s.MessageGroup.List[cur:]...), nil
+}
+
+func (s *MessageSession) SelectWhere(where sq.Condition) ([]overpower.MessageDat, error) {
+	cur := len(s.MessageGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "Message SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertMessage2Intf(s.MessageGroup.List[cur:]...), nil
+}
+
+// SelectVisible returns the messages fid sent or received, broadcasts
+// included.
+func (s *MessageSession) SelectVisible(gid, fid int) ([]overpower.MessageDat, error) {
+	where := sq.AND(sq.EQ("gid", gid), sq.OR(sq.EQ("fid", fid), sq.EQ("recipient", fid), sq.EQ("recipient", 0)))
+	return s.SelectWhere(where)
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertMessage2Struct(list ...overpower.MessageDat) ([]*Message, error) {
+	mylist := make([]*Message, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(MessageIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad Message struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertMessage2Intf(list ...*Message) []overpower.MessageDat {
+	converted := make([]overpower.MessageDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func MessageTableCreate(d db.DBer) error {
+	query := `create table message(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	mid SERIAL PRIMARY KEY,
+	turn integer NOT NULL,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	recipient integer NOT NULL DEFAULT 0,
+	body varchar(1000) NOT NULL
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Message table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func MessageTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS message CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Message table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,270 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type MessageRead struct {
+	GID      int `json:"gid"`
+	FID      int `json:"fid"`
+	LastRead int `json:"lastread"`
+	sql      gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewMessageRead() *MessageRead {
+	return &MessageRead{
+	//
+	}
+}
+
+type MessageReadIntf struct {
+	item *MessageRead
+}
+
+func (item *MessageRead) Intf() overpower.MessageReadDat {
+	return &MessageReadIntf{item}
+}
+
+func (i MessageReadIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *MessageRead) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "lastread":
+		return item.LastRead
+	}
+	return nil
+}
+
+func (item *MessageRead) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "lastread":
+		return &item.LastRead
+	}
+	return nil
+}
+func (item *MessageRead) SQLTable() string {
+	return "messageread"
+}
+
+func (i MessageReadIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i MessageReadIntf) UnmarshalJSON(data []byte) error {
+	i.item = &MessageRead{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i MessageReadIntf) GID() int {
+	return i.item.GID
+}
+
+func (i MessageReadIntf) FID() int {
+	return i.item.FID
+}
+
+func (i MessageReadIntf) LastRead() int {
+	return i.item.LastRead
+}
+
+func (i MessageReadIntf) SetLastRead(x int) {
+	if i.item.LastRead == x {
+		return
+	}
+	i.item.LastRead = x
+	i.item.sql.UPDATE = true
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type MessageReadGroup struct {
+	List []*MessageRead
+}
+
+func NewMessageReadGroup() *MessageReadGroup {
+	return &MessageReadGroup{
+		List: []*MessageRead{},
+	}
+}
+
+func (item *MessageRead) SQLGroup() gp.SQLGrouper {
+	return NewMessageReadGroup()
+}
+
+func (group *MessageReadGroup) New() gp.SQLer {
+	item := NewMessageRead()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *MessageReadGroup) UpdateList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *MessageReadGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *MessageReadGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *MessageReadGroup) SQLTable() string {
+	return "messageread"
+}
+
+func (group *MessageReadGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+	}
+}
+
+func (group *MessageReadGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"lastread",
+	}
+}
+
+func (group *MessageReadGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *MessageReadGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"lastread",
+	}
+}
+
+func (group *MessageReadGroup) UpdateCols() []string {
+	return []string{
+		"lastread",
+	}
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type MessageReadSession struct {
+	*MessageReadGroup
+	*gp.Session
+}
+
+func NewMessageReadSession(d db.DBer) *MessageReadSession {
+	group := NewMessageReadGroup()
+	return &MessageReadSession{
+		MessageReadGroup: group,
+		Session:          gp.NewSession(group, d),
+	}
+}
+
+func (s *MessageReadSession) Select(conditions ...interface{}) ([]overpower.MessageReadDat, error) {
+	cur := len(s.MessageReadGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "MessageRead select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertMessageRead2Intf(s.MessageReadGroup.List[cur:]...), nil
+}
+
+func (s *MessageReadSession) SelectWhere(where sq.Condition) ([]overpower.MessageReadDat, error) {
+	cur := len(s.MessageReadGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "MessageRead SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertMessageRead2Intf(s.MessageReadGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertMessageRead2Struct(list ...overpower.MessageReadDat) ([]*MessageRead, error) {
+	mylist := make([]*MessageRead, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(MessageReadIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad MessageRead struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertMessageRead2Intf(list ...*MessageRead) []overpower.MessageReadDat {
+	converted := make([]overpower.MessageReadDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func MessageReadTableCreate(d db.DBer) error {
+	query := `create table messageread(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	lastread integer NOT NULL DEFAULT 0,
+	PRIMARY KEY(gid, fid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed MessageRead table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func MessageReadTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS messageread CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed MessageRead table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -11,19 +11,37 @@ var (
 )
 
 type Manager struct {
-	D                   db.DBer
-	BattleRecordSession *BattleRecordSession
-	FactionSession      *FactionSession
-	GameSession         *GameSession
-	LaunchRecordSession *LaunchRecordSession
-	MapViewSession      *MapViewSession
-	LaunchOrderSession  *LaunchOrderSession
-	PlanetSession       *PlanetSession
-	PlanetViewSession   *PlanetViewSession
-	PowerOrderSession   *PowerOrderSession
-	ShipSession         *ShipSession
-	ShipViewSession     *ShipViewSession
-	TruceSession        *TruceSession
+	D                         db.DBer
+	BattleRecordSession       *BattleRecordSession
+	FactionSession            *FactionSession
+	GameSession               *GameSession
+	LaunchRecordSession       *LaunchRecordSession
+	MapViewSession            *MapViewSession
+	MessageSession            *MessageSession
+	MessageReadSession        *MessageReadSession
+	LaunchOrderSession        *LaunchOrderSession
+	PlanetSession             *PlanetSession
+	PlanetViewSession         *PlanetViewSession
+	PowerOrderSession         *PowerOrderSession
+	ShipSession               *ShipSession
+	ShipViewSession           *ShipViewSession
+	ShipOrderSession          *ShipOrderSession
+	StandingOrderSession      *StandingOrderSession
+	ConditionalOrderSession   *ConditionalOrderSession
+	ConditionRecordSession    *ConditionRecordSession
+	TruceSession              *TruceSession
+	TreatySession             *TreatySession
+	TreatyBreakSession        *TreatyBreakSession
+	VisionGrantSession        *VisionGrantSession
+	LaunchOrderArchiveSession *LaunchOrderArchiveSession
+	PowerOrderArchiveSession  *PowerOrderArchiveSession
+	TruceArchiveSession       *TruceArchiveSession
+	TreatyArchiveSession      *TreatyArchiveSession
+	VisionGrantArchiveSession *VisionGrantArchiveSession
+	ShipOrderArchiveSession   *ShipOrderArchiveSession
+	ConditionalOrderArchiveSession *ConditionalOrderArchiveSession
+	StandingSession           *StandingSession
+	HomeReachSession *HomeReachSession
 }
 
 func NewManager(d db.DBer) *Manager {
@@ -110,6 +128,34 @@ func (m *Manager) CreateMapView(item *MapView) {
 	m.MapViewSession.List = append(m.MapViewSession.List, item)
 }
 
+func (m *Manager) Message() *MessageSession {
+	s := NewMessageSession(m.D)
+	m.MessageSession = s
+	return s
+}
+
+func (m *Manager) CreateMessage(item *Message) {
+	if m.MessageSession == nil {
+		m.MessageSession = NewMessageSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.MessageSession.List = append(m.MessageSession.List, item)
+}
+
+func (m *Manager) MessageRead() *MessageReadSession {
+	s := NewMessageReadSession(m.D)
+	m.MessageReadSession = s
+	return s
+}
+
+func (m *Manager) CreateMessageRead(item *MessageRead) {
+	if m.MessageReadSession == nil {
+		m.MessageReadSession = NewMessageReadSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.MessageReadSession.List = append(m.MessageReadSession.List, item)
+}
+
 func (m *Manager) LaunchOrder() *LaunchOrderSession {
 	s := NewLaunchOrderSession(m.D)
 	m.LaunchOrderSession = s
@@ -194,6 +240,58 @@ func (m *Manager) CreateShipView(item *ShipView) {
 	m.ShipViewSession.List = append(m.ShipViewSession.List, item)
 }
 
+func (m *Manager) ShipOrder() *ShipOrderSession {
+	s := NewShipOrderSession(m.D)
+	m.ShipOrderSession = s
+	return s
+}
+
+func (m *Manager) CreateShipOrder(item *ShipOrder) {
+	if m.ShipOrderSession == nil {
+		m.ShipOrderSession = NewShipOrderSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.ShipOrderSession.List = append(m.ShipOrderSession.List, item)
+}
+
+func (m *Manager) StandingOrder() *StandingOrderSession {
+	s := NewStandingOrderSession(m.D)
+	m.StandingOrderSession = s
+	return s
+}
+
+func (m *Manager) CreateStandingOrder(item *StandingOrder) {
+	if m.StandingOrderSession == nil {
+		m.StandingOrderSession = NewStandingOrderSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.StandingOrderSession.List = append(m.StandingOrderSession.List, item)
+}
+func (m *Manager) ConditionalOrder() *ConditionalOrderSession {
+	s := NewConditionalOrderSession(m.D)
+	m.ConditionalOrderSession = s
+	return s
+}
+func (m *Manager) CreateConditionalOrder(item *ConditionalOrder) {
+	if m.ConditionalOrderSession == nil {
+		m.ConditionalOrderSession = NewConditionalOrderSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.ConditionalOrderSession.List = append(m.ConditionalOrderSession.List, item)
+}
+func (m *Manager) ConditionRecord() *ConditionRecordSession {
+	s := NewConditionRecordSession(m.D)
+	m.ConditionRecordSession = s
+	return s
+}
+func (m *Manager) CreateConditionRecord(item *ConditionRecord) {
+	if m.ConditionRecordSession == nil {
+		m.ConditionRecordSession = NewConditionRecordSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.ConditionRecordSession.List = append(m.ConditionRecordSession.List, item)
+}
+
 func (m *Manager) Truce() *TruceSession {
 	s := NewTruceSession(m.D)
 	m.TruceSession = s
@@ -207,6 +305,162 @@ func (m *Manager) CreateTruce(item *Truce) {
 	item.sql.INSERT = true
 	m.TruceSession.List = append(m.TruceSession.List, item)
 }
+func (m *Manager) Treaty() *TreatySession {
+	s := NewTreatySession(m.D)
+	m.TreatySession = s
+	return s
+}
+func (m *Manager) CreateTreaty(item *Treaty) {
+	if m.TreatySession == nil {
+		m.TreatySession = NewTreatySession(m.D)
+	}
+	item.sql.INSERT = true
+	m.TreatySession.List = append(m.TreatySession.List, item)
+}
+func (m *Manager) TreatyBreak() *TreatyBreakSession {
+	s := NewTreatyBreakSession(m.D)
+	m.TreatyBreakSession = s
+	return s
+}
+func (m *Manager) CreateTreatyBreak(item *TreatyBreak) {
+	if m.TreatyBreakSession == nil {
+		m.TreatyBreakSession = NewTreatyBreakSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.TreatyBreakSession.List = append(m.TreatyBreakSession.List, item)
+}
+func (m *Manager) VisionGrant() *VisionGrantSession {
+	s := NewVisionGrantSession(m.D)
+	m.VisionGrantSession = s
+	return s
+}
+func (m *Manager) CreateVisionGrant(item *VisionGrant) {
+	if m.VisionGrantSession == nil {
+		m.VisionGrantSession = NewVisionGrantSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.VisionGrantSession.List = append(m.VisionGrantSession.List, item)
+}
+
+func (m *Manager) LaunchOrderArchive() *LaunchOrderArchiveSession {
+	s := NewLaunchOrderArchiveSession(m.D)
+	m.LaunchOrderArchiveSession = s
+	return s
+}
+
+func (m *Manager) CreateLaunchOrderArchive(item *LaunchOrderArchive) {
+	if m.LaunchOrderArchiveSession == nil {
+		m.LaunchOrderArchiveSession = NewLaunchOrderArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.LaunchOrderArchiveSession.List = append(m.LaunchOrderArchiveSession.List, item)
+}
+
+func (m *Manager) PowerOrderArchive() *PowerOrderArchiveSession {
+	s := NewPowerOrderArchiveSession(m.D)
+	m.PowerOrderArchiveSession = s
+	return s
+}
+
+func (m *Manager) CreatePowerOrderArchive(item *PowerOrderArchive) {
+	if m.PowerOrderArchiveSession == nil {
+		m.PowerOrderArchiveSession = NewPowerOrderArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.PowerOrderArchiveSession.List = append(m.PowerOrderArchiveSession.List, item)
+}
+
+func (m *Manager) TruceArchive() *TruceArchiveSession {
+	s := NewTruceArchiveSession(m.D)
+	m.TruceArchiveSession = s
+	return s
+}
+
+func (m *Manager) CreateTruceArchive(item *TruceArchive) {
+	if m.TruceArchiveSession == nil {
+		m.TruceArchiveSession = NewTruceArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.TruceArchiveSession.List = append(m.TruceArchiveSession.List, item)
+}
+func (m *Manager) TreatyArchive() *TreatyArchiveSession {
+	s := NewTreatyArchiveSession(m.D)
+	m.TreatyArchiveSession = s
+	return s
+}
+func (m *Manager) CreateTreatyArchive(item *TreatyArchive) {
+	if m.TreatyArchiveSession == nil {
+		m.TreatyArchiveSession = NewTreatyArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.TreatyArchiveSession.List = append(m.TreatyArchiveSession.List, item)
+}
+func (m *Manager) VisionGrantArchive() *VisionGrantArchiveSession {
+	s := NewVisionGrantArchiveSession(m.D)
+	m.VisionGrantArchiveSession = s
+	return s
+}
+func (m *Manager) CreateVisionGrantArchive(item *VisionGrantArchive) {
+	if m.VisionGrantArchiveSession == nil {
+		m.VisionGrantArchiveSession = NewVisionGrantArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.VisionGrantArchiveSession.List = append(m.VisionGrantArchiveSession.List, item)
+}
+
+func (m *Manager) ShipOrderArchive() *ShipOrderArchiveSession {
+	s := NewShipOrderArchiveSession(m.D)
+	m.ShipOrderArchiveSession = s
+	return s
+}
+
+func (m *Manager) CreateShipOrderArchive(item *ShipOrderArchive) {
+	if m.ShipOrderArchiveSession == nil {
+		m.ShipOrderArchiveSession = NewShipOrderArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.ShipOrderArchiveSession.List = append(m.ShipOrderArchiveSession.List, item)
+}
+func (m *Manager) ConditionalOrderArchive() *ConditionalOrderArchiveSession {
+	s := NewConditionalOrderArchiveSession(m.D)
+	m.ConditionalOrderArchiveSession = s
+	return s
+}
+func (m *Manager) CreateConditionalOrderArchive(item *ConditionalOrderArchive) {
+	if m.ConditionalOrderArchiveSession == nil {
+		m.ConditionalOrderArchiveSession = NewConditionalOrderArchiveSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.ConditionalOrderArchiveSession.List = append(m.ConditionalOrderArchiveSession.List, item)
+}
+
+func (m *Manager) Standing() *StandingSession {
+	s := NewStandingSession(m.D)
+	m.StandingSession = s
+	return s
+}
+
+func (m *Manager) CreateStanding(item *Standing) {
+	if m.StandingSession == nil {
+		m.StandingSession = NewStandingSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.StandingSession.List = append(m.StandingSession.List, item)
+}
+
+func (m *Manager) HomeReach() *HomeReachSession {
+	s := NewHomeReachSession(m.D)
+	m.HomeReachSession = s
+	return s
+}
+
+func (m *Manager) CreateHomeReach(item *HomeReach) {
+	if m.HomeReachSession == nil {
+		m.HomeReachSession = NewHomeReachSession(m.D)
+	}
+	item.sql.INSERT = true
+	m.HomeReachSession.List = append(m.HomeReachSession.List, item)
+}
 
 func (m *Manager) Close() error {
 	var err error
@@ -250,6 +504,22 @@ func (m *Manager) Close() error {
 		m.MapViewSession = nil
 	}
 
+	if m.MessageSession != nil {
+		err = m.MessageSession.Close()
+		if my, bad := Check(err, "manager close failure on Message Close"); bad {
+			return my
+		}
+		m.MessageSession = nil
+	}
+
+	if m.MessageReadSession != nil {
+		err = m.MessageReadSession.Close()
+		if my, bad := Check(err, "manager close failure on MessageRead Close"); bad {
+			return my
+		}
+		m.MessageReadSession = nil
+	}
+
 	if m.LaunchOrderSession != nil {
 		err = m.LaunchOrderSession.Close()
 		if my, bad := Check(err, "manager close failure on LaunchOrder Close"); bad {
@@ -298,6 +568,38 @@ func (m *Manager) Close() error {
 		m.ShipViewSession = nil
 	}
 
+	if m.ShipOrderSession != nil {
+		err = m.ShipOrderSession.Close()
+		if my, bad := Check(err, "manager close failure on ShipOrder Close"); bad {
+			return my
+		}
+		m.ShipOrderSession = nil
+	}
+
+	if m.StandingOrderSession != nil {
+		err = m.StandingOrderSession.Close()
+		if my, bad := Check(err, "manager close failure on StandingOrder Close"); bad {
+			return my
+		}
+		m.StandingOrderSession = nil
+	}
+
+	if m.ConditionalOrderSession != nil {
+		err = m.ConditionalOrderSession.Close()
+		if my, bad := Check(err, "manager close failure on ConditionalOrder Close"); bad {
+			return my
+		}
+		m.ConditionalOrderSession = nil
+	}
+
+	if m.ConditionRecordSession != nil {
+		err = m.ConditionRecordSession.Close()
+		if my, bad := Check(err, "manager close failure on ConditionRecord Close"); bad {
+			return my
+		}
+		m.ConditionRecordSession = nil
+	}
+
 	if m.TruceSession != nil {
 		err = m.TruceSession.Close()
 		if my, bad := Check(err, "manager close failure on Truce Close"); bad {
@@ -306,6 +608,102 @@ func (m *Manager) Close() error {
 		m.TruceSession = nil
 	}
 
+	if m.TreatySession != nil {
+		err = m.TreatySession.Close()
+		if my, bad := Check(err, "manager close failure on Treaty Close"); bad {
+			return my
+		}
+		m.TreatySession = nil
+	}
+
+	if m.TreatyBreakSession != nil {
+		err = m.TreatyBreakSession.Close()
+		if my, bad := Check(err, "manager close failure on TreatyBreak Close"); bad {
+			return my
+		}
+		m.TreatyBreakSession = nil
+	}
+
+	if m.VisionGrantSession != nil {
+		err = m.VisionGrantSession.Close()
+		if my, bad := Check(err, "manager close failure on VisionGrant Close"); bad {
+			return my
+		}
+		m.VisionGrantSession = nil
+	}
+
+	if m.LaunchOrderArchiveSession != nil {
+		err = m.LaunchOrderArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on LaunchOrderArchive Close"); bad {
+			return my
+		}
+		m.LaunchOrderArchiveSession = nil
+	}
+
+	if m.PowerOrderArchiveSession != nil {
+		err = m.PowerOrderArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on PowerOrderArchive Close"); bad {
+			return my
+		}
+		m.PowerOrderArchiveSession = nil
+	}
+
+	if m.TruceArchiveSession != nil {
+		err = m.TruceArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on TruceArchive Close"); bad {
+			return my
+		}
+		m.TruceArchiveSession = nil
+	}
+
+	if m.TreatyArchiveSession != nil {
+		err = m.TreatyArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on TreatyArchive Close"); bad {
+			return my
+		}
+		m.TreatyArchiveSession = nil
+	}
+
+	if m.VisionGrantArchiveSession != nil {
+		err = m.VisionGrantArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on VisionGrantArchive Close"); bad {
+			return my
+		}
+		m.VisionGrantArchiveSession = nil
+	}
+
+	if m.ShipOrderArchiveSession != nil {
+		err = m.ShipOrderArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on ShipOrderArchive Close"); bad {
+			return my
+		}
+		m.ShipOrderArchiveSession = nil
+	}
+
+	if m.ConditionalOrderArchiveSession != nil {
+		err = m.ConditionalOrderArchiveSession.Close()
+		if my, bad := Check(err, "manager close failure on ConditionalOrderArchive Close"); bad {
+			return my
+		}
+		m.ConditionalOrderArchiveSession = nil
+	}
+
+	if m.StandingSession != nil {
+		err = m.StandingSession.Close()
+		if my, bad := Check(err, "manager close failure on Standing Close"); bad {
+			return my
+		}
+		m.StandingSession = nil
+	}
+
+	if m.HomeReachSession != nil {
+		err = m.HomeReachSession.Close()
+		if my, bad := Check(err, "manager close failure on HomeReach Close"); bad {
+			return my
+		}
+		m.HomeReachSession = nil
+	}
+
 	return nil
 }
 
@@ -347,6 +745,16 @@ func CreateAllTables(d db.DBer) error {
 		return my
 	}
 
+	err = MessageTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table Message"); bad {
+		return my
+	}
+
+	err = MessageReadTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table MessageRead"); bad {
+		return my
+	}
+
 	err = LaunchOrderTableCreate(d)
 	if my, bad := Check(err, "Create all tables failure on table LaunchOrder"); bad {
 		return my
@@ -367,16 +775,106 @@ func CreateAllTables(d db.DBer) error {
 		return my
 	}
 
+	err = ShipOrderTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table ShipOrder"); bad {
+		return my
+	}
+
+	err = StandingOrderTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table StandingOrder"); bad {
+		return my
+	}
+
+	err = ConditionalOrderTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table ConditionalOrder"); bad {
+		return my
+	}
+
+	err = ConditionRecordTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table ConditionRecord"); bad {
+		return my
+	}
+
 	err = TruceTableCreate(d)
 	if my, bad := Check(err, "Create all tables failure on table Truce"); bad {
 		return my
 	}
 
+	err = TreatyTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table Treaty"); bad {
+		return my
+	}
+
+	err = TreatyBreakTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table TreatyBreak"); bad {
+		return my
+	}
+
+	err = VisionGrantTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table VisionGrant"); bad {
+		return my
+	}
+
+	err = LaunchOrderArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table LaunchOrderArchive"); bad {
+		return my
+	}
+
+	err = PowerOrderArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table PowerOrderArchive"); bad {
+		return my
+	}
+
+	err = TruceArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table TruceArchive"); bad {
+		return my
+	}
+
+	err = TreatyArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table TreatyArchive"); bad {
+		return my
+	}
+
+	err = VisionGrantArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table VisionGrantArchive"); bad {
+		return my
+	}
+
+	err = ShipOrderArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table ShipOrderArchive"); bad {
+		return my
+	}
+
+	err = ConditionalOrderArchiveTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table ConditionalOrderArchive"); bad {
+		return my
+	}
+
+	err = StandingTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table Standing"); bad {
+		return my
+	}
+
+	err = HomeReachTableCreate(d)
+	if my, bad := Check(err, "Create all tables failure on table HomeReach"); bad {
+		return my
+	}
+
+	err = SnapshotTablesCreate(d)
+	if my, bad := Check(err, "Create all tables failure on snapshot tables"); bad {
+		return my
+	}
+
 	return nil
 }
 
 func DropAllTables(d db.DBer) error {
 	var err error
+	err = SnapshotTablesDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on snapshot tables"); bad {
+		return my
+	}
+
 	err = BattleRecordTableDelete(d)
 	if my, bad := Check(err, "Delete all tables failure on table BattleRecord"); bad {
 		return my
@@ -402,6 +900,16 @@ func DropAllTables(d db.DBer) error {
 		return my
 	}
 
+	err = MessageTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table Message"); bad {
+		return my
+	}
+
+	err = MessageReadTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table MessageRead"); bad {
+		return my
+	}
+
 	err = LaunchOrderTableDelete(d)
 	if my, bad := Check(err, "Delete all tables failure on table LaunchOrder"); bad {
 		return my
@@ -422,6 +930,26 @@ func DropAllTables(d db.DBer) error {
 		return my
 	}
 
+	err = ShipOrderTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table ShipOrder"); bad {
+		return my
+	}
+
+	err = StandingOrderTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table StandingOrder"); bad {
+		return my
+	}
+
+	err = ConditionalOrderTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table ConditionalOrder"); bad {
+		return my
+	}
+
+	err = ConditionRecordTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table ConditionRecord"); bad {
+		return my
+	}
+
 	err = ShipTableDelete(d)
 	if my, bad := Check(err, "Delete all tables failure on table Ship"); bad {
 		return my
@@ -437,5 +965,65 @@ func DropAllTables(d db.DBer) error {
 		return my
 	}
 
+	err = TreatyTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table Treaty"); bad {
+		return my
+	}
+
+	err = TreatyBreakTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table TreatyBreak"); bad {
+		return my
+	}
+
+	err = VisionGrantTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table VisionGrant"); bad {
+		return my
+	}
+
+	err = LaunchOrderArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table LaunchOrderArchive"); bad {
+		return my
+	}
+
+	err = PowerOrderArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table PowerOrderArchive"); bad {
+		return my
+	}
+
+	err = TruceArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table TruceArchive"); bad {
+		return my
+	}
+
+	err = TreatyArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table TreatyArchive"); bad {
+		return my
+	}
+
+	err = VisionGrantArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table VisionGrantArchive"); bad {
+		return my
+	}
+
+	err = ShipOrderArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table ShipOrderArchive"); bad {
+		return my
+	}
+
+	err = ConditionalOrderArchiveTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table ConditionalOrderArchive"); bad {
+		return my
+	}
+
+	err = StandingTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table Standing"); bad {
+		return my
+	}
+
+	err = HomeReachTableDelete(d)
+	if my, bad := Check(err, "Delete all tables failure on table HomeReach"); bad {
+		return my
+	}
+
 	return nil
 }
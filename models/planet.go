@@ -23,6 +23,8 @@ type Planet struct {
 	SecondaryPower    int           `json:"secondarypower"`
 	Antimatter        int           `json:"antimatter"`
 	Tachyons          int           `json:"tachyons"`
+	Size              int           `json:"size"`
+	Radar             int           `json:"radar"`
 	sql               gp.SQLStruct
 }
 
@@ -72,6 +74,10 @@ func (item *Planet) SQLVal(name string) interface{} {
 		return item.Antimatter
 	case "tachyons":
 		return item.Tachyons
+	case "size":
+		return item.Size
+	case "radar":
+		return item.Radar
 	}
 	return nil
 }
@@ -102,6 +108,10 @@ func (item *Planet) SQLPtr(name string) interface{} {
 		return &item.Antimatter
 	case "tachyons":
 		return &item.Tachyons
+	case "size":
+		return &item.Size
+	case "radar":
+		return &item.Radar
 	}
 	return nil
 }
@@ -253,6 +263,22 @@ func (i PlanetIntf) SetTachyons(x int) {
 	i.item.sql.UPDATE = true
 }
 
+func (i PlanetIntf) Size() int {
+	return i.item.Size
+}
+
+func (i PlanetIntf) Radar() int {
+	return i.item.Radar
+}
+
+func (i PlanetIntf) SetRadar(x int) {
+	if i.item.Radar == x {
+		return
+	}
+	i.item.Radar = x
+	i.item.sql.UPDATE = true
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -383,6 +409,8 @@ func (group *PlanetGroup) InsertCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"size",
+		"radar",
 	}
 }
 
@@ -404,6 +432,8 @@ func (group *PlanetGroup) SelectCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"size",
+		"radar",
 	}
 }
 
@@ -417,6 +447,7 @@ func (group *PlanetGroup) UpdateCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"radar",
 	}
 }
 
@@ -501,6 +532,8 @@ func PlanetTableCreate(d db.DBer) error {
 	secondarypower int NOT NULL,
 	antimatter int NOT NULL,
 	tachyons int NOT NULL,
+	size int NOT NULL DEFAULT 0,
+	radar int NOT NULL DEFAULT 0,
 	UNIQUE(gid, name),
 	PRIMARY KEY(gid, locx, locy)
 );`
@@ -511,6 +544,14 @@ func PlanetTableCreate(d db.DBer) error {
 	return nil
 }
 
+// PlanetTableMigrate adds the columns a planet table made before them lacks.
+func PlanetTableMigrate(d db.DBer) error {
+	return addColumns(d, "planet",
+		"size int NOT NULL DEFAULT 0",
+		"radar int NOT NULL DEFAULT 0",
+	)
+}
+
 func PlanetTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS planet CASCADE"
 	err := db.Exec(d, false, query)
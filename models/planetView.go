@@ -25,6 +25,7 @@ type PlanetView struct {
 	SecondaryPower    int           `json:"secondarypower"`
 	Antimatter        int           `json:"antimatter"`
 	Tachyons          int           `json:"tachyons"`
+	Radar             int           `json:"radar"`
 	sql               gp.SQLStruct
 }
 
@@ -78,6 +79,8 @@ func (item *PlanetView) SQLVal(name string) interface{} {
 		return item.Antimatter
 	case "tachyons":
 		return item.Tachyons
+	case "radar":
+		return item.Radar
 	}
 	return nil
 }
@@ -112,6 +115,8 @@ func (item *PlanetView) SQLPtr(name string) interface{} {
 		return &item.Antimatter
 	case "tachyons":
 		return &item.Tachyons
+	case "radar":
+		return &item.Radar
 	}
 	return nil
 }
@@ -288,6 +293,18 @@ func (i PlanetViewIntf) SetTachyons(x int) {
 	i.item.sql.UPDATE = true
 }
 
+func (i PlanetViewIntf) Radar() int {
+	return i.item.Radar
+}
+
+func (i PlanetViewIntf) SetRadar(x int) {
+	if i.item.Radar == x {
+		return
+	}
+	i.item.Radar = x
+	i.item.sql.UPDATE = true
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -373,6 +390,7 @@ func (group *PlanetViewGroup) InsertCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"radar",
 	}
 }
 
@@ -396,6 +414,7 @@ func (group *PlanetViewGroup) SelectCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"radar",
 	}
 }
 
@@ -410,6 +429,7 @@ func (group *PlanetViewGroup) UpdateCols() []string {
 		"secondarypower",
 		"antimatter",
 		"tachyons",
+		"radar",
 	}
 }
 
@@ -488,6 +508,7 @@ func PlanetViewTableCreate(d db.DBer) error {
 	secondarypower int NOT NULL,
 	antimatter int NOT NULL,
 	tachyons int NOT NULL,
+	radar int NOT NULL DEFAULT 0,
 	FOREIGN KEY(gid, locx, locy) REFERENCES planet ON DELETE CASCADE,
 	PRIMARY KEY(gid, fid, locx, locy)
 );`
@@ -498,6 +519,13 @@ func PlanetViewTableCreate(d db.DBer) error {
 	return nil
 }
 
+// PlanetViewTableMigrate adds the columns a planetview table made before them lacks.
+func PlanetViewTableMigrate(d db.DBer) error {
+	return addColumns(d, "planetview",
+		"radar int NOT NULL DEFAULT 0",
+	)
+}
+
 func PlanetViewTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS planetview CASCADE"
 	err := db.Exec(d, false, query)
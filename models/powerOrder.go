@@ -100,14 +100,6 @@ func (i PowerOrderIntf) UpPower() int {
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
-func (i PowerOrderIntf) SetLoc(x hexagon.Coord) {
-	if i.item.Loc == x {
-		return
-	}
-	i.item.Loc = x
-	i.item.sql.UPDATE = true
-}
-
 func (i PowerOrderIntf) SetUpPower(x int) {
 	if i.item.UpPower == x {
 		return
@@ -177,6 +169,8 @@ func (group *PowerOrderGroup) PKCols() []string {
 	return []string{
 		"gid",
 		"fid",
+		"locx",
+		"locy",
 	}
 }
 
@@ -206,8 +200,6 @@ func (group *PowerOrderGroup) SelectCols() []string {
 
 func (group *PowerOrderGroup) UpdateCols() []string {
 	return []string{
-		"locx",
-		"locy",
 		"uppower",
 	}
 }
@@ -279,7 +271,7 @@ func PowerOrderTableCreate(d db.DBer) error {
 	locy int NOT NULL,
 	uppower int NOT NULL,
 	FOREIGN KEY(gid, locx, locy) REFERENCES planet ON DELETE CASCADE,
-	PRIMARY KEY(gid, fid)
+	PRIMARY KEY(gid, fid, locx, locy)
 );`
 
 	err := db.Exec(d, false, query)
@@ -289,6 +281,29 @@ func PowerOrderTableCreate(d db.DBer) error {
 	return nil
 }
 
+// PowerOrderTableMigrate moves a powerorder table from one standing order
+// per faction to orders keyed by planet and cleared each turn.  Orders
+// that change nothing are dropped, and power orders are no longer kept in
+// snapshots, being restored from the archive instead.
+func PowerOrderTableMigrate(d db.DBer) error {
+	for _, query := range []string{
+		`DELETE FROM powerorder o USING planet p
+	WHERE o.gid = p.gid AND o.locx = p.locx AND o.locy = p.locy AND
+	(o.uppower = 0 OR
+	(p.primaryfaction = o.fid AND p.primarypower = o.uppower) OR
+	(p.secondaryfaction = o.fid AND p.secondarypower = o.uppower))`,
+		"ALTER TABLE powerorder DROP CONSTRAINT powerorder_pkey",
+		"ALTER TABLE powerorder ADD PRIMARY KEY(gid, fid, locx, locy)",
+		"DROP TABLE IF EXISTS " + snapshotTable("powerorder"),
+	} {
+		err := db.Exec(d, false, query)
+		if my, bad := Check(err, "failed PowerOrder table migration", "query", query); bad {
+			return my
+		}
+	}
+	return nil
+}
+
 func PowerOrderTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS powerorder CASCADE"
 	err := db.Exec(d, false, query)
@@ -0,0 +1,223 @@
+package models
+
+import (
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type PowerOrderArchive struct {
+	GID     int           `json:"gid"`
+	Turn    int           `json:"turn"`
+	FID     int           `json:"fid"`
+	Loc     hexagon.Coord `json:"loc"`
+	UpPower int           `json:"uppower"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewPowerOrderArchive() *PowerOrderArchive {
+	return &PowerOrderArchive{
+		//
+	}
+}
+
+func (item *PowerOrderArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "uppower":
+		return item.UpPower
+	}
+	return nil
+}
+
+func (item *PowerOrderArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "uppower":
+		return &item.UpPower
+	}
+	return nil
+}
+func (item *PowerOrderArchive) SQLTable() string {
+	return "powerorderarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type PowerOrderArchiveGroup struct {
+	List []*PowerOrderArchive
+}
+
+func NewPowerOrderArchiveGroup() *PowerOrderArchiveGroup {
+	return &PowerOrderArchiveGroup{
+		List: []*PowerOrderArchive{},
+	}
+}
+
+func (item *PowerOrderArchive) SQLGroup() gp.SQLGrouper {
+	return NewPowerOrderArchiveGroup()
+}
+
+func (group *PowerOrderArchiveGroup) New() gp.SQLer {
+	item := NewPowerOrderArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *PowerOrderArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *PowerOrderArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *PowerOrderArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *PowerOrderArchiveGroup) SQLTable() string {
+	return "powerorderarchive"
+}
+
+func (group *PowerOrderArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+	}
+}
+
+func (group *PowerOrderArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+		"uppower",
+	}
+}
+
+func (group *PowerOrderArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *PowerOrderArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+		"uppower",
+	}
+}
+
+func (group *PowerOrderArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type PowerOrderArchiveSession struct {
+	*PowerOrderArchiveGroup
+	*gp.Session
+}
+
+func NewPowerOrderArchiveSession(d db.DBer) *PowerOrderArchiveSession {
+	group := NewPowerOrderArchiveGroup()
+	return &PowerOrderArchiveSession{
+		PowerOrderArchiveGroup: group,
+		Session:                gp.NewSession(group, d),
+	}
+}
+
+func (s *PowerOrderArchiveSession) Select(conditions ...interface{}) ([]*PowerOrderArchive, error) {
+	cur := len(s.PowerOrderArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "PowerOrderArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.PowerOrderArchiveGroup.List[cur:], nil
+}
+
+func (s *PowerOrderArchiveSession) SelectWhere(where sq.Condition) ([]*PowerOrderArchive, error) {
+	cur := len(s.PowerOrderArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "PowerOrderArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.PowerOrderArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func PowerOrderArchiveTableCreate(d db.DBer) error {
+	query := `create table powerorderarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	locx int NOT NULL,
+	locy int NOT NULL,
+	uppower int NOT NULL,
+	PRIMARY KEY(gid, turn, fid, locx, locy)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed PowerOrderArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func PowerOrderArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS powerorderarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed PowerOrderArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
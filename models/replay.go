@@ -0,0 +1,166 @@
+package models
+
+import (
+	"mule/overpower/memsource"
+)
+
+// ReplayGame reruns a game from its first turn with its archived orders and
+// lists each way the result differs from the stored planets and ships.
+func (d *DB) ReplayGame(gid int) ([]string, error) {
+	m := d.NewManager()
+	where := m.GID(gid)
+	games, err := m.Game().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	if len(games) == 0 {
+		return nil, ErrNoneFound
+	}
+	game := games[0]
+	if game.Turn() < 1 {
+		return nil, nil
+	}
+	factions, err := m.Faction().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	planets, err := m.Planet().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	ships, err := m.Ship().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	launches, err := m.LaunchOrderArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	shipOrders, err := m.ShipOrderArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	powers, err := m.PowerOrderArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	truces, err := m.TruceArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	treaties, err := m.TreatyArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	grants, err := m.VisionGrantArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	conditions, err := m.ConditionalOrderArchive().SelectWhere(where)
+	if my, bad := Check(err, "replay game resource failure", "gid", gid); bad {
+		return nil, my
+	}
+	archive := make([]*memsource.TurnOrders, game.Turn()-1)
+	for i, _ := range archive {
+		archive[i] = &memsource.TurnOrders{Turn: i + 1}
+	}
+	for _, o := range launches {
+		if o.Turn < 1 || o.Turn > len(archive) {
+			continue
+		}
+		ar := archive[o.Turn-1]
+		ar.LaunchOrders = append(ar.LaunchOrders, &memsource.LaunchOrder{
+			GID:    gid,
+			FID:    o.FID,
+			Source: o.Source,
+			Target: o.Target,
+			Size:   o.Size,
+		})
+	}
+	for _, o := range shipOrders {
+		if o.Turn < 1 || o.Turn > len(archive) {
+			continue
+		}
+		ar := archive[o.Turn-1]
+		ar.ShipOrders = append(ar.ShipOrders, &memsource.ShipOrder{
+			GID:    gid,
+			FID:    o.FID,
+			SID:    o.SID,
+			Target: o.Target,
+			Merge:  o.Merge,
+		})
+	}
+	for _, po := range powers {
+		if po.Turn < 1 || po.Turn > len(archive) {
+			continue
+		}
+		ar := archive[po.Turn-1]
+		ar.PowerOrders = append(ar.PowerOrders, &memsource.PowerOrder{
+			GID:     gid,
+			FID:     po.FID,
+			Loc:     po.Loc,
+			UpPower: po.UpPower,
+		})
+	}
+	for _, tr := range truces {
+		if tr.Turn < 1 || tr.Turn > len(archive) {
+			continue
+		}
+		ar := archive[tr.Turn-1]
+		ar.Truces = append(ar.Truces, &memsource.Truce{
+			GID:     gid,
+			FID:     tr.FID,
+			Loc:     tr.Loc,
+			Trucee:  tr.Trucee,
+			Hostile: tr.Hostile,
+		})
+	}
+	for _, tr := range treaties {
+		if tr.Turn < 1 || tr.Turn > len(archive) {
+			continue
+		}
+		ar := archive[tr.Turn-1]
+		ar.Treaties = append(ar.Treaties, &memsource.Treaty{
+			GID:      gid,
+			FID:      tr.FID,
+			Partner:  tr.Partner,
+			Kind:     tr.Kind,
+			Accepted: tr.Accepted,
+		})
+	}
+	for _, vg := range grants {
+		if vg.Turn < 1 || vg.Turn > len(archive) {
+			continue
+		}
+		ar := archive[vg.Turn-1]
+		ar.VisionGrants = append(ar.VisionGrants, &memsource.VisionGrant{
+			GID:     gid,
+			FID:     vg.FID,
+			Grantee: vg.Grantee,
+		})
+	}
+	for _, o := range conditions {
+		if o.Turn < 1 || o.Turn > len(archive) {
+			continue
+		}
+		ar := archive[o.Turn-1]
+		ar.Conditionals = append(ar.Conditionals, &memsource.ConditionalOrder{
+			GID:    gid,
+			FID:    o.FID,
+			CID:    o.CID,
+			Kind:   o.Kind,
+			Loc:    o.Loc,
+			Within: o.Within,
+			Action: o.Action,
+			Source: o.Source,
+			Target: o.Target,
+			Size:   o.Size,
+			Power:  o.Power,
+		})
+	}
+	s, err := memsource.Replay(game, factions, archive)
+	if my, bad := Check(err, "replay game failure", "gid", gid); bad {
+		return nil, my
+	}
+	return s.Diff(planets, ships), nil
+}
@@ -15,6 +15,7 @@ type Ship struct {
 	FID      int               `json:"fid"`
 	SID      int               `json:"sid"`
 	Size     int               `json:"size"`
+	Power    int               `json:"power"`
 	Launched int               `json:"launched"`
 	Path     hexagon.CoordList `json:"path"`
 	sql      gp.SQLStruct
@@ -50,6 +51,8 @@ func (item *Ship) SQLVal(name string) interface{} {
 		return item.SID
 	case "size":
 		return item.Size
+	case "power":
+		return item.Power
 	case "launched":
 		return item.Launched
 	case "path":
@@ -68,6 +71,8 @@ func (item *Ship) SQLPtr(name string) interface{} {
 		return &item.SID
 	case "size":
 		return &item.Size
+	case "power":
+		return &item.Power
 	case "launched":
 		return &item.Launched
 	case "path":
@@ -103,6 +108,10 @@ func (i ShipIntf) Size() int {
 	return i.item.Size
 }
 
+func (i ShipIntf) Power() int {
+	return i.item.Power
+}
+
 func (i ShipIntf) Launched() int {
 	return i.item.Launched
 }
@@ -111,6 +120,27 @@ func (i ShipIntf) Path() hexagon.CoordList {
 	return i.item.Path
 }
 
+func (i ShipIntf) SetSize(x int) {
+	if i.item.Size == x {
+		return
+	}
+	i.item.Size = x
+	i.item.sql.UPDATE = true
+}
+
+func (i ShipIntf) SetLaunched(x int) {
+	if i.item.Launched == x {
+		return
+	}
+	i.item.Launched = x
+	i.item.sql.UPDATE = true
+}
+
+func (i ShipIntf) SetPath(x hexagon.CoordList) {
+	i.item.Path = x
+	i.item.sql.UPDATE = true
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -138,7 +168,13 @@ func (group *ShipGroup) New() gp.SQLer {
 }
 
 func (group *ShipGroup) UpdateList() []gp.SQLer {
-	return nil
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
 }
 
 func (group *ShipGroup) InsertList() []gp.SQLer {
@@ -179,6 +215,7 @@ func (group *ShipGroup) InsertCols() []string {
 		"fid",
 		"sid",
 		"size",
+		"power",
 		"launched",
 		"path",
 	}
@@ -194,13 +231,18 @@ func (group *ShipGroup) SelectCols() []string {
 		"fid",
 		"sid",
 		"size",
+		"power",
 		"launched",
 		"path",
 	}
 }
 
 func (group *ShipGroup) UpdateCols() []string {
-	return nil
+	return []string{
+		"size",
+		"launched",
+		"path",
+	}
 }
 
 // --------- END GROUP ------------ //
@@ -268,6 +310,7 @@ func ShipTableCreate(d db.DBer) error {
 	fid int NOT NULL REFERENCES faction ON DELETE CASCADE,
 	sid int NOT NULL,
 	size int NOT NULL,
+	power int NOT NULL DEFAULT 0,
 	launched int NOT NULL,
 	path point[] NOT NULL,
 	PRIMARY KEY(gid, fid, sid)
@@ -280,6 +323,13 @@ func ShipTableCreate(d db.DBer) error {
 	return nil
 }
 
+// ShipTableMigrate adds the columns a ship table made before them lacks.
+func ShipTableMigrate(d db.DBer) error {
+	return addColumns(d, "ship",
+		"power int NOT NULL DEFAULT 0",
+	)
+}
+
 func ShipTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS ship CASCADE"
 	err := db.Exec(d, false, query)
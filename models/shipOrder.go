@@ -0,0 +1,314 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type ShipOrder struct {
+	GID    int           `json:"gid"`
+	FID    int           `json:"fid"`
+	SID    int           `json:"sid"`
+	Target hexagon.Coord `json:"target"`
+	Merge  int           `json:"merge"`
+	sql    gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewShipOrder() *ShipOrder {
+	return &ShipOrder{
+	//
+	}
+}
+
+type ShipOrderIntf struct {
+	item *ShipOrder
+}
+
+func (item *ShipOrder) Intf() overpower.ShipOrderDat {
+	return &ShipOrderIntf{item}
+}
+
+func (i ShipOrderIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *ShipOrder) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "sid":
+		return item.SID
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "merge":
+		return item.Merge
+	}
+	return nil
+}
+
+func (item *ShipOrder) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "sid":
+		return &item.SID
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "merge":
+		return &item.Merge
+	}
+	return nil
+}
+func (item *ShipOrder) SQLTable() string {
+	return "shiporder"
+}
+
+func (i ShipOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i ShipOrderIntf) UnmarshalJSON(data []byte) error {
+	i.item = &ShipOrder{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i ShipOrderIntf) GID() int {
+	return i.item.GID
+}
+
+func (i ShipOrderIntf) FID() int {
+	return i.item.FID
+}
+
+func (i ShipOrderIntf) SID() int {
+	return i.item.SID
+}
+
+func (i ShipOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+
+func (i ShipOrderIntf) Merge() int {
+	return i.item.Merge
+}
+
+func (i ShipOrderIntf) SetTarget(x hexagon.Coord) {
+	if i.item.Target == x {
+		return
+	}
+	i.item.Target = x
+	i.item.sql.UPDATE = true
+}
+
+func (i ShipOrderIntf) SetMerge(x int) {
+	if i.item.Merge == x {
+		return
+	}
+	i.item.Merge = x
+	i.item.sql.UPDATE = true
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type ShipOrderGroup struct {
+	List []*ShipOrder
+}
+
+func NewShipOrderGroup() *ShipOrderGroup {
+	return &ShipOrderGroup{
+		List: []*ShipOrder{},
+	}
+}
+
+func (item *ShipOrder) SQLGroup() gp.SQLGrouper {
+	return NewShipOrderGroup()
+}
+
+func (group *ShipOrderGroup) New() gp.SQLer {
+	item := NewShipOrder()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *ShipOrderGroup) UpdateList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ShipOrderGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ShipOrderGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ShipOrderGroup) SQLTable() string {
+	return "shiporder"
+}
+
+func (group *ShipOrderGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sid",
+	}
+}
+
+func (group *ShipOrderGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sid",
+		"targetx",
+		"targety",
+		"merge",
+	}
+}
+
+func (group *ShipOrderGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *ShipOrderGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sid",
+		"targetx",
+		"targety",
+		"merge",
+	}
+}
+
+func (group *ShipOrderGroup) UpdateCols() []string {
+	return []string{
+		"targetx",
+		"targety",
+		"merge",
+	}
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type ShipOrderSession struct {
+	*ShipOrderGroup
+	*gp.Session
+}
+
+func NewShipOrderSession(d db.DBer) *ShipOrderSession {
+	group := NewShipOrderGroup()
+	return &ShipOrderSession{
+		ShipOrderGroup: group,
+		Session:        gp.NewSession(group, d),
+	}
+}
+
+func (s *ShipOrderSession) Select(conditions ...interface{}) ([]overpower.ShipOrderDat, error) {
+	cur := len(s.ShipOrderGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "ShipOrder select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertShipOrder2Intf(s.ShipOrderGroup.List[cur:]...), nil
+}
+
+func (s *ShipOrderSession) SelectWhere(where sq.Condition) ([]overpower.ShipOrderDat, error) {
+	cur := len(s.ShipOrderGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "ShipOrder SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertShipOrder2Intf(s.ShipOrderGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertShipOrder2Struct(list ...overpower.ShipOrderDat) ([]*ShipOrder, error) {
+	mylist := make([]*ShipOrder, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(ShipOrderIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad ShipOrder struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertShipOrder2Intf(list ...*ShipOrder) []overpower.ShipOrderDat {
+	converted := make([]overpower.ShipOrderDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func ShipOrderTableCreate(d db.DBer) error {
+	query := `create table shiporder(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	sid integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	merge integer NOT NULL,
+	FOREIGN KEY(gid, fid, sid) REFERENCES ship ON DELETE CASCADE,
+	PRIMARY KEY(gid, fid, sid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ShipOrder table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func ShipOrderTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS shiporder CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ShipOrder table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
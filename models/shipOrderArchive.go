@@ -0,0 +1,230 @@
+package models
+
+import (
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type ShipOrderArchive struct {
+	GID    int           `json:"gid"`
+	Turn   int           `json:"turn"`
+	FID    int           `json:"fid"`
+	SID    int           `json:"sid"`
+	Target hexagon.Coord `json:"target"`
+	Merge  int           `json:"merge"`
+	sql    gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewShipOrderArchive() *ShipOrderArchive {
+	return &ShipOrderArchive{
+		//
+	}
+}
+
+func (item *ShipOrderArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "sid":
+		return item.SID
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "merge":
+		return item.Merge
+	}
+	return nil
+}
+
+func (item *ShipOrderArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "sid":
+		return &item.SID
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "merge":
+		return &item.Merge
+	}
+	return nil
+}
+func (item *ShipOrderArchive) SQLTable() string {
+	return "shiporderarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type ShipOrderArchiveGroup struct {
+	List []*ShipOrderArchive
+}
+
+func NewShipOrderArchiveGroup() *ShipOrderArchiveGroup {
+	return &ShipOrderArchiveGroup{
+		List: []*ShipOrderArchive{},
+	}
+}
+
+func (item *ShipOrderArchive) SQLGroup() gp.SQLGrouper {
+	return NewShipOrderArchiveGroup()
+}
+
+func (group *ShipOrderArchiveGroup) New() gp.SQLer {
+	item := NewShipOrderArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *ShipOrderArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *ShipOrderArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ShipOrderArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *ShipOrderArchiveGroup) SQLTable() string {
+	return "shiporderarchive"
+}
+
+func (group *ShipOrderArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sid",
+	}
+}
+
+func (group *ShipOrderArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sid",
+		"targetx",
+		"targety",
+		"merge",
+	}
+}
+
+func (group *ShipOrderArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *ShipOrderArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"sid",
+		"targetx",
+		"targety",
+		"merge",
+	}
+}
+
+func (group *ShipOrderArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type ShipOrderArchiveSession struct {
+	*ShipOrderArchiveGroup
+	*gp.Session
+}
+
+func NewShipOrderArchiveSession(d db.DBer) *ShipOrderArchiveSession {
+	group := NewShipOrderArchiveGroup()
+	return &ShipOrderArchiveSession{
+		ShipOrderArchiveGroup: group,
+		Session:               gp.NewSession(group, d),
+	}
+}
+
+func (s *ShipOrderArchiveSession) Select(conditions ...interface{}) ([]*ShipOrderArchive, error) {
+	cur := len(s.ShipOrderArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "ShipOrderArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.ShipOrderArchiveGroup.List[cur:], nil
+}
+
+func (s *ShipOrderArchiveSession) SelectWhere(where sq.Condition) ([]*ShipOrderArchive, error) {
+	cur := len(s.ShipOrderArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "ShipOrderArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.ShipOrderArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func ShipOrderArchiveTableCreate(d db.DBer) error {
+	query := `create table shiporderarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	sid integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	merge integer NOT NULL,
+	PRIMARY KEY(gid, turn, fid, sid)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ShipOrderArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func ShipOrderArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS shiporderarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed ShipOrderArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -17,6 +17,7 @@ type ShipView struct {
 	SID        int               `json:"sid"`
 	Controller int               `json:"controller"`
 	Size       int               `json:"size"`
+	Power      int               `json:"power"`
 	Loc        hexagon.NullCoord `json:"loc"`
 	Dest       hexagon.NullCoord `json:"dest"`
 	Trail      hexagon.CoordList `json:"trail"`
@@ -57,6 +58,8 @@ func (item *ShipView) SQLVal(name string) interface{} {
 		return item.Controller
 	case "size":
 		return item.Size
+	case "power":
+		return item.Power
 	case "loc":
 		return item.Loc
 	case "dest":
@@ -81,6 +84,8 @@ func (item *ShipView) SQLPtr(name string) interface{} {
 		return &item.Controller
 	case "size":
 		return &item.Size
+	case "power":
+		return &item.Power
 	case "loc":
 		return &item.Loc
 	case "dest":
@@ -126,6 +131,10 @@ func (i ShipViewIntf) Size() int {
 	return i.item.Size
 }
 
+func (i ShipViewIntf) Power() int {
+	return i.item.Power
+}
+
 func (i ShipViewIntf) Loc() hexagon.NullCoord {
 	return i.item.Loc
 }
@@ -215,6 +224,7 @@ func (group *ShipViewGroup) InsertCols() []string {
 		"sid",
 		"controller",
 		"size",
+		"power",
 		"loc",
 		"dest",
 		"trail",
@@ -233,6 +243,7 @@ func (group *ShipViewGroup) SelectCols() []string {
 		"sid",
 		"controller",
 		"size",
+		"power",
 		"loc",
 		"dest",
 		"trail",
@@ -247,6 +258,7 @@ func (group *ShipViewGroup) UpdateCols() []string {
 		"sid",
 		"controller",
 		"size",
+		"power",
 		"loc",
 		"dest",
 		"trail",
@@ -323,6 +335,7 @@ func ShipViewTableCreate(d db.DBer) error {
 	dest point,
 	trail point[] NOT NULL,
 	size int NOT NULL,
+	power int NOT NULL DEFAULT 0,
 	PRIMARY KEY(gid, fid, turn, sid)
 );`
 	err := db.Exec(d, false, query)
@@ -332,6 +345,13 @@ func ShipViewTableCreate(d db.DBer) error {
 	return nil
 }
 
+// ShipViewTableMigrate adds the columns a shipview table made before them lacks.
+func ShipViewTableMigrate(d db.DBer) error {
+	return addColumns(d, "shipview",
+		"power int NOT NULL DEFAULT 0",
+	)
+}
+
 func ShipViewTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS shipview CASCADE"
 	err := db.Exec(d, false, query)
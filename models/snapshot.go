@@ -0,0 +1,212 @@
+package models
+
+import (
+	"fmt"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	"strings"
+)
+
+// snapshotTables are copied for a game at the start of every turn, listed
+// in the order they are restored.
+var snapshotTables = []gp.SQLGrouper{
+	NewGameGroup(),
+	NewFactionGroup(),
+	NewPlanetGroup(),
+	NewPlanetViewGroup(),
+	NewShipGroup(),
+	NewShipViewGroup(),
+	NewTruceGroup(),
+	NewTreatyGroup(),
+	NewVisionGrantGroup(),
+	NewStandingOrderGroup(),
+	NewConditionalOrderGroup(),
+	NewBattleRecordGroup(),
+	NewLaunchRecordGroup(),
+	NewConditionRecordGroup(),
+	NewTreatyBreakGroup(),
+	NewStandingGroup(),
+}
+
+func snapshotTable(table string) string {
+	return table + "snapshot"
+}
+
+// SnapshotGame copies the game's rows into the snapshot tables under the
+// game's current turn, replacing any earlier snapshot of that turn.
+func SnapshotGame(d db.DBer, gid int) error {
+	var turn int
+	err := d.QueryRow("SELECT turn FROM game WHERE gid = $1", gid).Scan(&turn)
+	if my, bad := Check(err, "snapshot game failure on turn lookup", "gid", gid); bad {
+		return my
+	}
+	for _, g := range snapshotTables {
+		table := g.SQLTable()
+		cols := strings.Join(g.SelectCols(), ", ")
+		query := "DELETE FROM " + snapshotTable(table) + " WHERE gid = $1 AND snapturn = $2"
+		_, err = d.Exec(query, gid, turn)
+		if my, bad := Check(err, "snapshot game failure on clearing old snapshot", "query", query, "gid", gid, "turn", turn); bad {
+			return my
+		}
+		query = fmt.Sprintf("INSERT INTO %s(snapturn, %s) SELECT $2, %s FROM %s WHERE gid = $1",
+			snapshotTable(table), cols, cols, table)
+		_, err = d.Exec(query, gid, turn)
+		if my, bad := Check(err, "snapshot game failure on copy", "query", query, "gid", gid, "turn", turn); bad {
+			return my
+		}
+	}
+	return nil
+}
+
+// SnapshotTurns lists the turns a game can be rewound to, earliest first.
+func (d *DB) SnapshotTurns(gid int) ([]int, error) {
+	query := "SELECT snapturn FROM " + snapshotTable("game") + " WHERE gid = $1 ORDER BY snapturn"
+	rows, err := d.Query(query, gid)
+	if my, bad := Check(err, "snapshot turns failure on query", "query", query, "gid", gid); bad {
+		return nil, my
+	}
+	defer rows.Close()
+	var turns []int
+	for rows.Next() {
+		var turn int
+		err = rows.Scan(&turn)
+		if my, bad := Check(err, "snapshot turns failure on scan", "gid", gid); bad {
+			return nil, my
+		}
+		turns = append(turns, turn)
+	}
+	err = rows.Err()
+	if my, bad := Check(err, "snapshot turns failure on rows", "gid", gid); bad {
+		return nil, my
+	}
+	return turns, nil
+}
+
+// RewindGame puts a game back to how it stood at the start of the given
+// turn.  The launch, ship and power orders that turn was run with are put back
+// in place, bar the launches made from standing orders, which the restored
+// standing orders make again.  The archives and messages from that turn on
+// and snapshots of later turns are discarded.
+func (d *DB) RewindGame(gid, turn int) error {
+	err := db.Transact(d.DB, func(d db.DBer) error {
+		return rewindGame(d, gid, turn)
+	})
+	if my, bad := Check(err, "rewind game failure", "gid", gid, "turn", turn); bad {
+		return my
+	}
+	return nil
+}
+
+func rewindGame(d db.DBer, gid, turn int) error {
+	var found int
+	query := "SELECT count(*) FROM " + snapshotTable("game") + " WHERE gid = $1 AND snapturn = $2"
+	err := d.QueryRow(query, gid, turn).Scan(&found)
+	if my, bad := Check(err, "rewind failure on snapshot lookup", "query", query); bad {
+		return my
+	}
+	if found == 0 {
+		return ErrNoneFound
+	}
+	exec := func(query string, args ...interface{}) error {
+		_, err := d.Exec(query, args...)
+		if my, bad := Check(err, "rewind failure", "query", query, "args", args); bad {
+			return my
+		}
+		return nil
+	}
+	// game and faction rows stay put, as removing them cascades to
+	// everything else; the rest is cleared and copied back in.  Factions
+	// that quit since stay gone, so the game keeps its quits, moved back
+	// to the turn rewound to for replays.
+	for _, g := range snapshotTables[:2] {
+		table := g.SQLTable()
+		var kept []string
+		for _, col := range g.UpdateCols() {
+			if col != "quits" {
+				kept = append(kept, col)
+			}
+		}
+		cols := strings.Join(kept, ", ")
+		match := []string{"s.gid = t.gid"}
+		for _, pk := range g.PKCols() {
+			if pk != "gid" {
+				match = append(match, fmt.Sprintf("s.%s = t.%s", pk, pk))
+			}
+		}
+		err = exec(fmt.Sprintf("UPDATE %s t SET (%s) = (SELECT %s FROM %s s WHERE s.snapturn = $2 AND %s) WHERE t.gid = $1",
+			table, cols, cols, snapshotTable(table), strings.Join(match, " AND ")), gid, turn)
+		if err != nil {
+			return err
+		}
+	}
+	err = exec("UPDATE game SET quits = array(SELECT CASE WHEN q > $2 THEN $2 ELSE q END FROM unnest(quits) WITH ORDINALITY AS u(q, i) ORDER BY i) WHERE gid = $1", gid, turn)
+	if err != nil {
+		return err
+	}
+	for _, table := range []string{"launchorder", "shiporder", "powerorder", "ship", "shipview", "battlerecord", "launchrecord", "conditionrecord", "treaty", "treatybreak", "visiongrant", "standing", "planet"} {
+		if err = exec("DELETE FROM "+table+" WHERE gid = $1", gid); err != nil {
+			return err
+		}
+	}
+	for _, g := range snapshotTables[2:] {
+		table := g.SQLTable()
+		cols := strings.Join(g.SelectCols(), ", ")
+		err = exec(fmt.Sprintf("INSERT INTO %s(%s) SELECT %s FROM %s WHERE gid = $1 AND snapturn = $2",
+			table, cols, cols, snapshotTable(table)), gid, turn)
+		if err != nil {
+			return err
+		}
+	}
+	cols := strings.Join(NewLaunchOrderGroup().InsertCols(), ", ")
+	err = exec(fmt.Sprintf("INSERT INTO launchorder(%s) SELECT %s FROM launchorderarchive WHERE gid = $1 AND turn = $2 AND NOT standing", cols, cols), gid, turn)
+	if err != nil {
+		return err
+	}
+	cols = strings.Join(NewShipOrderGroup().InsertCols(), ", ")
+	err = exec(fmt.Sprintf("INSERT INTO shiporder(%s) SELECT %s FROM shiporderarchive WHERE gid = $1 AND turn = $2", cols, cols), gid, turn)
+	if err != nil {
+		return err
+	}
+	cols = strings.Join(NewPowerOrderGroup().InsertCols(), ", ")
+	err = exec(fmt.Sprintf("INSERT INTO powerorder(%s) SELECT %s FROM powerorderarchive WHERE gid = $1 AND turn = $2", cols, cols), gid, turn)
+	if err != nil {
+		return err
+	}
+	for _, table := range []string{"launchorderarchive", "shiporderarchive", "powerorderarchive", "trucearchive", "conditionalorderarchive", "treatyarchive", "visiongrantarchive", "message"} {
+		if err = exec("DELETE FROM "+table+" WHERE gid = $1 AND turn >= $2", gid, turn); err != nil {
+			return err
+		}
+	}
+	for _, g := range snapshotTables {
+		if err = exec("DELETE FROM "+snapshotTable(g.SQLTable())+" WHERE gid = $1 AND snapturn > $2", gid, turn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func SnapshotTablesCreate(d db.DBer) error {
+	for _, g := range snapshotTables {
+		query := fmt.Sprintf(`create table %s(
+	snapturn int NOT NULL,
+	LIKE %s,
+	FOREIGN KEY(gid) REFERENCES game ON DELETE CASCADE
+);`, snapshotTable(g.SQLTable()), g.SQLTable())
+		err := db.Exec(d, false, query)
+		if my, bad := Check(err, "failed snapshot table creation", "query", query); bad {
+			return my
+		}
+	}
+	return nil
+}
+
+func SnapshotTablesDelete(d db.DBer) error {
+	for _, g := range snapshotTables {
+		query := "DROP TABLE IF EXISTS " + snapshotTable(g.SQLTable()) + " CASCADE"
+		err := db.Exec(d, false, query)
+		if my, bad := Check(err, "failed snapshot table deletion", "query", query); bad {
+			return my
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"fmt"
+	"mule/overpower"
+	"reflect"
+	"testing"
+)
+
+// rewoundTables are compared between a turn run once and the same turn run
+// again after a rewind.
+var rewoundTables = []string{"game", "faction", "planet", "planetview", "ship", "shipview", "standingorder", "launchrecord", "battlerecord"}
+
+// tableRows gives the game's rows in each of the tables as text.
+func tableRows(t *testing.T, d *DB, gid int) map[string][]string {
+	rows := map[string][]string{}
+	for _, table := range rewoundTables {
+		query := fmt.Sprintf("SELECT t::text FROM %s t WHERE gid = $1 ORDER BY 1", table)
+		res, err := d.Query(query, gid)
+		if err != nil {
+			t.Fatal("query failed:", query, err)
+		}
+		for res.Next() {
+			var row string
+			if err := res.Scan(&row); err != nil {
+				t.Fatal("scan failed:", query, err)
+			}
+			rows[table] = append(rows[table], row)
+		}
+		if err := res.Close(); err != nil {
+			t.Fatal("rows failed:", query, err)
+		}
+	}
+	return rows
+}
+
+// makeRewindGame starts a fresh two faction game and gives the first
+// faction a launch order and the second a standing order for turn one.
+func makeRewindGame(t *testing.T, d *DB) int {
+	g := &Game{
+		Owner: "Testing_Rewind",
+		Name:  "RewindGame",
+		ToWin: 100,
+	}
+	_, failE := d.Transact(func(m *Manager) (logE, failE error) {
+		old, err := m.Game().Select("owner", g.Owner)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range old {
+			o.DELETE()
+		}
+		if err = m.Close(); err != nil {
+			return nil, err
+		}
+		m.CreateGame(g)
+		if err = m.Close(); err != nil {
+			return nil, err
+		}
+		for i := 0; i < 2; i++ {
+			m.CreateFaction(&Faction{
+				GID:   g.GID,
+				Owner: fmt.Sprintf("Rewinder%d", i),
+				Name:  fmt.Sprintf("Rewinder%d", i),
+			})
+		}
+		return nil, m.Close()
+	})
+	if failE != nil {
+		t.Fatal("make game failed:", failE)
+	}
+	_, failE = d.TurnTransact(g.GID, func(s overpower.Source) (logE, failE error) {
+		return nil, overpower.MakeGalaxy(s, "standard")
+	})
+	if failE != nil {
+		t.Fatal("make galaxy failed:", failE)
+	}
+	_, failE = d.Transact(func(m *Manager) (logE, failE error) {
+		s := NewSource(m, g.GID)
+		factions, err := s.Factions()
+		if err != nil {
+			return nil, err
+		}
+		planets, err := s.Planets()
+		if err != nil {
+			return nil, err
+		}
+		var free []overpower.PlanetDat
+		homes := map[int]overpower.PlanetDat{}
+		for _, pl := range planets {
+			if pl.PrimaryFaction() == 0 {
+				free = append(free, pl)
+			} else {
+				homes[pl.PrimaryFaction()] = pl
+			}
+		}
+		if len(factions) != 2 || len(homes) != 2 || len(free) < 2 {
+			return nil, fmt.Errorf("made %d factions, %d homes and %d free planets", len(factions), len(homes), len(free))
+		}
+		a, b := factions[0].FID(), factions[1].FID()
+		m.CreateLaunchOrder(&LaunchOrder{
+			GID:    g.GID,
+			FID:    a,
+			Source: homes[a].Loc(),
+			Target: free[0].Loc(),
+			Size:   2,
+		})
+		m.CreateStandingOrder(&StandingOrder{
+			GID:    g.GID,
+			FID:    b,
+			Source: homes[b].Loc(),
+			Target: free[1].Loc(),
+			Size:   1,
+			Turns:  2,
+		})
+		return nil, m.Close()
+	})
+	if failE != nil {
+		t.Fatal("give orders failed:", failE)
+	}
+	return g.GID
+}
+
+func TestRewind(t *testing.T) {
+	d, err := LoadDB()
+	if err != nil {
+		t.Skip("no database:", err)
+	}
+	gid := makeRewindGame(t, d)
+	runTurn := func() {
+		if _, failE := d.TurnTransact(gid, overpower.RunGameTurn); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+	}
+	runTurn()
+	once := tableRows(t, d, gid)
+	runTurn()
+	if err := d.RewindGame(gid, 1); err != nil {
+		t.Fatal("rewind failed:", err)
+	}
+	launches, err := d.NewManager().LaunchOrder().Select("gid", gid)
+	if err != nil {
+		t.Fatal("launch order select failed:", err)
+	}
+	if len(launches) != 1 {
+		t.Errorf("rewind left %d launch orders, want the 1 given: %v", len(launches), launches)
+	}
+	turns, err := d.SnapshotTurns(gid)
+	if err != nil {
+		t.Fatal("snapshot turns failed:", err)
+	}
+	if !reflect.DeepEqual(turns, []int{1}) {
+		t.Errorf("rewind kept snapshots %v, want [1]", turns)
+	}
+	runTurn()
+	again := tableRows(t, d, gid)
+	for _, table := range rewoundTables {
+		if !reflect.DeepEqual(once[table], again[table]) {
+			t.Errorf("%s after rewind and rerun:\n%v\nwant:\n%v", table, again[table], once[table])
+		}
+	}
+}
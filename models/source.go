@@ -51,9 +51,24 @@ func (s *Source) Ships() ([]overpower.ShipDat, error) {
 func (s *Source) Truces() ([]overpower.TruceDat, error) {
 	return s.M.Truce().SelectWhere(s.Where)
 }
+func (s *Source) Treaties() ([]overpower.TreatyDat, error) {
+	return s.M.Treaty().SelectWhere(s.Where)
+}
+func (s *Source) VisionGrants() ([]overpower.VisionGrantDat, error) {
+	return s.M.VisionGrant().SelectWhere(s.Where)
+}
 func (s *Source) PowerOrders() ([]overpower.PowerOrderDat, error) {
 	return s.M.PowerOrder().SelectWhere(s.Where)
 }
+func (s *Source) StandingOrders() ([]overpower.StandingOrderDat, error) {
+	return s.M.StandingOrder().SelectWhere(s.Where)
+}
+func (s *Source) ConditionalOrders() ([]overpower.ConditionalOrderDat, error) {
+	return s.M.ConditionalOrder().SelectWhere(s.Where)
+}
+func (s *Source) ShipOrders() ([]overpower.ShipOrderDat, error) {
+	return s.M.ShipOrder().SelectWhere(s.Where)
+}
 
 func (s *Source) UpdatePlanetView(fid, turn int, planet overpower.PlanetDat) overpower.PlanetViewDat {
 	pv := &PlanetView{
@@ -69,6 +84,7 @@ func (s *Source) UpdatePlanetView(fid, turn int, planet overpower.PlanetDat) ove
 		SecondaryPower:    planet.SecondaryPower(),
 		Antimatter:        planet.Antimatter(),
 		Tachyons:          planet.Tachyons(),
+		Radar:             planet.Radar(),
 	}
 	pF := planet.PrimaryFaction()
 	sF := planet.SecondaryFaction()
@@ -96,14 +112,18 @@ func (s *Source) ClearLaunchOrders() error {
 	return s.M.LaunchOrder().Delete(s.Where)
 }
 
-//func (s *Source) ClearPowerOrders() error {
-//return s.M.PowerOrder().Delete(s.Where)
-//}
+func (s *Source) ClearShipOrders() error {
+	return s.M.ShipOrder().Delete(s.Where)
+}
+
+func (s *Source) ClearPowerOrders() error {
+	return s.M.PowerOrder().Delete(s.Where)
+}
 
 func (s *Source) NewPlanet(name string,
 	primaryFac, prPres, prPower,
 	secondaryFac, sePres, sePower,
-	antimatter, tachyons int,
+	antimatter, tachyons, size int,
 	loc hexagon.Coord,
 ) overpower.PlanetDat {
 	pl := &Planet{
@@ -116,6 +136,7 @@ func (s *Source) NewPlanet(name string,
 		SecondaryPower:    sePower,
 		Antimatter:        antimatter,
 		Tachyons:          tachyons,
+		Size:              size,
 	}
 	if primaryFac != 0 {
 		pl.PrimaryFaction = sql.NullInt64{Valid: true, Int64: int64(primaryFac)}
@@ -151,6 +172,7 @@ func (s *Source) NewPlanetView(fid int, pl overpower.PlanetDat, exodus bool) ove
 		pv.SecondaryPower = pl.SecondaryPower()
 		pv.Antimatter = pl.Antimatter()
 		pv.Tachyons = pl.Tachyons()
+		pv.Radar = pl.Radar()
 	}
 	s.M.CreatePlanetView(pv)
 	return pv.Intf()
@@ -166,12 +188,13 @@ func (s *Source) NewMapView(fid int, center hexagon.Coord) overpower.MapViewDat
 	return mv.Intf()
 }
 
-func (s *Source) NewShip(fid, sid, size, turn int, path hexagon.CoordList) overpower.ShipDat {
+func (s *Source) NewShip(fid, sid, size, power, turn int, path hexagon.CoordList) overpower.ShipDat {
 	sh := &Ship{
 		GID:      s.GID,
 		FID:      fid,
 		SID:      sid,
 		Size:     size,
+		Power:    power,
 		Launched: turn,
 		Path:     path,
 	}
@@ -190,12 +213,13 @@ func (s *Source) NewShipView(sh overpower.ShipDat, fid, turn int, loc, dest hexa
 		Controller: sh.FID(),
 		SID:        sh.SID(),
 		Size:       sh.Size(),
+		Power:      sh.Power(),
 	}
 	s.M.CreateShipView(sv)
 	return sv.Intf()
 }
 
-func (s *Source) NewLaunchRecord(turn int, o overpower.LaunchOrderDat, ship overpower.ShipDat) {
+func (s *Source) NewLaunchRecord(turn int, o overpower.LaunchOrderGet, ship overpower.ShipDat) {
 	lr := &LaunchRecord{
 		GID:       s.GID,
 		FID:       o.FID(),
@@ -209,11 +233,37 @@ func (s *Source) NewLaunchRecord(turn int, o overpower.LaunchOrderDat, ship over
 	}
 	s.M.CreateLaunchRecord(lr)
 }
+func (s *Source) NewConditionRecord(turn int, o overpower.ConditionalOrderDat, cause, size int) {
+	s.M.CreateConditionRecord(&ConditionRecord{
+		GID:    s.GID,
+		FID:    o.FID(),
+		Turn:   turn,
+		CID:    o.CID(),
+		Kind:   o.Kind(),
+		Loc:    o.Loc(),
+		Action: o.Action(),
+		Source: o.Source(),
+		Target: o.Target(),
+		Size:   size,
+		Power:  o.Power(),
+		Cause:  cause,
+	})
+}
+func (s *Source) NewTreatyBreak(turn, fid, partner, kind int) {
+	s.M.CreateTreatyBreak(&TreatyBreak{
+		GID:     s.GID,
+		Turn:    turn,
+		FID:     fid,
+		Partner: partner,
+		Kind:    kind,
+	})
+}
 func (s *Source) NewBattleRecord(ship overpower.ShipDat, fid, turn,
 	initPrimaryFac, initPrPres,
 	initSecondaryFac, initSePres int,
 	result overpower.PlanetDat,
 	betrayals [][2]int,
+	events []overpower.CombatEvent,
 ) {
 	btr := make([]int, 0, len(betrayals)*2)
 	for _, pt := range betrayals {
@@ -226,6 +276,7 @@ func (s *Source) NewBattleRecord(ship overpower.ShipDat, fid, turn,
 		Turn:      turn,
 		Loc:       result.Loc(),
 		Betrayals: btr,
+		Events:    overpower.EncodeCombatEvents(events),
 
 		InitPrimaryPresence:   initPrPres,
 		InitSecondaryPresence: initSePres,
@@ -253,12 +304,106 @@ func (s *Source) NewBattleRecord(ship overpower.ShipDat, fid, turn,
 	s.M.CreateBattleRecord(br)
 }
 
-func (s *Source) NewPowerOrder(fid int, planet overpower.PlanetDat) overpower.PowerOrderDat {
-	po := &PowerOrder{
-		GID: s.GID,
-		FID: fid,
-		Loc: planet.Loc(),
-	}
-	s.M.CreatePowerOrder(po)
-	return po.Intf()
+func (s *Source) NewStanding(fid, rank, planets, presence, ships int) overpower.StandingDat {
+	st := &Standing{
+		GID:      s.GID,
+		FID:      fid,
+		Rank:     rank,
+		Planets:  planets,
+		Presence: presence,
+		Ships:    ships,
+	}
+	s.M.CreateStanding(st)
+	return st.Intf()
+}
+
+func (s *Source) NewHomeReach(fid int, loc hexagon.Coord, reach, planets, antimatter, tachyons int) overpower.HomeReachDat {
+	hr := &HomeReach{
+		GID:        s.GID,
+		FID:        fid,
+		Loc:        loc,
+		Reach:      reach,
+		Planets:    planets,
+		Antimatter: antimatter,
+		Tachyons:   tachyons,
+	}
+	s.M.CreateHomeReach(hr)
+	return hr.Intf()
+}
+
+func (s *Source) ArchiveOrders(turn int, launches []overpower.LaunchOrderDat, shipOrders []overpower.ShipOrderDat, powers []overpower.PowerOrderDat, truces []overpower.TruceDat, treaties []overpower.TreatyDat, grants []overpower.VisionGrantDat, conditions []overpower.ConditionalOrderDat) {
+	for _, o := range launches {
+		s.M.CreateLaunchOrderArchive(&LaunchOrderArchive{
+			GID:      s.GID,
+			Turn:     turn,
+			FID:      o.FID(),
+			Source:   o.Source(),
+			Target:   o.Target(),
+			Size:     o.Size(),
+			Standing: overpower.IsStandingLaunch(o),
+		})
+	}
+	for _, o := range shipOrders {
+		s.M.CreateShipOrderArchive(&ShipOrderArchive{
+			GID:    s.GID,
+			Turn:   turn,
+			FID:    o.FID(),
+			SID:    o.SID(),
+			Target: o.Target(),
+			Merge:  o.Merge(),
+		})
+	}
+	for _, po := range powers {
+		s.M.CreatePowerOrderArchive(&PowerOrderArchive{
+			GID:     s.GID,
+			Turn:    turn,
+			FID:     po.FID(),
+			Loc:     po.Loc(),
+			UpPower: po.UpPower(),
+		})
+	}
+	for _, tr := range truces {
+		s.M.CreateTruceArchive(&TruceArchive{
+			GID:     s.GID,
+			Turn:    turn,
+			FID:     tr.FID(),
+			Loc:     tr.Loc(),
+			Trucee:  tr.Trucee(),
+			Hostile: tr.Hostile(),
+		})
+	}
+	for _, tr := range treaties {
+		s.M.CreateTreatyArchive(&TreatyArchive{
+			GID:      s.GID,
+			Turn:     turn,
+			FID:      tr.FID(),
+			Partner:  tr.Partner(),
+			Kind:     tr.Kind(),
+			Accepted: tr.Accepted(),
+		})
+	}
+	for _, vg := range grants {
+		s.M.CreateVisionGrantArchive(&VisionGrantArchive{
+			GID:     s.GID,
+			Turn:    turn,
+			FID:     vg.FID(),
+			Grantee: vg.Grantee(),
+		})
+	}
+	for _, o := range conditions {
+		s.M.CreateConditionalOrderArchive(&ConditionalOrderArchive{
+			GID:    s.GID,
+			Turn:   turn,
+			FID:    o.FID(),
+			CID:    o.CID(),
+			Kind:   o.Kind(),
+			Loc:    o.Loc(),
+			Within: o.Within(),
+			Action: o.Action(),
+			Source: o.Source(),
+			Target: o.Target(),
+			Size:   o.Size(),
+			Power:  o.Power(),
+		})
+	}
 }
@@ -0,0 +1,289 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type Standing struct {
+	GID      int `json:"gid"`
+	FID      int `json:"fid"`
+	Rank     int `json:"rank"`
+	Planets  int `json:"planets"`
+	Presence int `json:"presence"`
+	Ships    int `json:"ships"`
+	sql      gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewStanding() *Standing {
+	return &Standing{
+		//
+	}
+}
+
+type StandingIntf struct {
+	item *Standing
+}
+
+func (item *Standing) Intf() overpower.StandingDat {
+	return &StandingIntf{item}
+}
+
+func (i StandingIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *Standing) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "rank":
+		return item.Rank
+	case "planets":
+		return item.Planets
+	case "presence":
+		return item.Presence
+	case "ships":
+		return item.Ships
+	}
+	return nil
+}
+
+func (item *Standing) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "rank":
+		return &item.Rank
+	case "planets":
+		return &item.Planets
+	case "presence":
+		return &item.Presence
+	case "ships":
+		return &item.Ships
+	}
+	return nil
+}
+func (item *Standing) SQLTable() string {
+	return "standing"
+}
+
+func (i StandingIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i StandingIntf) UnmarshalJSON(data []byte) error {
+	i.item = &Standing{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i StandingIntf) GID() int {
+	return i.item.GID
+}
+
+func (i StandingIntf) FID() int {
+	return i.item.FID
+}
+
+func (i StandingIntf) Rank() int {
+	return i.item.Rank
+}
+
+func (i StandingIntf) Planets() int {
+	return i.item.Planets
+}
+
+func (i StandingIntf) Presence() int {
+	return i.item.Presence
+}
+
+func (i StandingIntf) Ships() int {
+	return i.item.Ships
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type StandingGroup struct {
+	List []*Standing
+}
+
+func NewStandingGroup() *StandingGroup {
+	return &StandingGroup{
+		List: []*Standing{},
+	}
+}
+
+func (item *Standing) SQLGroup() gp.SQLGrouper {
+	return NewStandingGroup()
+}
+
+func (group *StandingGroup) New() gp.SQLer {
+	item := NewStanding()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *StandingGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *StandingGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *StandingGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *StandingGroup) SQLTable() string {
+	return "standing"
+}
+
+func (group *StandingGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+	}
+}
+
+func (group *StandingGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"rank",
+		"planets",
+		"presence",
+		"ships",
+	}
+}
+
+func (group *StandingGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *StandingGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"rank",
+		"planets",
+		"presence",
+		"ships",
+	}
+}
+
+func (group *StandingGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type StandingSession struct {
+	*StandingGroup
+	*gp.Session
+}
+
+func NewStandingSession(d db.DBer) *StandingSession {
+	group := NewStandingGroup()
+	return &StandingSession{
+		StandingGroup: group,
+		Session:       gp.NewSession(group, d),
+	}
+}
+
+func (s *StandingSession) Select(conditions ...interface{}) ([]overpower.StandingDat, error) {
+	cur := len(s.StandingGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "Standing select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertStanding2Intf(s.StandingGroup.List[cur:]...), nil
+}
+
+func (s *StandingSession) SelectWhere(where sq.Condition) ([]overpower.StandingDat, error) {
+	cur := len(s.StandingGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "Standing SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertStanding2Intf(s.StandingGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertStanding2Struct(list ...overpower.StandingDat) ([]*Standing, error) {
+	mylist := make([]*Standing, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(StandingIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad Standing struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertStanding2Intf(list ...*Standing) []overpower.StandingDat {
+	converted := make([]overpower.StandingDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func StandingTableCreate(d db.DBer) error {
+	query := `create table standing(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	rank int NOT NULL,
+	planets int NOT NULL,
+	presence int NOT NULL,
+	ships int NOT NULL,
+	PRIMARY KEY(gid, fid)
+);`
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Standing table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func StandingTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS standing CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Standing table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,357 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type StandingOrder struct {
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Source  hexagon.Coord `json:"source"`
+	Target  hexagon.Coord `json:"target"`
+	Size    int           `json:"size"`
+	Percent int           `json:"percent"`
+	Turns   int           `json:"turns"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewStandingOrder() *StandingOrder {
+	return &StandingOrder{
+	//
+	}
+}
+
+type StandingOrderIntf struct {
+	item *StandingOrder
+}
+
+func (item *StandingOrder) Intf() overpower.StandingOrderDat {
+	return &StandingOrderIntf{item}
+}
+
+func (i StandingOrderIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *StandingOrder) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "sourcex":
+		return item.Source[0]
+	case "sourcey":
+		return item.Source[1]
+	case "targetx":
+		return item.Target[0]
+	case "targety":
+		return item.Target[1]
+	case "size":
+		return item.Size
+	case "percent":
+		return item.Percent
+	case "turns":
+		return item.Turns
+	}
+	return nil
+}
+
+func (item *StandingOrder) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "sourcex":
+		return &item.Source[0]
+	case "sourcey":
+		return &item.Source[1]
+	case "targetx":
+		return &item.Target[0]
+	case "targety":
+		return &item.Target[1]
+	case "size":
+		return &item.Size
+	case "percent":
+		return &item.Percent
+	case "turns":
+		return &item.Turns
+	}
+	return nil
+}
+func (item *StandingOrder) SQLTable() string {
+	return "standingorder"
+}
+
+func (i StandingOrderIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i StandingOrderIntf) UnmarshalJSON(data []byte) error {
+	i.item = &StandingOrder{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i StandingOrderIntf) GID() int {
+	return i.item.GID
+}
+
+func (i StandingOrderIntf) FID() int {
+	return i.item.FID
+}
+
+func (i StandingOrderIntf) Source() hexagon.Coord {
+	return i.item.Source
+}
+
+func (i StandingOrderIntf) Target() hexagon.Coord {
+	return i.item.Target
+}
+
+func (i StandingOrderIntf) Size() int {
+	return i.item.Size
+}
+
+func (i StandingOrderIntf) Percent() int {
+	return i.item.Percent
+}
+
+func (i StandingOrderIntf) Turns() int {
+	return i.item.Turns
+}
+
+func (i StandingOrderIntf) SetSize(x int) {
+	if i.item.Size == x {
+		return
+	}
+	i.item.Size = x
+	i.item.sql.UPDATE = true
+}
+
+func (i StandingOrderIntf) SetPercent(x int) {
+	if i.item.Percent == x {
+		return
+	}
+	i.item.Percent = x
+	i.item.sql.UPDATE = true
+}
+
+func (i StandingOrderIntf) SetTurns(x int) {
+	if i.item.Turns == x {
+		return
+	}
+	i.item.Turns = x
+	i.item.sql.UPDATE = true
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type StandingOrderGroup struct {
+	List []*StandingOrder
+}
+
+func NewStandingOrderGroup() *StandingOrderGroup {
+	return &StandingOrderGroup{
+		List: []*StandingOrder{},
+	}
+}
+
+func (item *StandingOrder) SQLGroup() gp.SQLGrouper {
+	return NewStandingOrderGroup()
+}
+
+func (group *StandingOrderGroup) New() gp.SQLer {
+	item := NewStandingOrder()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *StandingOrderGroup) UpdateList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *StandingOrderGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *StandingOrderGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *StandingOrderGroup) SQLTable() string {
+	return "standingorder"
+}
+
+func (group *StandingOrderGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+	}
+}
+
+func (group *StandingOrderGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"percent",
+		"turns",
+	}
+}
+
+func (group *StandingOrderGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *StandingOrderGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"sourcex",
+		"sourcey",
+		"targetx",
+		"targety",
+		"size",
+		"percent",
+		"turns",
+	}
+}
+
+func (group *StandingOrderGroup) UpdateCols() []string {
+	return []string{
+		"size",
+		"percent",
+		"turns",
+	}
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type StandingOrderSession struct {
+	*StandingOrderGroup
+	*gp.Session
+}
+
+func NewStandingOrderSession(d db.DBer) *StandingOrderSession {
+	group := NewStandingOrderGroup()
+	return &StandingOrderSession{
+		StandingOrderGroup: group,
+		Session:        gp.NewSession(group, d),
+	}
+}
+
+func (s *StandingOrderSession) Select(conditions ...interface{}) ([]overpower.StandingOrderDat, error) {
+	cur := len(s.StandingOrderGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "StandingOrder select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertStandingOrder2Intf(s.StandingOrderGroup.List[cur:]...), nil
+}
+
+func (s *StandingOrderSession) SelectWhere(where sq.Condition) ([]overpower.StandingOrderDat, error) {
+	cur := len(s.StandingOrderGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "StandingOrder SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertStandingOrder2Intf(s.StandingOrderGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertStandingOrder2Struct(list ...overpower.StandingOrderDat) ([]*StandingOrder, error) {
+	mylist := make([]*StandingOrder, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(StandingOrderIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad StandingOrder struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertStandingOrder2Intf(list ...*StandingOrder) []overpower.StandingOrderDat {
+	converted := make([]overpower.StandingOrderDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func StandingOrderTableCreate(d db.DBer) error {
+	query := `create table standingorder(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	sourcex integer NOT NULL,
+	sourcey integer NOT NULL,
+	targetx integer NOT NULL,
+	targety integer NOT NULL,
+	size integer NOT NULL,
+	percent integer NOT NULL,
+	turns integer NOT NULL,
+	FOREIGN KEY(gid, sourcex, sourcey) REFERENCES planet ON DELETE CASCADE,
+	FOREIGN KEY(gid, targetx, targety) REFERENCES planet ON DELETE CASCADE,
+	PRIMARY KEY(gid, fid, sourcex, sourcey, targetx, targety)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed StandingOrder table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func StandingOrderTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS standingorder CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed StandingOrder table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
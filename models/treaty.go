@@ -0,0 +1,304 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type Treaty struct {
+	GID      int  `json:"gid"`
+	FID      int  `json:"fid"`
+	Partner  int  `json:"partner"`
+	Kind     int  `json:"kind"`
+	Accepted bool `json:"accepted"`
+	sql      gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewTreaty() *Treaty {
+	return &Treaty{
+	//
+	}
+}
+
+type TreatyIntf struct {
+	item *Treaty
+}
+
+func (item *Treaty) Intf() overpower.TreatyDat {
+	return &TreatyIntf{item}
+}
+
+func (i TreatyIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *Treaty) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "partner":
+		return item.Partner
+	case "kind":
+		return item.Kind
+	case "accepted":
+		return item.Accepted
+	}
+	return nil
+}
+
+func (item *Treaty) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "partner":
+		return &item.Partner
+	case "kind":
+		return &item.Kind
+	case "accepted":
+		return &item.Accepted
+	}
+	return nil
+}
+func (item *Treaty) SQLTable() string {
+	return "treaty"
+}
+
+func (i TreatyIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i TreatyIntf) UnmarshalJSON(data []byte) error {
+	i.item = &Treaty{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i TreatyIntf) GID() int {
+	return i.item.GID
+}
+
+func (i TreatyIntf) FID() int {
+	return i.item.FID
+}
+
+func (i TreatyIntf) Partner() int {
+	return i.item.Partner
+}
+
+func (i TreatyIntf) Kind() int {
+	return i.item.Kind
+}
+
+func (i TreatyIntf) Accepted() bool {
+	return i.item.Accepted
+}
+
+func (i TreatyIntf) SetKind(x int) {
+	if i.item.Kind == x {
+		return
+	}
+	i.item.Kind = x
+	i.item.sql.UPDATE = true
+}
+
+func (i TreatyIntf) SetAccepted(x bool) {
+	if i.item.Accepted == x {
+		return
+	}
+	i.item.Accepted = x
+	i.item.sql.UPDATE = true
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type TreatyGroup struct {
+	List []*Treaty
+}
+
+func NewTreatyGroup() *TreatyGroup {
+	return &TreatyGroup{
+		List: []*Treaty{},
+	}
+}
+
+func (item *Treaty) SQLGroup() gp.SQLGrouper {
+	return NewTreatyGroup()
+}
+
+func (group *TreatyGroup) New() gp.SQLer {
+	item := NewTreaty()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *TreatyGroup) UpdateList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyGroup) SQLTable() string {
+	return "treaty"
+}
+
+func (group *TreatyGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"partner",
+	}
+}
+
+func (group *TreatyGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"partner",
+		"kind",
+		"accepted",
+	}
+}
+
+func (group *TreatyGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *TreatyGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"partner",
+		"kind",
+		"accepted",
+	}
+}
+
+func (group *TreatyGroup) UpdateCols() []string {
+	return []string{
+		"kind",
+		"accepted",
+	}
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type TreatySession struct {
+	*TreatyGroup
+	*gp.Session
+}
+
+func NewTreatySession(d db.DBer) *TreatySession {
+	group := NewTreatyGroup()
+	return &TreatySession{
+		TreatyGroup: group,
+		Session:     gp.NewSession(group, d),
+	}
+}
+
+func (s *TreatySession) Select(conditions ...interface{}) ([]overpower.TreatyDat, error) {
+	cur := len(s.TreatyGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "Treaty select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertTreaty2Intf(s.TreatyGroup.List[cur:]...), nil
+}
+
+func (s *TreatySession) SelectWhere(where sq.Condition) ([]overpower.TreatyDat, error) {
+	cur := len(s.TreatyGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "Treaty SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertTreaty2Intf(s.TreatyGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertTreaty2Struct(list ...overpower.TreatyDat) ([]*Treaty, error) {
+	mylist := make([]*Treaty, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(TreatyIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad Treaty struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertTreaty2Intf(list ...*Treaty) []overpower.TreatyDat {
+	converted := make([]overpower.TreatyDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func TreatyTableCreate(d db.DBer) error {
+	query := `create table treaty(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	partner integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	kind integer NOT NULL,
+	accepted boolean NOT NULL DEFAULT false,
+	PRIMARY KEY(gid, fid, partner)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Treaty table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func TreatyTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS treaty CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed Treaty table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type TreatyArchive struct {
+	GID      int  `json:"gid"`
+	Turn     int  `json:"turn"`
+	FID      int  `json:"fid"`
+	Partner  int  `json:"partner"`
+	Kind     int  `json:"kind"`
+	Accepted bool `json:"accepted"`
+	sql      gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewTreatyArchive() *TreatyArchive {
+	return &TreatyArchive{
+	//
+	}
+}
+
+func (item *TreatyArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "partner":
+		return item.Partner
+	case "kind":
+		return item.Kind
+	case "accepted":
+		return item.Accepted
+	}
+	return nil
+}
+
+func (item *TreatyArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "partner":
+		return &item.Partner
+	case "kind":
+		return &item.Kind
+	case "accepted":
+		return &item.Accepted
+	}
+	return nil
+}
+func (item *TreatyArchive) SQLTable() string {
+	return "treatyarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type TreatyArchiveGroup struct {
+	List []*TreatyArchive
+}
+
+func NewTreatyArchiveGroup() *TreatyArchiveGroup {
+	return &TreatyArchiveGroup{
+		List: []*TreatyArchive{},
+	}
+}
+
+func (item *TreatyArchive) SQLGroup() gp.SQLGrouper {
+	return NewTreatyArchiveGroup()
+}
+
+func (group *TreatyArchiveGroup) New() gp.SQLer {
+	item := NewTreatyArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *TreatyArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *TreatyArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyArchiveGroup) SQLTable() string {
+	return "treatyarchive"
+}
+
+func (group *TreatyArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+	}
+}
+
+func (group *TreatyArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+		"kind",
+		"accepted",
+	}
+}
+
+func (group *TreatyArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *TreatyArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+		"kind",
+		"accepted",
+	}
+}
+
+func (group *TreatyArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type TreatyArchiveSession struct {
+	*TreatyArchiveGroup
+	*gp.Session
+}
+
+func NewTreatyArchiveSession(d db.DBer) *TreatyArchiveSession {
+	group := NewTreatyArchiveGroup()
+	return &TreatyArchiveSession{
+		TreatyArchiveGroup: group,
+		Session:            gp.NewSession(group, d),
+	}
+}
+
+func (s *TreatyArchiveSession) Select(conditions ...interface{}) ([]*TreatyArchive, error) {
+	cur := len(s.TreatyArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "TreatyArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.TreatyArchiveGroup.List[cur:], nil
+}
+
+func (s *TreatyArchiveSession) SelectWhere(where sq.Condition) ([]*TreatyArchive, error) {
+	cur := len(s.TreatyArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "TreatyArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.TreatyArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func TreatyArchiveTableCreate(d db.DBer) error {
+	query := `create table treatyarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	partner integer NOT NULL,
+	kind integer NOT NULL,
+	accepted boolean NOT NULL,
+	PRIMARY KEY(gid, turn, fid, partner)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TreatyArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func TreatyArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS treatyarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TreatyArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
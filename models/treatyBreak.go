@@ -0,0 +1,280 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type TreatyBreak struct {
+	GID     int `json:"gid"`
+	Turn    int `json:"turn"`
+	FID     int `json:"fid"`
+	Partner int `json:"partner"`
+	Kind    int `json:"kind"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewTreatyBreak() *TreatyBreak {
+	return &TreatyBreak{
+	//
+	}
+}
+
+type TreatyBreakIntf struct {
+	item *TreatyBreak
+}
+
+func (item *TreatyBreak) Intf() overpower.TreatyBreakDat {
+	return &TreatyBreakIntf{item}
+}
+
+func (i TreatyBreakIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *TreatyBreak) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "partner":
+		return item.Partner
+	case "kind":
+		return item.Kind
+	}
+	return nil
+}
+
+func (item *TreatyBreak) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "partner":
+		return &item.Partner
+	case "kind":
+		return &item.Kind
+	}
+	return nil
+}
+func (item *TreatyBreak) SQLTable() string {
+	return "treatybreak"
+}
+
+func (i TreatyBreakIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i TreatyBreakIntf) UnmarshalJSON(data []byte) error {
+	i.item = &TreatyBreak{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i TreatyBreakIntf) GID() int {
+	return i.item.GID
+}
+
+func (i TreatyBreakIntf) Turn() int {
+	return i.item.Turn
+}
+
+func (i TreatyBreakIntf) FID() int {
+	return i.item.FID
+}
+
+func (i TreatyBreakIntf) Partner() int {
+	return i.item.Partner
+}
+
+func (i TreatyBreakIntf) Kind() int {
+	return i.item.Kind
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type TreatyBreakGroup struct {
+	List []*TreatyBreak
+}
+
+func NewTreatyBreakGroup() *TreatyBreakGroup {
+	return &TreatyBreakGroup{
+		List: []*TreatyBreak{},
+	}
+}
+
+func (item *TreatyBreak) SQLGroup() gp.SQLGrouper {
+	return NewTreatyBreakGroup()
+}
+
+func (group *TreatyBreakGroup) New() gp.SQLer {
+	item := NewTreatyBreak()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *TreatyBreakGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *TreatyBreakGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyBreakGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TreatyBreakGroup) SQLTable() string {
+	return "treatybreak"
+}
+
+func (group *TreatyBreakGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+	}
+}
+
+func (group *TreatyBreakGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+		"kind",
+	}
+}
+
+func (group *TreatyBreakGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *TreatyBreakGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"partner",
+		"kind",
+	}
+}
+
+func (group *TreatyBreakGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type TreatyBreakSession struct {
+	*TreatyBreakGroup
+	*gp.Session
+}
+
+func NewTreatyBreakSession(d db.DBer) *TreatyBreakSession {
+	group := NewTreatyBreakGroup()
+	return &TreatyBreakSession{
+		TreatyBreakGroup: group,
+		Session:          gp.NewSession(group, d),
+	}
+}
+
+func (s *TreatyBreakSession) Select(conditions ...interface{}) ([]overpower.TreatyBreakDat, error) {
+	cur := len(s.TreatyBreakGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "TreatyBreak select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertTreatyBreak2Intf(s.TreatyBreakGroup.List[cur:]...), nil
+}
+
+func (s *TreatyBreakSession) SelectWhere(where sq.Condition) ([]overpower.TreatyBreakDat, error) {
+	cur := len(s.TreatyBreakGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "TreatyBreak SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertTreatyBreak2Intf(s.TreatyBreakGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertTreatyBreak2Struct(list ...overpower.TreatyBreakDat) ([]*TreatyBreak, error) {
+	mylist := make([]*TreatyBreak, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(TreatyBreakIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad TreatyBreak struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertTreatyBreak2Intf(list ...*TreatyBreak) []overpower.TreatyBreakDat {
+	converted := make([]overpower.TreatyBreakDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func TreatyBreakTableCreate(d db.DBer) error {
+	query := `create table treatybreak(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	partner integer NOT NULL,
+	kind integer NOT NULL,
+	PRIMARY KEY(gid, turn, fid, partner)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TreatyBreak table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func TreatyBreakTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS treatybreak CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TreatyBreak table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
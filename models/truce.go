@@ -11,11 +11,12 @@ import (
 )
 
 type Truce struct {
-	GID    int           `json:"gid"`
-	FID    int           `json:"fid"`
-	Loc    hexagon.Coord `json:"loc"`
-	Trucee int           `json:"trucee"`
-	sql    gp.SQLStruct
+	GID     int           `json:"gid"`
+	FID     int           `json:"fid"`
+	Loc     hexagon.Coord `json:"loc"`
+	Trucee  int           `json:"trucee"`
+	Hostile bool          `json:"hostile"`
+	sql     gp.SQLStruct
 }
 
 // --------- BEGIN GENERIC METHODS ------------ //
@@ -50,6 +51,8 @@ func (item *Truce) SQLVal(name string) interface{} {
 		return item.Loc[1]
 	case "trucee":
 		return item.Trucee
+	case "hostile":
+		return item.Hostile
 	}
 	return nil
 }
@@ -66,6 +69,8 @@ func (item *Truce) SQLPtr(name string) interface{} {
 		return &item.Loc[1]
 	case "trucee":
 		return &item.Trucee
+	case "hostile":
+		return &item.Hostile
 	}
 	return nil
 }
@@ -97,6 +102,18 @@ func (i TruceIntf) Trucee() int {
 	return i.item.Trucee
 }
 
+func (i TruceIntf) Hostile() bool {
+	return i.item.Hostile
+}
+
+func (i TruceIntf) SetHostile(x bool) {
+	if i.item.Hostile == x {
+		return
+	}
+	i.item.Hostile = x
+	i.item.sql.UPDATE = true
+}
+
 // --------- END GENERIC METHODS ------------ //
 // --------- BEGIN CUSTOM METHODS ------------ //
 
@@ -124,7 +141,13 @@ func (group *TruceGroup) New() gp.SQLer {
 }
 
 func (group *TruceGroup) UpdateList() []gp.SQLer {
-	return nil
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.UPDATE && !item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
 }
 
 func (group *TruceGroup) InsertList() []gp.SQLer {
@@ -168,6 +191,7 @@ func (group *TruceGroup) InsertCols() []string {
 		"locx",
 		"locy",
 		"trucee",
+		"hostile",
 	}
 }
 
@@ -182,11 +206,14 @@ func (group *TruceGroup) SelectCols() []string {
 		"locx",
 		"locy",
 		"trucee",
+		"hostile",
 	}
 }
 
 func (group *TruceGroup) UpdateCols() []string {
-	return nil
+	return []string{
+		"hostile",
+	}
 }
 
 // --------- END GROUP ------------ //
@@ -255,6 +282,7 @@ func TruceTableCreate(d db.DBer) error {
 	locx int NOT NULL,
 	locy int NOT NULL,
 	trucee int NOT NULL REFERENCES faction ON DELETE CASCADE,
+	hostile boolean NOT NULL DEFAULT false,
 	FOREIGN KEY(gid, locx, locy) REFERENCES planet ON DELETE CASCADE,
 	PRIMARY KEY(gid, fid, locx, locy, trucee)
 );`
@@ -265,6 +293,13 @@ func TruceTableCreate(d db.DBer) error {
 	return nil
 }
 
+// TruceTableMigrate adds the columns a truce table made before them lacks.
+func TruceTableMigrate(d db.DBer) error {
+	return addColumns(d, "truce",
+		"hostile boolean NOT NULL DEFAULT false",
+	)
+}
+
 func TruceTableDelete(d db.DBer) error {
 	query := "DROP TABLE IF EXISTS truce CASCADE"
 	err := db.Exec(d, false, query)
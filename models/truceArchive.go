@@ -0,0 +1,232 @@
+package models
+
+import (
+	"mule/hexagon"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type TruceArchive struct {
+	GID     int           `json:"gid"`
+	Turn    int           `json:"turn"`
+	FID     int           `json:"fid"`
+	Loc     hexagon.Coord `json:"loc"`
+	Trucee  int           `json:"trucee"`
+	Hostile bool          `json:"hostile"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewTruceArchive() *TruceArchive {
+	return &TruceArchive{
+		//
+	}
+}
+
+func (item *TruceArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "locx":
+		return item.Loc[0]
+	case "locy":
+		return item.Loc[1]
+	case "trucee":
+		return item.Trucee
+	case "hostile":
+		return item.Hostile
+	}
+	return nil
+}
+
+func (item *TruceArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "locx":
+		return &item.Loc[0]
+	case "locy":
+		return &item.Loc[1]
+	case "trucee":
+		return &item.Trucee
+	case "hostile":
+		return &item.Hostile
+	}
+	return nil
+}
+func (item *TruceArchive) SQLTable() string {
+	return "trucearchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type TruceArchiveGroup struct {
+	List []*TruceArchive
+}
+
+func NewTruceArchiveGroup() *TruceArchiveGroup {
+	return &TruceArchiveGroup{
+		List: []*TruceArchive{},
+	}
+}
+
+func (item *TruceArchive) SQLGroup() gp.SQLGrouper {
+	return NewTruceArchiveGroup()
+}
+
+func (group *TruceArchiveGroup) New() gp.SQLer {
+	item := NewTruceArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *TruceArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *TruceArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TruceArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *TruceArchiveGroup) SQLTable() string {
+	return "trucearchive"
+}
+
+func (group *TruceArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+		"trucee",
+	}
+}
+
+func (group *TruceArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+		"trucee",
+		"hostile",
+	}
+}
+
+func (group *TruceArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *TruceArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"locx",
+		"locy",
+		"trucee",
+		"hostile",
+	}
+}
+
+func (group *TruceArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type TruceArchiveSession struct {
+	*TruceArchiveGroup
+	*gp.Session
+}
+
+func NewTruceArchiveSession(d db.DBer) *TruceArchiveSession {
+	group := NewTruceArchiveGroup()
+	return &TruceArchiveSession{
+		TruceArchiveGroup: group,
+		Session:           gp.NewSession(group, d),
+	}
+}
+
+func (s *TruceArchiveSession) Select(conditions ...interface{}) ([]*TruceArchive, error) {
+	cur := len(s.TruceArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "TruceArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.TruceArchiveGroup.List[cur:], nil
+}
+
+func (s *TruceArchiveSession) SelectWhere(where sq.Condition) ([]*TruceArchive, error) {
+	cur := len(s.TruceArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "TruceArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.TruceArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func TruceArchiveTableCreate(d db.DBer) error {
+	query := `create table trucearchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	locx int NOT NULL,
+	locy int NOT NULL,
+	trucee int NOT NULL,
+	hostile boolean NOT NULL DEFAULT false,
+	PRIMARY KEY(gid, turn, fid, locx, locy, trucee)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TruceArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func TruceArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS trucearchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed TruceArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
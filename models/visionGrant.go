@@ -0,0 +1,255 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+	"mule/overpower"
+)
+
+type VisionGrant struct {
+	GID     int `json:"gid"`
+	FID     int `json:"fid"`
+	Grantee int `json:"grantee"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewVisionGrant() *VisionGrant {
+	return &VisionGrant{
+	//
+	}
+}
+
+type VisionGrantIntf struct {
+	item *VisionGrant
+}
+
+func (item *VisionGrant) Intf() overpower.VisionGrantDat {
+	return &VisionGrantIntf{item}
+}
+
+func (i VisionGrantIntf) DELETE() {
+	i.item.sql.DELETE = true
+}
+
+func (item *VisionGrant) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "fid":
+		return item.FID
+	case "grantee":
+		return item.Grantee
+	}
+	return nil
+}
+
+func (item *VisionGrant) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "fid":
+		return &item.FID
+	case "grantee":
+		return &item.Grantee
+	}
+	return nil
+}
+func (item *VisionGrant) SQLTable() string {
+	return "visiongrant"
+}
+
+func (i VisionGrantIntf) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.item)
+}
+func (i VisionGrantIntf) UnmarshalJSON(data []byte) error {
+	i.item = &VisionGrant{}
+	return json.Unmarshal(data, i.item)
+}
+
+func (i VisionGrantIntf) GID() int {
+	return i.item.GID
+}
+
+func (i VisionGrantIntf) FID() int {
+	return i.item.FID
+}
+
+func (i VisionGrantIntf) Grantee() int {
+	return i.item.Grantee
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type VisionGrantGroup struct {
+	List []*VisionGrant
+}
+
+func NewVisionGrantGroup() *VisionGrantGroup {
+	return &VisionGrantGroup{
+		List: []*VisionGrant{},
+	}
+}
+
+func (item *VisionGrant) SQLGroup() gp.SQLGrouper {
+	return NewVisionGrantGroup()
+}
+
+func (group *VisionGrantGroup) New() gp.SQLer {
+	item := NewVisionGrant()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *VisionGrantGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *VisionGrantGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *VisionGrantGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *VisionGrantGroup) SQLTable() string {
+	return "visiongrant"
+}
+
+func (group *VisionGrantGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *VisionGrantGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type VisionGrantSession struct {
+	*VisionGrantGroup
+	*gp.Session
+}
+
+func NewVisionGrantSession(d db.DBer) *VisionGrantSession {
+	group := NewVisionGrantGroup()
+	return &VisionGrantSession{
+		VisionGrantGroup: group,
+		Session:          gp.NewSession(group, d),
+	}
+}
+
+func (s *VisionGrantSession) Select(conditions ...interface{}) ([]overpower.VisionGrantDat, error) {
+	cur := len(s.VisionGrantGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "VisionGrant select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return convertVisionGrant2Intf(s.VisionGrantGroup.List[cur:]...), nil
+}
+
+func (s *VisionGrantSession) SelectWhere(where sq.Condition) ([]overpower.VisionGrantDat, error) {
+	cur := len(s.VisionGrantGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "VisionGrant SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return convertVisionGrant2Intf(s.VisionGrantGroup.List[cur:]...), nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func convertVisionGrant2Struct(list ...overpower.VisionGrantDat) ([]*VisionGrant, error) {
+	mylist := make([]*VisionGrant, 0, len(list))
+	for _, test := range list {
+		if test == nil {
+			continue
+		}
+		if t, ok := test.(VisionGrantIntf); ok {
+			mylist = append(mylist, t.item)
+		} else {
+			return nil, errors.New("bad VisionGrant struct type for conversion")
+		}
+	}
+	return mylist, nil
+}
+
+func convertVisionGrant2Intf(list ...*VisionGrant) []overpower.VisionGrantDat {
+	converted := make([]overpower.VisionGrantDat, len(list))
+	for i, item := range list {
+		converted[i] = item.Intf()
+	}
+	return converted
+}
+
+func VisionGrantTableCreate(d db.DBer) error {
+	query := `create table visiongrant(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	fid integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	grantee integer NOT NULL REFERENCES faction ON DELETE CASCADE,
+	PRIMARY KEY(gid, fid, grantee)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed VisionGrant table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func VisionGrantTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS visiongrant CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed VisionGrant table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
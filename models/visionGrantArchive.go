@@ -0,0 +1,206 @@
+package models
+
+import (
+	"mule/mydb/db"
+	gp "mule/mydb/group"
+	sq "mule/mydb/sql"
+)
+
+type VisionGrantArchive struct {
+	GID     int `json:"gid"`
+	Turn    int `json:"turn"`
+	FID     int `json:"fid"`
+	Grantee int `json:"grantee"`
+	sql     gp.SQLStruct
+}
+
+// --------- BEGIN GENERIC METHODS ------------ //
+
+func NewVisionGrantArchive() *VisionGrantArchive {
+	return &VisionGrantArchive{
+	//
+	}
+}
+
+func (item *VisionGrantArchive) SQLVal(name string) interface{} {
+	switch name {
+	case "gid":
+		return item.GID
+	case "turn":
+		return item.Turn
+	case "fid":
+		return item.FID
+	case "grantee":
+		return item.Grantee
+	}
+	return nil
+}
+
+func (item *VisionGrantArchive) SQLPtr(name string) interface{} {
+	switch name {
+	case "gid":
+		return &item.GID
+	case "turn":
+		return &item.Turn
+	case "fid":
+		return &item.FID
+	case "grantee":
+		return &item.Grantee
+	}
+	return nil
+}
+func (item *VisionGrantArchive) SQLTable() string {
+	return "visiongrantarchive"
+}
+
+// --------- END GENERIC METHODS ------------ //
+// --------- BEGIN CUSTOM METHODS ------------ //
+
+// --------- END CUSTOM METHODS ------------ //
+// --------- BEGIN GROUP ------------ //
+
+type VisionGrantArchiveGroup struct {
+	List []*VisionGrantArchive
+}
+
+func NewVisionGrantArchiveGroup() *VisionGrantArchiveGroup {
+	return &VisionGrantArchiveGroup{
+		List: []*VisionGrantArchive{},
+	}
+}
+
+func (item *VisionGrantArchive) SQLGroup() gp.SQLGrouper {
+	return NewVisionGrantArchiveGroup()
+}
+
+func (group *VisionGrantArchiveGroup) New() gp.SQLer {
+	item := NewVisionGrantArchive()
+	group.List = append(group.List, item)
+	return item
+}
+
+func (group *VisionGrantArchiveGroup) UpdateList() []gp.SQLer {
+	return nil
+}
+
+func (group *VisionGrantArchiveGroup) InsertList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.INSERT && !item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *VisionGrantArchiveGroup) DeleteList() []gp.SQLer {
+	list := make([]gp.SQLer, 0, len(group.List))
+	for _, item := range group.List {
+		if item.sql.DELETE {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (group *VisionGrantArchiveGroup) SQLTable() string {
+	return "visiongrantarchive"
+}
+
+func (group *VisionGrantArchiveGroup) PKCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantArchiveGroup) InsertCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantArchiveGroup) InsertScanCols() []string {
+	return []string{}
+}
+
+func (group *VisionGrantArchiveGroup) SelectCols() []string {
+	return []string{
+		"gid",
+		"turn",
+		"fid",
+		"grantee",
+	}
+}
+
+func (group *VisionGrantArchiveGroup) UpdateCols() []string {
+	return nil
+}
+
+// --------- END GROUP ------------ //
+// --------- BEGIN SESSION ------------ //
+type VisionGrantArchiveSession struct {
+	*VisionGrantArchiveGroup
+	*gp.Session
+}
+
+func NewVisionGrantArchiveSession(d db.DBer) *VisionGrantArchiveSession {
+	group := NewVisionGrantArchiveGroup()
+	return &VisionGrantArchiveSession{
+		VisionGrantArchiveGroup: group,
+		Session:                 gp.NewSession(group, d),
+	}
+}
+
+func (s *VisionGrantArchiveSession) Select(conditions ...interface{}) ([]*VisionGrantArchive, error) {
+	cur := len(s.VisionGrantArchiveGroup.List)
+	err := s.Session.Select(conditions...)
+	if my, bad := Check(err, "VisionGrantArchive select failed", "conditions", conditions); bad {
+		return nil, my
+	}
+	return s.VisionGrantArchiveGroup.List[cur:], nil
+}
+
+func (s *VisionGrantArchiveSession) SelectWhere(where sq.Condition) ([]*VisionGrantArchive, error) {
+	cur := len(s.VisionGrantArchiveGroup.List)
+	err := s.Session.SelectWhere(where)
+	if my, bad := Check(err, "VisionGrantArchive SelectWhere failed", "where", where); bad {
+		return nil, my
+	}
+	return s.VisionGrantArchiveGroup.List[cur:], nil
+}
+
+// --------- END SESSION  ------------ //
+// --------- BEGIN UTILS ------------ //
+
+func VisionGrantArchiveTableCreate(d db.DBer) error {
+	query := `create table visiongrantarchive(
+	gid integer NOT NULL REFERENCES game ON DELETE CASCADE,
+	turn integer NOT NULL,
+	fid integer NOT NULL,
+	grantee integer NOT NULL,
+	PRIMARY KEY(gid, turn, fid, grantee)
+);`
+
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed VisionGrantArchive table creation", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+func VisionGrantArchiveTableDelete(d db.DBer) error {
+	query := "DROP TABLE IF EXISTS visiongrantarchive CASCADE"
+	err := db.Exec(d, false, query)
+	if my, bad := Check(err, "failed VisionGrantArchive table deletion", "query", query); bad {
+		return my
+	}
+	return nil
+}
+
+// --------- END UTILS ------------ //
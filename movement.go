@@ -4,18 +4,20 @@ import (
 	"mule/hexagon"
 )
 
-func Travelled(sh ShipDat, turn int) (travelled []hexagon.Coord, land bool) {
+// Travelled gives the stretch of path a ship moving speed hexes a turn
+// covers on the given turn, and whether it lands at the end of it.
+func Travelled(sh ShipDat, turn, speed int) (travelled []hexagon.Coord, land bool) {
 	l := sh.Launched()
 	if l > turn {
 		return []hexagon.Coord{}, false
 	}
 	path := sh.Path()
 	// 0 -- 10 -- 20
-	start := SHIPSPEED * (turn - l)
+	start := speed * (turn - l)
 	if start+1 > len(path) {
 		return []hexagon.Coord{}, false
 	}
-	end := start + SHIPSPEED + 1
+	end := start + speed + 1
 	if end >= len(path) {
 		end = len(path)
 		land = true
@@ -23,14 +25,16 @@ func Travelled(sh ShipDat, turn int) (travelled []hexagon.Coord, land bool) {
 	return path[start:end], land
 }
 
-func RadarCheck(rList, travelled []hexagon.Coord) (spotted []hexagon.Coord, spottedShip bool) {
+// RadarCheck gives the parts of travelled within range of any sensor, and
+// whether the last of them was seen.
+func RadarCheck(rList []Sensor, travelled []hexagon.Coord) (spotted []hexagon.Coord, spottedShip bool) {
 	if len(rList) < 1 || len(travelled) < 1 {
 		return nil, false
 	}
 	spotted = make([]hexagon.Coord, 0, len(travelled))
 	for i, c := range travelled {
-		for _, c2 := range rList {
-			if c.StepsTo(c2) <= VISDIST {
+		for _, sn := range rList {
+			if c.StepsTo(sn.Loc) <= sn.Range {
 				spotted = append(spotted, c)
 				if i == len(travelled)-1 {
 					spottedShip = true
@@ -0,0 +1,181 @@
+package overpower
+
+import (
+	"mule/hexagon"
+)
+
+// Sensor is a place radar sees from and how many hexes it sees.
+type Sensor struct {
+	Loc   hexagon.Coord
+	Range int
+}
+
+// dimmedRadar gives radar seeing only percent of its range, as it does
+// stealthy ships.
+func dimmedRadar(radar map[int][]Sensor, percent int) map[int][]Sensor {
+	dimmed := make(map[int][]Sensor, len(radar))
+	for fid, list := range radar {
+		dim := make([]Sensor, len(list))
+		for i, sn := range list {
+			dim[i] = Sensor{sn.Loc, sn.Range * percent / 100}
+		}
+		dimmed[fid] = dim
+	}
+	return dimmed
+}
+
+// RadarIndex holds, for each faction, a bitmap of the hexes its radar
+// sees within the box around all the radar, so that asking whether a
+// faction sees a hex is a single bit test rather than a walk over every
+// sensor.
+type RadarIndex struct {
+	fids   []int
+	layer  map[int][]uint64
+	minX   int
+	minY   int
+	width  int
+	height int
+	// words per column of the box
+	stride int
+}
+
+// NewRadarIndex marks the hexes within range of each faction's sensors.
+func NewRadarIndex(radar map[int][]Sensor) *RadarIndex {
+	ri := &RadarIndex{
+		fids:  sortedFids(radar),
+		layer: make(map[int][]uint64, len(radar)),
+	}
+	var maxX, maxY int
+	first := true
+	for _, list := range radar {
+		for _, sn := range list {
+			loc, r := sn.Loc, sensorRange(sn)
+			if first || loc[0]-r < ri.minX {
+				ri.minX = loc[0] - r
+			}
+			if first || loc[1]-r < ri.minY {
+				ri.minY = loc[1] - r
+			}
+			if first || loc[0]+r > maxX {
+				maxX = loc[0] + r
+			}
+			if first || loc[1]+r > maxY {
+				maxY = loc[1] + r
+			}
+			first = false
+		}
+	}
+	if first {
+		return ri
+	}
+	ri.width = maxX - ri.minX + 1
+	ri.height = maxY - ri.minY + 1
+	ri.stride = (ri.height + 63) / 64
+	spanCache := map[int][][2]int{}
+	for _, fid := range ri.fids {
+		bits := make([]uint64, ri.width*ri.stride)
+		for _, sn := range radar[fid] {
+			loc, r := sn.Loc, sensorRange(sn)
+			spans, ok := spanCache[r]
+			if !ok {
+				spans = sightSpans(r)
+				spanCache[r] = spans
+			}
+			for i, span := range spans {
+				col := bits[(loc[0]-r+i-ri.minX)*ri.stride:]
+				setBits(col, loc[1]+span[0]-ri.minY, loc[1]+span[1]-ri.minY)
+			}
+		}
+		ri.layer[fid] = bits
+	}
+	return ri
+}
+
+func sensorRange(sn Sensor) int {
+	if sn.Range < 0 {
+		return 0
+	}
+	return sn.Range
+}
+
+// sightSpans gives the hexes within dist of the origin, as a span of dy
+// for each dx from -dist.
+func sightSpans(dist int) [][2]int {
+	var origin hexagon.Coord
+	spans := make([][2]int, 0, 2*dist+1)
+	for dx := -dist; dx <= dist; dx++ {
+		lo, hi := dist+1, -dist-1
+		for dy := -dist; dy <= dist; dy++ {
+			if origin.StepsTo(hexagon.Coord{dx, dy}) <= dist {
+				if dy < lo {
+					lo = dy
+				}
+				hi = dy
+			}
+		}
+		spans = append(spans, [2]int{lo, hi})
+	}
+	return spans
+}
+
+// setBits sets bits lo through hi of bits.
+func setBits(bits []uint64, lo, hi int) {
+	for lo <= hi {
+		w, b := lo/64, uint(lo%64)
+		n := hi - lo + 1
+		if n >= 64-int(b) {
+			bits[w] |= ^uint64(0) << b
+			lo += 64 - int(b)
+		} else {
+			bits[w] |= (uint64(1)<<uint(n) - 1) << b
+			lo += n
+		}
+	}
+}
+
+func (ri *RadarIndex) sees(bits []uint64, c hexagon.Coord) bool {
+	x, y := c[0]-ri.minX, c[1]-ri.minY
+	if x < 0 || y < 0 || x >= ri.width || y >= ri.height {
+		return false
+	}
+	return bits[x*ri.stride+y/64]&(uint64(1)<<uint(y%64)) != 0
+}
+
+// Seers gives the factions that see c, in FID order.
+func (ri *RadarIndex) Seers(c hexagon.Coord) []int {
+	var list []int
+	for _, fid := range ri.fids {
+		if ri.sees(ri.layer[fid], c) {
+			list = append(list, fid)
+		}
+	}
+	return list
+}
+
+// Sees reports whether fid sees c.
+func (ri *RadarIndex) Sees(fid int, c hexagon.Coord) bool {
+	bits, ok := ri.layer[fid]
+	return ok && ri.sees(bits, c)
+}
+
+// Check is RadarCheck for fid's radar: the parts of travelled fid sees,
+// and whether the last of them was seen.
+func (ri *RadarIndex) Check(fid int, travelled []hexagon.Coord) (spotted []hexagon.Coord, spottedShip bool) {
+	bits, ok := ri.layer[fid]
+	if !ok {
+		return nil, false
+	}
+	for i, c := range travelled {
+		if !ri.sees(bits, c) {
+			continue
+		}
+		if spotted == nil {
+			spotted = make([]hexagon.Coord, 0, len(travelled)-i)
+		}
+		spotted = append(spotted, c)
+		if i == len(travelled)-1 {
+			spottedShip = true
+		}
+	}
+	return
+}
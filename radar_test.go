@@ -0,0 +1,156 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestRadarRanges(t *testing.T) {
+	rules := allMechanics()
+	pl := &memsource.Planet{
+		PrimaryFaction: 1, PrimaryPresence: 5, PrimaryPower: overpower.ANTIMATTER,
+		SecondaryFaction: 2, SecondaryPresence: 5, SecondaryPower: overpower.TACHYONS,
+	}
+	if got, want := rules.RadarOf(pl.Intf()), rules.VisionRadius+10*rules.RadarPresence/100+rules.TachyonRadar; got != want {
+		t.Errorf("shared tachyon planet has radar %d, want %d", got, want)
+	}
+	if got := rules.RadarOf((&memsource.Planet{}).Intf()); got != 0 {
+		t.Errorf("unheld planet has radar %d", got)
+	}
+	s := makeRulesGalaxy(t, rules, 2, 1, "standard")
+	home := s.HomePlanet(1)
+	if got, want := home.Radar, rules.RadarOf(home.Intf()); got != want {
+		t.Errorf("new home has radar %d, want %d", got, want)
+	}
+	for _, pv := range s.PlanetViewList {
+		if pv.FID == 1 && pv.Loc == home.Loc && pv.Radar != home.Radar {
+			t.Errorf("home's view reports radar %d, want %d", pv.Radar, home.Radar)
+		}
+	}
+	// the radar stored and shown is the planet's at the turn's end, after
+	// it has turned to tachyons
+	home.PrimaryPower = overpower.ANTIMATTER
+	s.AddPowerOrder(1, overpower.TACHYONS, home.Loc)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if got, want := home.Radar, rules.RadarOf(home.Intf()); got != want || home.PrimaryPower != overpower.TACHYONS {
+		t.Errorf("home turned to tachyons has radar %d, want %d", got, want)
+	}
+	for _, pv := range s.PlanetViewList {
+		if pv.FID == 1 && pv.Loc == home.Loc && pv.Radar != home.Radar {
+			t.Errorf("home's view reports radar %d after the turn, want %d", pv.Radar, home.Radar)
+		}
+	}
+	// faction 1 watches faction 2's home from a lone planet just far
+	// enough off that only its dimmed radar misses the launch
+	seen := func(size int) bool {
+		s := makeRulesGalaxy(t, rules, 2, 1, "standard")
+		from := s.HomePlanet(2)
+		var far *memsource.Planet
+		for _, pl := range s.PlanetList {
+			if pl.PrimaryFaction == 0 && (far == nil || from.Loc.StepsTo(pl.Loc) > from.Loc.StepsTo(far.Loc)) {
+				far = pl
+			}
+		}
+		rules := &s.GameItem.Rules
+		dist := from.Loc.StepsTo(far.Loc)
+		if dist < 15 {
+			t.Fatalf("farthest planet from faction 2's home is only %d hexes off", dist)
+		}
+		rules.VisionRadius = dist + rules.MaxSpeed()
+		rules.RadarPresence, rules.TachyonRadar = 0, 0
+		rules.StealthSize, rules.StealthRange = 3, 10
+		s.HomePlanet(1).PrimaryFaction = 0
+		far.PrimaryFaction, far.PrimaryPresence = 1, 1
+		s.AddLaunchOrder(2, size, from.Loc, nearestFree(s, from.Loc).Loc)
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+		if far.Radar != rules.VisionRadius {
+			t.Errorf("watching planet has radar %d, want %d", far.Radar, rules.VisionRadius)
+		}
+		for _, sv := range s.ShipViewList {
+			if sv.FID == 1 && sv.Controller == 2 {
+				return true
+			}
+		}
+		return false
+	}
+	if !seen(5) {
+		t.Error("large ship unseen within radar range")
+	}
+	if seen(2) {
+		t.Error("stealthy ship seen beyond its dimmed range")
+	}
+}
+
+// lateGame gives the radar of an eight player galaxy with every planet
+// held, and a turn's travel for a ship launched from each planet.
+func lateGame(tb testing.TB) (map[int][]overpower.Sensor, [][]hexagon.Coord) {
+	s := makeTestGalaxy(tb, 8)
+	radar := map[int][]overpower.Sensor{}
+	for i, pl := range s.PlanetList {
+		fid := 1 + i%8
+		radar[fid] = append(radar[fid], overpower.Sensor{pl.Loc, overpower.VISDIST - 2 + i%5})
+	}
+	var paths [][]hexagon.Coord
+	n := len(s.PlanetList)
+	for i, pl := range s.PlanetList {
+		path := pl.Loc.PathTo(s.PlanetList[(i*7+3)%n].Loc)
+		if len(path) > 11 {
+			path = path[:11]
+		}
+		paths = append(paths, path)
+	}
+	return radar, paths
+}
+
+func TestRadarIndex(t *testing.T) {
+	radar, paths := lateGame(t)
+	index := overpower.NewRadarIndex(radar)
+	for fid, rList := range radar {
+		for _, path := range paths {
+			want, wantShip := overpower.RadarCheck(rList, path)
+			got, gotShip := index.Check(fid, path)
+			if len(got) != len(want) || gotShip != wantShip {
+				t.Fatalf("faction %d sees %v (ship %v) of %v, want %v (ship %v)", fid, got, gotShip, path, want, wantShip)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("faction %d sees %v of %v, want %v", fid, got, path, want)
+				}
+			}
+		}
+	}
+	if index.Sees(9, paths[0][0]) || len(index.Seers(hexagon.Coord{1000, 1000})) != 0 {
+		t.Error("index sees for factions or places no radar covers")
+	}
+}
+
+func BenchmarkRadarCheck(b *testing.B) {
+	radar, paths := lateGame(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, path := range paths {
+			for fid := 1; fid <= 8; fid++ {
+				overpower.RadarCheck(radar[fid], path)
+			}
+		}
+	}
+}
+
+func BenchmarkRadarIndex(b *testing.B) {
+	radar, paths := lateGame(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		index := overpower.NewRadarIndex(radar)
+		for _, path := range paths {
+			for fid := 1; fid <= 8; fid++ {
+				index.Check(fid, path)
+			}
+		}
+	}
+}
@@ -4,7 +4,18 @@ import (
 	"math/rand"
 )
 
-func shuffleInts(list []int) []int {
+// NewRand gives the random source for everything seeded from seed.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// TurnRand gives the random source used to resolve the given turn of a game
+// with the given seed, so that any turn can be rerun in isolation.
+func TurnRand(seed int64, turn int) *rand.Rand {
+	return NewRand(seed ^ (int64(turn) * 0x5DEECE66D))
+}
+
+func shuffleInts(rng *rand.Rand, list []int) []int {
 	if list == nil {
 		return make([]int, 0)
 	}
@@ -13,21 +24,21 @@ func shuffleInts(list []int) []int {
 		return list
 	}
 	newList := make([]int, l)
-	newOrder := rand.Perm(l)
+	newOrder := rng.Perm(l)
 	for i, val := range newOrder {
 		newList[val] = list[i]
 	}
 	return newList
 }
 
-func pick(n int) int {
-	return rand.Intn(n) + 1
+func pick(rng *rand.Rand, n int) int {
+	return rng.Intn(n) + 1
 }
 
-func coin() bool {
-	return rand.Intn(2) == 0
+func coin(rng *rand.Rand) bool {
+	return rng.Intn(2) == 0
 }
 
-func RandF(x float64) float64 {
-	return rand.Float64() * x
+func RandF(rng *rand.Rand, x float64) float64 {
+	return rng.Float64() * x
 }
@@ -0,0 +1,82 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"reflect"
+	"testing"
+)
+
+// expand has each faction still playing send ships from home to the
+// nearest free planet for the given number of turns.
+func expand(t *testing.T, s *memsource.Source, turns int) {
+	for turn := 0; turn < turns; turn++ {
+		for _, f := range s.FactionList {
+			if f.Deleted {
+				continue
+			}
+			home := s.HomePlanet(f.FID)
+			if home == nil {
+				continue
+			}
+			if target := nearestFree(s, home.Loc); target != nil {
+				s.AddLaunchOrder(f.FID, 2, home.Loc, target.Loc)
+			}
+		}
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	s := makeGalaxy(t, 3, 7, "standard")
+	expand(t, s, 10)
+	if got := len(s.Archive); got != 10 {
+		t.Fatalf("archived %d turns, want 10", got)
+	}
+	game, _ := s.Game()
+	factions, _ := s.Factions()
+	planets, _ := s.Planets()
+	ships, _ := s.Ships()
+	r, err := memsource.Replay(game, factions, s.Archive)
+	if err != nil {
+		t.Fatal("replay failed:", err)
+	}
+	if diffs := r.Diff(planets, ships); len(diffs) != 0 {
+		t.Errorf("replay diverged: %v", diffs)
+	}
+	s.PlanetList[0].Antimatter += 1
+	if diffs := r.Diff(planets, ships); len(diffs) != 1 {
+		t.Errorf("altered planet gave diffs %v, want one", diffs)
+	}
+	if _, err := memsource.Replay(game, factions, s.Archive[1:]); err == nil {
+		t.Error("replay with a missing turn succeeded")
+	}
+}
+
+func TestReplayQuit(t *testing.T) {
+	s := makeGalaxy(t, 3, 7, "standard")
+	expand(t, s, 4)
+	quitter := s.FactionList[0].FID
+	overpower.RecordQuit(s.GameItem.Intf(), quitter)
+	s.Quit(quitter)
+	expand(t, s, 4)
+	game, _ := s.Game()
+	factions, _ := s.Factions()
+	if len(factions) != 2 {
+		t.Fatalf("%d factions after a quit, want 2", len(factions))
+	}
+	planets, _ := s.Planets()
+	ships, _ := s.Ships()
+	r, err := memsource.Replay(game, factions, s.Archive)
+	if err != nil {
+		t.Fatal("replay failed:", err)
+	}
+	if diffs := r.Diff(planets, ships); len(diffs) != 0 {
+		t.Errorf("replay after a quit diverged: %v", diffs)
+	}
+	if got := r.GameItem.Quits; !reflect.DeepEqual(got, game.Quits()) {
+		t.Errorf("replay quits %v, want %v", got, game.Quits())
+	}
+}
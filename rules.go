@@ -0,0 +1,203 @@
+package overpower
+
+import (
+	"fmt"
+)
+
+// GameRules are the settings a game is played under, fixed when the game
+// is created.
+type GameRules struct {
+	ShipSpeed       int `json:"shipspeed"`
+	VisionRadius    int `json:"visionradius"`
+	BigPerPlayer    int `json:"bigperplayer"`
+	LittlePerPlayer int `json:"littleperplayer"`
+	HomePresence    int `json:"homepresence"`
+	HomeAntimatter  int `json:"homeantimatter"`
+	HomeTachyons    int `json:"hometachyons"`
+	// ResourceCap limits the antimatter and tachyons any one planet may
+	// hold; 0 means no limit.
+	ResourceCap int `json:"resourcecap"`
+	// FairTurns is how many turns of flight from a home count as within
+	// its reach when a new galaxy's fairness is judged.
+	FairTurns int `json:"fairturns"`
+	// FairTolerance is the widest spread, in percent, allowed between the
+	// homes' reaches before a new galaxy is rerolled; 0 accepts any galaxy.
+	FairTolerance int `json:"fairtolerance"`
+	// CombatMode decides how ships landing on one planet at the same step
+	// fight: SEQUENTIAL lands them one by one in random order, SIMULTANEOUS
+	// has them all fight at once.
+	CombatMode int `json:"combatmode"`
+	// PresenceGrowth is the presence each occupant of a planet gains a
+	// turn, paying one of its power's resource for each, while the
+	// planet's presence is below its size.
+	PresenceGrowth int `json:"presencegrowth"`
+	// ResourceGrowth is the percent of a planet's size each of its
+	// resources regrows a turn, up to its size; a resource an occupant is
+	// aligned to regrows twice as fast.
+	ResourceGrowth int `json:"resourcegrowth"`
+	// TachyonSpeed is how many hexes a turn faster than ShipSpeed a ship
+	// launched on tachyons flies.
+	TachyonSpeed int `json:"tachyonspeed"`
+	// AntimatterStrike is the percent of its size an antimatter ship kills
+	// of the hostile presence on a planet as it lands, before the
+	// defenders fight back.
+	AntimatterStrike int `json:"antimatterstrike"`
+	// RadarPresence is the percent of the presence on a planet added to
+	// its radar range beyond VisionRadius.
+	RadarPresence int `json:"radarpresence"`
+	// TachyonRadar is how many hexes further a planet's radar reaches
+	// while an occupant is attuned to tachyons.
+	TachyonRadar int `json:"tachyonradar"`
+	// StealthSize is the size up to which ships are stealthy, seen only
+	// within StealthRange percent of a radar's range; 0 means no ship is.
+	StealthSize  int `json:"stealthsize"`
+	StealthRange int `json:"stealthrange"`
+}
+
+// Combat modes.
+const (
+	SEQUENTIAL   = 0
+	SIMULTANEOUS = 1
+)
+
+// DefaultRules gives the standard game: fair galaxies, with combat and
+// every later mechanic playing as they did before they were rules.  Games
+// opt in to the rest on the new game form.
+func DefaultRules() GameRules {
+	return GameRules{
+		ShipSpeed:        SHIPSPEED,
+		VisionRadius:     VISDIST,
+		BigPerPlayer:     3,
+		LittlePerPlayer:  12,
+		HomePresence:     5,
+		HomeAntimatter:   10,
+		HomeTachyons:     10,
+		ResourceCap:      0,
+		FairTurns:        2,
+		FairTolerance:    40,
+		CombatMode:       SEQUENTIAL,
+		PresenceGrowth:   0,
+		ResourceGrowth:   0,
+		TachyonSpeed:     0,
+		AntimatterStrike: 0,
+		RadarPresence:    0,
+		TachyonRadar:     0,
+		StealthSize:      0,
+		StealthRange:     0,
+	}
+}
+
+// PlanetsPerPlayer counts the planets a galaxy made under these rules holds
+// for each player, including their home.
+func (r GameRules) PlanetsPerPlayer() int {
+	return r.BigPerPlayer + r.LittlePerPlayer + 1
+}
+
+// RuleField is one rule as the new game form, the game table and
+// Validate know it.
+type RuleField struct {
+	// Name is the rule's form field and game column.
+	Name     string
+	Desc     string
+	Min, Max int
+	// Before is the value games made before the rule existed play by, and
+	// so the column's default.
+	Before int
+	field  func(*GameRules) *int
+}
+
+// Of gives the rule's place in r.
+func (f RuleField) Of(r *GameRules) *int {
+	return f.field(r)
+}
+
+// RuleFields lists every rule.  A new rule needs only its GameRules
+// field, its DefaultRules value, an entry here and its input on the new
+// game form.
+var RuleFields = []RuleField{
+	{"shipspeed", "ship speed", 1, 50, 10, func(r *GameRules) *int { return &r.ShipSpeed }},
+	{"visionradius", "vision radius", 0, 100, 20, func(r *GameRules) *int { return &r.VisionRadius }},
+	{"bigperplayer", "inner planets per player", 0, 10, 3, func(r *GameRules) *int { return &r.BigPerPlayer }},
+	{"littleperplayer", "outer planets per player", 0, 30, 12, func(r *GameRules) *int { return &r.LittlePerPlayer }},
+	{"homepresence", "home presence", 1, 20, 5, func(r *GameRules) *int { return &r.HomePresence }},
+	{"homeantimatter", "home antimatter", 0, 100, 10, func(r *GameRules) *int { return &r.HomeAntimatter }},
+	{"hometachyons", "home tachyons", 0, 100, 10, func(r *GameRules) *int { return &r.HomeTachyons }},
+	{"resourcecap", "resource cap", 0, 1000, 0, func(r *GameRules) *int { return &r.ResourceCap }},
+	{"fairturns", "fairness turns", 1, 10, 2, func(r *GameRules) *int { return &r.FairTurns }},
+	{"fairtolerance", "fairness tolerance", 0, 100, 0, func(r *GameRules) *int { return &r.FairTolerance }},
+	{"combatmode", "combat mode", SEQUENTIAL, SIMULTANEOUS, SEQUENTIAL, func(r *GameRules) *int { return &r.CombatMode }},
+	{"presencegrowth", "presence growth", 0, 10, 0, func(r *GameRules) *int { return &r.PresenceGrowth }},
+	{"resourcegrowth", "resource growth", 0, 100, 0, func(r *GameRules) *int { return &r.ResourceGrowth }},
+	{"tachyonspeed", "tachyon speed", 0, 50, 0, func(r *GameRules) *int { return &r.TachyonSpeed }},
+	{"antimatterstrike", "antimatter strike", 0, 100, 0, func(r *GameRules) *int { return &r.AntimatterStrike }},
+	{"radarpresence", "radar presence", 0, 100, 0, func(r *GameRules) *int { return &r.RadarPresence }},
+	{"tachyonradar", "tachyon radar", 0, 50, 0, func(r *GameRules) *int { return &r.TachyonRadar }},
+	{"stealthsize", "stealth size", 0, 100, 0, func(r *GameRules) *int { return &r.StealthSize }},
+	{"stealthrange", "stealth range", 0, 100, 0, func(r *GameRules) *int { return &r.StealthRange }},
+}
+
+// Validate gives an error describing the first rule out of its allowed
+// range, or nil.
+func (r GameRules) Validate() error {
+	for _, f := range RuleFields {
+		if val := *f.Of(&r); val < f.Min || val > f.Max {
+			return fmt.Errorf("%s must be from %d to %d", f.Desc, f.Min, f.Max)
+		}
+	}
+	if r.ResourceCap > 0 && (r.HomeAntimatter > r.ResourceCap || r.HomeTachyons > r.ResourceCap) {
+		return fmt.Errorf("home resources must not exceed the resource cap")
+	}
+	return nil
+}
+
+// SpeedOf gives how many hexes a turn a ship launched on the given power
+// flies.
+func (r GameRules) SpeedOf(power int) int {
+	if power == TACHYONS {
+		return r.ShipSpeed + r.TachyonSpeed
+	}
+	return r.ShipSpeed
+}
+
+// MaxSpeed gives how many hexes a turn the fastest ship flies.
+func (r GameRules) MaxSpeed() int {
+	return r.ShipSpeed + r.TachyonSpeed
+}
+
+// StrikeOf gives how many defenders a ship of the given power and size
+// kills as it lands.
+func (r GameRules) StrikeOf(power, size int) int {
+	if power == ANTIMATTER {
+		return size * r.AntimatterStrike / 100
+	}
+	return 0
+}
+
+// CapResource limits an amount of antimatter or tachyons to the resource
+// cap.
+func (r GameRules) CapResource(amount int) int {
+	if r.ResourceCap > 0 && amount > r.ResourceCap {
+		return r.ResourceCap
+	}
+	return amount
+}
+
+// RadarOf gives how many hexes from a planet its occupants see ships; a
+// planet nobody holds has no radar.
+func (r GameRules) RadarOf(pl PlanetDat) int {
+	pFid, sFid := pl.PrimaryFaction(), pl.SecondaryFaction()
+	if pFid == 0 && sFid == 0 {
+		return 0
+	}
+	dist := r.VisionRadius + (pl.PrimaryPresence()+pl.SecondaryPresence())*r.RadarPresence/100
+	if (pFid != 0 && pl.PrimaryPower() == TACHYONS) || (sFid != 0 && pl.SecondaryPower() == TACHYONS) {
+		dist += r.TachyonRadar
+	}
+	return dist
+}
+
+// Stealthy reports whether ships of the given size are seen only at
+// StealthRange.
+func (r GameRules) Stealthy(size int) bool {
+	return size <= r.StealthSize
+}
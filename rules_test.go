@@ -0,0 +1,124 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestGameRules(t *testing.T) {
+	rules := overpower.DefaultRules()
+	rules.BigPerPlayer, rules.LittlePerPlayer = 2, 5
+	rules.HomePresence, rules.HomeAntimatter, rules.HomeTachyons = 8, 4, 4
+	rules.ResourceCap = 6
+	s := memsource.New(1)
+	s.GameItem.ToWin = 100
+	s.GameItem.Seed = 3
+	s.GameItem.Rules = rules
+	for i := 0; i < 3; i++ {
+		s.AddFaction("tester", "Faction")
+	}
+	if err := overpower.MakeGalaxy(s, "standard"); err != nil {
+		t.Fatal("make galaxy failed:", err)
+	}
+	if got, want := len(s.PlanetList), 1+3*rules.PlanetsPerPlayer(); got != want {
+		t.Errorf("made %d planets, want %d", got, want)
+	}
+	for _, pl := range s.PlanetList {
+		if pl.Antimatter > rules.ResourceCap || pl.Tachyons > rules.ResourceCap {
+			t.Errorf("planet %s holds %d/%d, over cap %d", pl.Name, pl.Antimatter, pl.Tachyons, rules.ResourceCap)
+		}
+		if pl.PrimaryFaction != 0 && (pl.PrimaryPresence != 8 || pl.Antimatter != 4 || pl.Tachyons != 4) {
+			t.Errorf("home %s: presence %d resources %d/%d", pl.Name, pl.PrimaryPresence, pl.Antimatter, pl.Tachyons)
+		}
+	}
+	bad := overpower.DefaultRules()
+	bad.ShipSpeed = 0
+	if bad.Validate() == nil {
+		t.Error("zero ship speed passed validation")
+	}
+	bad = overpower.DefaultRules()
+	bad.ResourceCap = 5
+	if bad.Validate() == nil {
+		t.Error("home resources over the cap passed validation")
+	}
+	s.GameItem.Rules = bad
+	if err := overpower.MakeGalaxy(s, "standard"); err == nil {
+		t.Error("galaxy made with invalid rules")
+	}
+}
+
+func TestShipPowers(t *testing.T) {
+	s := makeRulesGalaxy(t, allMechanics(), 2, 1, "standard")
+	rules := s.GameItem.Rules
+	home := s.HomePlanet(1)
+	var far *memsource.Planet
+	for _, pl := range s.PlanetList {
+		if far == nil || home.Loc.StepsTo(pl.Loc) > home.Loc.StepsTo(far.Loc) {
+			far = pl
+		}
+	}
+	path := home.Loc.PathTo(far.Loc)
+	turn := s.GameItem.Turn
+	s.NewShip(1, 1, 5, overpower.ANTIMATTER, turn, path)
+	s.NewShip(1, 2, 5, overpower.TACHYONS, turn, path)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	var seen int
+	for _, sv := range s.ShipViewList {
+		if sv.FID != 1 || sv.Turn != turn {
+			continue
+		}
+		var power int
+		switch sv.SID {
+		case 1:
+			power = overpower.ANTIMATTER
+		case 2:
+			power = overpower.TACHYONS
+		default:
+			continue
+		}
+		if sv.Power != power {
+			t.Errorf("ship %d view has power %d, want %d", sv.SID, sv.Power, power)
+		}
+		if want := path[rules.SpeedOf(power)]; !sv.Loc.Valid || sv.Loc.Coord != want {
+			t.Errorf("ship %d seen at %v, want %v", sv.SID, sv.Loc, want)
+		}
+		seen++
+	}
+	if seen != 2 {
+		t.Errorf("%d views of the ships, want 2", seen)
+	}
+
+	state := overpower.CombatState{PrimaryFaction: 2, PrimaryPresence: 4, PrimaryPower: overpower.TACHYONS}
+	res := overpower.ResolveLanding(rules, state, overpower.Fleet{FID: 1, Size: 8, Power: overpower.ANTIMATTER}, nil)
+	if len(res.Events) == 0 || res.Events[0].Kind != overpower.COMBATSTRIKE || res.Events[0].Losses[1] != 2 {
+		t.Errorf("strike events %+v, want a strike killing 2 first", res.Events)
+	}
+	if res.Final.PrimaryFaction != 1 || res.Final.PrimaryPresence != 6 {
+		t.Errorf("strike left %+v, want held by 1 with 6", res.Final)
+	}
+	plain := overpower.ResolveCombat(state, 1, 8, nil)
+	if plain.Final.PrimaryPresence != 4 {
+		t.Errorf("plain landing left %+v, want held by 1 with 4", plain.Final)
+	}
+	strikes := func(res overpower.CombatResult) (killed int) {
+		for _, ev := range res.Events {
+			if ev.Kind == overpower.COMBATSTRIKE {
+				killed += ev.Losses[1]
+			}
+		}
+		return
+	}
+	// strikes come from what is left of a fleet, not what was launched
+	held := overpower.CombatState{PrimaryFaction: 3, PrimaryPresence: 10}
+	cut := overpower.ResolveArrival(rules, held, []overpower.Fleet{{FID: 1, Size: 20, Power: overpower.ANTIMATTER}, {FID: 2, Size: 16}}, nil)
+	if got, want := strikes(cut), rules.StrikeOf(overpower.ANTIMATTER, 4); got != want {
+		t.Errorf("fleet cut to 4 in space struck %d, want %d: %+v", got, want, cut.Events)
+	}
+	betrayed := overpower.ResolveLanding(rules, state, overpower.Fleet{FID: 1, Size: 4, Power: overpower.ANTIMATTER}, overpower.TrustSet{{1, 2}: true})
+	if got, want := strikes(betrayed), rules.StrikeOf(overpower.ANTIMATTER, 3); got != want {
+		t.Errorf("fleet betrayed down to 3 struck %d, want %d: %+v", got, want, betrayed.Events)
+	}
+}
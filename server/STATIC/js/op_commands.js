@@ -82,7 +82,13 @@ commands.zoomMap = function(zoomIn) {
     }
 };
 commands.setPowerOrder = function(planet, power) {
-    if (!planet.myControl || planet.myPower === power) {
+    if (!planet.myControl) {
+        return;
+    }
+    if (planet.myPower === power) {
+        power = 0;
+    }
+    if (!power && !overpower.data.powers.getHex(planet.hex)) {
         return;
     }
     overpower.net.putPowerOrder({hex: planet.hex, type: power});
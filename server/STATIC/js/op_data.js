@@ -14,7 +14,8 @@ var data = muleObj.overpower.data;
 
 data.factions = {};
 data.targets = {};
-data.power = {};
+data.powers = new geometry.HexMap();
+data.standing = [];
 data.getName = function(fid) {
     if (fid === 0) {
         return "Hostile natives";
@@ -196,6 +197,7 @@ data.parseFullView = function(fullView) {
     // REPORTS //
     data.launchReports = fullView.launchrecords;
     data.battleReports = fullView.battlerecords;
+    data.conditionReports = fullView.conditionrecords;
 
     // PLANETS //
     var setAvail = function() {
@@ -286,16 +288,20 @@ data.parseFullView = function(fullView) {
         pv.sourceLaunchOrders = new geometry.HexMap();
         pv.targetLaunchOrders = new geometry.HexMap();
         pv.truces = makeTr();
+        pv.hostiles = {};
         pv.modTruce = modTruce;
         pv.landing = [];
     });
-    // POWER ORDER //
-    data.power = { 
-        loc: fullView.powerorder.loc,
-        hex: new geometry.Hex(fullView.powerorder.loc[0], fullView.powerorder.loc[1]),
-        type: fullView.powerorder.uppower,
-    };
-    data.power.planet = data.planetGrid.getHex(data.power.hex);
+    // STANDING ORDERS //
+    data.standing = [];
+    fullView.standingorders.forEach(data.standingOrderConfirmed);
+    // CONDITIONAL ORDERS //
+    data.conditionals = [];
+    fullView.conditionalorders.forEach(data.conditionalOrderConfirmed);
+    data.conditionsFired = data.conditionReports.map(data.conditionOf);
+    // POWER ORDERS //
+    data.powers.clear();
+    fullView.powerorders.forEach(data.powerOrderConfirmed);
     // LAUNCH ORDERS //
     fullView.launchorders.forEach(function(ord) {
         ord.sourceHex = new geometry.Hex(ord.source[0], ord.source[1]);
@@ -325,8 +331,40 @@ data.parseFullView = function(fullView) {
             console.log("BAD TRUCE:", truce, "-- CAN'T FIND PLANET");
             return;
         }
-        pl.truces[truce.trucee] = 1;
+        if (truce.hostile) {
+            pl.hostiles[truce.trucee] = true;
+        } else {
+            pl.truces[truce.trucee] = 1;
+        }
+    });
+    // TREATIES //
+    data.treaties = {};
+    fullView.treaties.forEach(function(tr) {
+        var partner = (tr.fid === overpower.FID) ? tr.partner : tr.fid;
+        var cur = data.treaties[partner] || {};
+        if (tr.accepted) {
+            cur.kind = tr.kind;
+        } else if (tr.fid === overpower.FID) {
+            cur.offered = tr.kind;
+        } else {
+            cur.incoming = tr.kind;
+        }
+        data.treaties[partner] = cur;
+    });
+    data.treatyBreaks = fullView.treatybreaks;
+    // VISION GRANTS //
+    data.grantsGiven = {};
+    data.grantsReceived = {};
+    fullView.visiongrants.forEach(function(vg) {
+        if (vg.fid === overpower.FID) {
+            data.grantsGiven[vg.grantee] = true;
+        } else {
+            data.grantsReceived[vg.fid] = true;
+        }
     });
+    // MESSAGES //
+    data.messages = fullView.messages;
+    data.lastRead = fullView.lastread;
 
     // MAP //
     data.mapView = fullView.mapview;
@@ -377,14 +415,81 @@ data.launchOrderConfirmed = function(order) {
 };
 
 data.powerOrderConfirmed = function(order) {
-    data.power = {
+    var hex = (new geometry.Hex()).addArray(order.loc);
+    if (!order.uppower) {
+        data.powers.deleteHex(hex);
+        return;
+    }
+    var power = {
         type: order.uppower,
-        hex: (new geometry.Hex()).addArray(order.loc),
+        hex: hex,
+    };
+    power.planet = data.planetGrid.getHex(hex);
+    data.powers.setHex(hex, power);
+};
+data.standingOrderConfirmed = function(order) {
+    var sourceHex = (new geometry.Hex()).addArray(order.source);
+    var targetHex = (new geometry.Hex()).addArray(order.target);
+    data.standing = data.standing.filter(function(so) {
+        return !(so.sourceHex.eq(sourceHex) && so.targetHex.eq(targetHex));
+    });
+    if (!order.size && !order.percent) {
+        return;
+    }
+    data.standing.push({
+        sourceHex: sourceHex,
+        targetHex: targetHex,
+        sourcePL: data.planetGrid.getHex(sourceHex),
+        targetPL: data.planetGrid.getHex(targetHex),
+        size: order.size,
+        percent: order.percent,
+        turns: order.turns,
+    });
+};
+data.conditionalOrderConfirmed = function(order) {
+    data.conditionals = data.conditionals.filter(function(co) {
+        return co.cid !== order.cid;
+    });
+    if (!order.kind) {
+        return;
+    }
+    data.conditionals.push(data.conditionOf(order));
+};
+data.conditionOf = function(order) {
+    var planetAt = function(pt) {
+        return data.planetGrid.getHex((new geometry.Hex()).addArray(pt));
+    };
+    return {
+        cid: order.cid,
+        kind: order.kind,
+        locPL: planetAt(order.loc),
+        within: order.within,
+        action: order.action,
+        sourcePL: planetAt(order.source),
+        targetPL: planetAt(order.target),
+        size: order.size,
+        power: order.power,
     };
-    data.power.planet = data.planetGrid.getHex(data.power.hex);
 };
-data.isPOUseful = function() {
-    return (data.power && data.power.planet && data.power.planet.myControl > 0 && data.power.type && data.power.planet.myPower !== data.power.type);
+data.standingOrderFor = function(sourceHex, targetHex) {
+    for (var i = 0; i < data.standing.length; i++) {
+        var so = data.standing[i];
+        if (so.sourceHex.eq(sourceHex) && so.targetHex.eq(targetHex)) {
+            return so;
+        }
+    }
+};
+data.isPOUseful = function(power) {
+    return (power && power.planet && power.planet.myControl > 0 && power.type && power.planet.myPower !== power.type);
+};
+data.usefulPowerOrders = function() {
+    var list = [];
+    data.powers.forEach(function(power) {
+        if (data.isPOUseful(power)) {
+            list.push(power);
+        }
+    });
+    return list;
 };
 
 data.trucesConfirmed = function(truces) {
@@ -402,6 +507,24 @@ data.trucesConfirmed = function(truces) {
     });
 };
 
+data.visionGrantConfirmed = function(vg) {
+    if (vg.grant) {
+        data.grantsGiven[vg.grantee] = true;
+    } else {
+        delete data.grantsGiven[vg.grantee];
+    }
+};
+data.unreadMessages = function() {
+    return data.messages.filter(function(msg) {
+        return msg.mid > data.lastRead && msg.fid !== overpower.FID;
+    }).length;
+};
+data.messagesReadConfirmed = function(mid) {
+    if (mid > data.lastRead) {
+        data.lastRead = mid;
+    }
+};
+
 data.turnBufferConfirmed = function(buff) {
     data.factions.myFaction.donebuffer = buff;
 };
@@ -50,6 +50,7 @@ var infobox = ophtml.infobox;
 infobox.render = function() {
     infobox.overview.render();
     infobox.targets.render();
+    infobox.orders.messagebox.render();
 };
 
 function setPlanet(dat) {
@@ -156,6 +156,132 @@ net.putLaunchOrder = function(order) {
 };
 
 
+net.putStandingOrder = function(order) {
+    var curTime = Date.now();
+    if (net.timers.standingOrder && curTime - net.timers.standingOrder < 1000) {
+        console.log("CANCELLING STANDING ORDER UPDATE: TOO SOON, EXECUTUS (1 second)");
+        return;
+    }
+    net.timers.standingOrder = curTime;
+    var jSO = { gid: overpower.GID,
+        fid: overpower.FID,
+        source: [order.sourcePL.hex.x, order.sourcePL.hex.y],
+        target: [order.targetPL.hex.x, order.targetPL.hex.y],
+        size: order.size || 0,
+        percent: order.percent || 0,
+        turns: order.turns || 0,
+    };
+    var url = "/overpower/json/standingorders";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error syncing standingorder data with server:", err, data);
+        },
+        success: function(jDat) {
+            overpower.data.standingOrderConfirmed(jSO);
+            overpower.html.infobox.targets.render();
+        },
+    };
+    ajax.putJSEND(url, jSO, callbacks);
+};
+
+net.cancelConditionalOrder = function(cid) {
+    var jCO = { gid: overpower.GID,
+        fid: overpower.FID,
+        cid: cid,
+    };
+    var url = "/overpower/json/conditionalorders";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error syncing conditionalorder data with server:", err, data);
+        },
+        success: function(jDat) {
+            overpower.data.conditionalOrderConfirmed(jCO);
+            overpower.html.infobox.targets.render();
+        },
+    };
+    ajax.putJSEND(url, jCO, callbacks);
+};
+
+net.putMessage = function(recipient, body) {
+    var jMS = { gid: overpower.GID,
+        fid: overpower.FID,
+        recipient: recipient,
+        body: body,
+    };
+    var url = "/overpower/json/messages";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error sending message to server:", err, data);
+        },
+        success: function(jDat) {
+            net.getFullView();
+        },
+    };
+    ajax.putJSEND(url, jMS, callbacks);
+};
+
+net.readMessages = function(mid) {
+    var jMS = { gid: overpower.GID,
+        fid: overpower.FID,
+        read: mid,
+    };
+    var url = "/overpower/json/messages";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error syncing message data with server:", err, data);
+        },
+        success: function(jDat) {
+            overpower.data.messagesReadConfirmed(mid);
+            overpower.html.infobox.render();
+        },
+    };
+    ajax.putJSEND(url, jMS, callbacks);
+};
+
+net.putTreaty = function(partner, kind, act) {
+    var jTY = { gid: overpower.GID,
+        fid: overpower.FID,
+        partner: partner,
+        kind: kind,
+        act: act,
+    };
+    var url = "/overpower/json/treaties";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error syncing treaty data with server:", err, data);
+        },
+        success: function(jDat) {
+            net.getFullView();
+        },
+    };
+    ajax.putJSEND(url, jTY, callbacks);
+};
+
+net.putVisionGrant = function(grantee, grant) {
+    var jVG = { gid: overpower.GID,
+        fid: overpower.FID,
+        grantee: grantee,
+        grant: grant,
+    };
+    var url = "/overpower/json/visiongrants";
+
+    var callbacks = {
+        error: function(err, data) {
+            console.log("Error syncing visiongrant data with server:", err, data);
+        },
+        success: function(jDat) {
+            overpower.data.visionGrantConfirmed(jVG);
+            overpower.html.infobox.targets.render();
+        },
+    };
+    ajax.putJSEND(url, jVG, callbacks);
+};
+
 net.putTruces = function(planet) {
     var curTime = Date.now();
     if (net.timers.truces && curTime - net.timers.truces < 1000) {
@@ -167,6 +293,7 @@ net.putTruces = function(planet) {
         fid: overpower.FID,
         loc: [planet.hex.x, planet.hex.y],
         trucees: [],
+        hostiles: [],
     };
     var trList = Object.keys(planet.truces);
     trList.forEach(function(key) {
@@ -174,6 +301,11 @@ net.putTruces = function(planet) {
             jTR.trucees.push(parseInt(key));
         }
     });
+    Object.keys(planet.hostiles).forEach(function(key) {
+        if (planet.hostiles[key] && !planet.truces[key]) {
+            jTR.hostiles.push(parseInt(key));
+        }
+    });
     var url = "/overpower/json/truces";
  
     var callbacks = {
@@ -42,8 +42,9 @@ var overview = ophtml.infobox.overview;
 
 ophtml.target2Button(null, overview.powerbutton);
 overview.powercancelbutton.setClick(function() {
-    if (data.power.planet) {
-        overpower.commands.setPowerOrder(data.power.planet, 0);
+    var list = data.usefulPowerOrders();
+    if (list.length === 1) {
+        overpower.commands.setPowerOrder(list[0].planet, 0);
     }
 });
 
@@ -128,6 +129,7 @@ overview.render = function() {
     overview.renderTurn();
     overview.renderReports();
     overview.renderPowerOrder();
+    html.setText("unreadnum", data.unreadMessages());
 };
 overview.renderScore = function() {
     html.setText("scoretext", data.factions.myFaction.score);
@@ -156,7 +158,7 @@ overview.renderTurn = function() {
 };
 overview.renderReports = function() {
     if (data.game.turn > 1) {
-        html.setText("reportnum", data.battleReports.length + data.launchReports.length);
+        html.setText("reportnum", data.battleReports.length + data.launchReports.length + data.conditionReports.length);
         overview.reportsbutton.style.display = 'inline';
 
     } else {
@@ -166,12 +168,20 @@ overview.renderReports = function() {
 };
 overview.renderPowerOrder = function() {
     var elem = document.getElementById("powerordertext");
-    if (data.isPOUseful()) {
-        elem.textContent = ((data.power.type === -1) ? "Tachyons ("+data.power.planet.tachyons+")": "Antimatter ("+data.power.planet.antimatter+")");
+    var list = data.usefulPowerOrders();
+    if (list.length > 1) {
+        elem.textContent = ""+list.length+" planets";
+        elem.className = "";
+        overview.powerbutton.style.display = 'inline';
+        overview.powercancelbutton.style.display = 'none';
+        overview.powerbutton.setPlanet(list[0].planet);
+    } else if (list.length === 1) {
+        var power = list[0];
+        elem.textContent = ((power.type === -1) ? "Tachyons ("+power.planet.tachyons+")": "Antimatter ("+power.planet.antimatter+")");
         elem.className = "";
         overview.powerbutton.style.display = 'inline';
         overview.powercancelbutton.style.display = 'inline';
-        overview.powerbutton.setPlanet(data.power.planet);
+        overview.powerbutton.setPlanet(power.planet);
     } else {
         elem.textContent = "Not yet set";
         elem.className = "alert";
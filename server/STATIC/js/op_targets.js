@@ -29,6 +29,10 @@ var net = overpower.net;
 ophtml.infobox.orders = {
     launchbox: new html.Tree("launchorderbox"),
     powerbox: new html.Tree("powerorderbox"),
+    standingbox: new html.Tree("standingorderbox"),
+    conditionalbox: new html.Tree("conditionalorderbox"),
+    diplomacybox: new html.Tree("diplomacybox"),
+    messagebox: new html.Tree("messagebox"),
 };
 
 var launchbox = ophtml.infobox.orders.launchbox;
@@ -67,16 +71,210 @@ launchbox.render = function() {
         elem.setClick(overpower.commands.confirmLaunchOrder);
         elem.elem.title = "You must click here to confirm your launch order before it is final";
     }
+    var so = data.standingOrderFor(tOrd.sourcePL.hex, tOrd.targetPL.hex);
+    if (so) {
+        launchbox.spur("br");
+        launchbox.addDisplay("Every Turn", (so.percent) ? ""+so.percent+"%" : so.size);
+    } else if (tOrd.curO && !tOrd.modified) {
+        launchbox.spur("br");
+        elem = launchbox.spur("button", "Repeat Every Turn");
+        elem.setClick(function() {
+            net.putStandingOrder({sourcePL: tOrd.sourcePL, targetPL: tOrd.targetPL, size: tOrd.curO.size});
+        });
+        elem.elem.title = "Launch this many ships along this route every turn until cancelled or the source planet is lost";
+    }
+};
+
+var standingbox = ophtml.infobox.orders.standingbox;
+standingbox.render = function() {
+    standingbox.clear();
+    if (!data.standing.length) {
+        standingbox.style.display = "none";
+        return;
+    }
+    standingbox.style.display = "block";
+    standingbox.addText("Standing Launches:");
+    data.standing.forEach(function(so) {
+        standingbox.spur("br");
+        standingbox.spurElement(ophtml.target2Button(so.sourcePL || so.sourceHex));
+        standingbox.addText(" to ");
+        standingbox.spurElement(ophtml.jumperButton(so.targetPL || so.targetHex));
+        standingbox.addDisplay(" Size", (so.percent) ? ""+so.percent+"%" : so.size);
+        if (so.turns) {
+            standingbox.addDisplay(" Turns Left", so.turns);
+        }
+        standingbox.addText(" ");
+        var elem = standingbox.spur("button", "Cancel");
+        elem.setClick(function() {
+            net.putStandingOrder({sourcePL: {hex: so.sourceHex}, targetPL: {hex: so.targetHex}});
+        });
+    });
+};
+var conditionalbox = ophtml.infobox.orders.conditionalbox;
+conditionalbox.render = function() {
+    conditionalbox.clear();
+    if (!data.conditionals.length && !data.conditionsFired.length) {
+        conditionalbox.style.display = "none";
+        return;
+    }
+    conditionalbox.style.display = "block";
+    var describe = function(co) {
+        if (co.kind === 1) {
+            conditionalbox.addText("If ");
+            conditionalbox.spurElement(ophtml.jumperButton(co.locPL));
+            conditionalbox.addText(" is attacked, ");
+        } else {
+            conditionalbox.addText((co.within === undefined) ? "If a ship is seen near " : "If a ship is seen within "+co.within+" of ");
+            conditionalbox.spurElement(ophtml.jumperButton(co.locPL));
+            conditionalbox.addText(", ");
+        }
+        if (co.action === 1) {
+            conditionalbox.addText("launch "+(co.size || "all")+" from ");
+            conditionalbox.spurElement(ophtml.target2Button(co.sourcePL));
+            conditionalbox.addText(" to ");
+        } else {
+            conditionalbox.addText("attune to "+((co.power === -1) ? "Tachyons" : "Antimatter")+" at ");
+        }
+        conditionalbox.spurElement(ophtml.jumperButton(co.targetPL));
+    };
+    if (data.conditionals.length) {
+        conditionalbox.addText("Conditional Orders:");
+    }
+    data.conditionals.forEach(function(co) {
+        conditionalbox.spur("br");
+        describe(co);
+        conditionalbox.addText(" ");
+        var elem = conditionalbox.spur("button", "Cancel");
+        elem.setClick(function() {
+            net.cancelConditionalOrder(co.cid);
+        });
+    });
+    data.conditionsFired.forEach(function(co, i) {
+        if (i === 0) {
+            if (data.conditionals.length) {
+                conditionalbox.spur("br");
+            }
+            conditionalbox.addText("Fired Last Turn:");
+        }
+        conditionalbox.spur("br");
+        describe(co);
+        if (co.action === 1) {
+            conditionalbox.addDisplay(" Launched", co.size);
+        }
+    });
+};
+var diplomacybox = ophtml.infobox.orders.diplomacybox;
+diplomacybox.render = function() {
+    diplomacybox.clear();
+    var others = data.factions.list.filter(function(fid) {
+        return fid !== overpower.FID;
+    });
+    if (!others.length) {
+        diplomacybox.style.display = "none";
+        return;
+    }
+    diplomacybox.style.display = "block";
+    var kinds = ["", "Non-Aggression", "Peace", "Alliance"];
+    var button = function(text, kind, fid, act) {
+        var elem = diplomacybox.spur("button", text);
+        elem.setClick(function() {
+            net.putTreaty(fid, kind, act);
+        });
+    };
+    diplomacybox.addText("Diplomacy:");
+    others.forEach(function(fid) {
+        var tr = data.treaties[fid] || {};
+        diplomacybox.spur("br");
+        diplomacybox.addText(data.getName(fid)+": ");
+        var given = !!data.grantsGiven[fid];
+        var visionB = diplomacybox.spur("button", given ? "Stop Sharing Vision" : "Share Vision");
+        visionB.setClick(function() {
+            net.putVisionGrant(fid, !given);
+        });
+        if (data.grantsReceived[fid]) {
+            diplomacybox.addText(" (sharing vision with you) ");
+        } else {
+            diplomacybox.addText(" ");
+        }
+        if (tr.kind) {
+            diplomacybox.addText(kinds[tr.kind]+" ");
+            button("Break", tr.kind, fid, "cancel");
+            return;
+        }
+        if (tr.incoming) {
+            diplomacybox.addText("offers "+kinds[tr.incoming]+" ");
+            button("Accept", tr.incoming, fid, "accept");
+            button("Reject", tr.incoming, fid, "reject");
+        }
+        if (tr.offered) {
+            diplomacybox.addText("offered "+kinds[tr.offered]+" ");
+            button("Withdraw", tr.offered, fid, "cancel");
+            return;
+        }
+        if (!tr.incoming) {
+            for (var kind = 1; kind < kinds.length; kind++) {
+                button("Offer "+kinds[kind], kind, fid, "propose");
+            }
+        }
+    });
+    data.treatyBreaks.forEach(function(tb, i) {
+        if (i === 0) {
+            diplomacybox.spur("br");
+            diplomacybox.addText("Treaties Broken:");
+        }
+        diplomacybox.spur("br");
+        diplomacybox.addText("Turn "+tb.turn+": "+data.getName(tb.fid)+" broke "+kinds[tb.kind]+" with "+data.getName(tb.partner));
+    });
+};
+var messagebox = ophtml.infobox.orders.messagebox;
+messagebox.render = function() {
+    messagebox.clear();
+    messagebox.style.display = "block";
+    messagebox.addText("Messages:");
+    var shown = data.messages.slice(-10);
+    var lastMID = 0;
+    shown.forEach(function(msg) {
+        lastMID = msg.mid;
+        messagebox.spur("br");
+        if (msg.mid > data.lastRead && msg.fid !== overpower.FID) {
+            messagebox.addText("* ");
+        }
+        var to = (msg.recipient === 0) ? "All" : data.getName(msg.recipient);
+        messagebox.addText("Turn "+msg.turn+", "+data.getName(msg.fid)+" to "+to+": "+msg.body);
+    });
+    if (data.unreadMessages()) {
+        messagebox.spur("br");
+        var readB = messagebox.spur("button", "Mark Read");
+        readB.setClick(function() {
+            net.readMessages(lastMID);
+        });
+    }
+    messagebox.spur("br");
+    var select = messagebox.spur("select");
+    select.spur("option", "All").elem.value = 0;
+    data.factions.list.forEach(function(fid) {
+        if (fid !== overpower.FID) {
+            select.spur("option", data.getName(fid)).elem.value = fid;
+        }
+    });
+    var input = messagebox.spur("input");
+    input.elem.maxLength = 1000;
+    var sendB = messagebox.spur("button", "Send");
+    sendB.setClick(function() {
+        var body = input.elem.value.trim();
+        if (body) {
+            net.putMessage(parseInt(select.elem.value), body);
+        }
+    });
 };
 var powerbox = ophtml.infobox.orders.powerbox;
 powerbox.render = function() {
     var elem;
     powerbox.clear();
-    var pl = data.power.planet;
-    var useful = data.isPOUseful();
-    if (!useful && data.targets.secondary && data.targets.secondary.planet.myControl > 0) {
+    var pl;
+    if (data.targets.secondary && data.targets.secondary.planet.myControl > 0) {
         pl = data.targets.secondary.planet;
-    } else if (!useful && (!data.targets.secondary && data.targets.primary && data.targets.primary.planet && data.targets.primary.planet.myControl > 0)) {
+    } else if (!data.targets.secondary && data.targets.primary && data.targets.primary.planet && data.targets.primary.planet.myControl > 0) {
         pl = data.targets.primary.planet;
     }  else {
         powerbox.style.display = "none";
@@ -84,6 +282,16 @@ powerbox.render = function() {
     }
     powerbox.style.display = "block";
     powerbox.spurElement(ophtml.target2Button(pl));
+    var power = data.powers.getHex(pl.hex);
+    if (data.isPOUseful(power)) {
+        powerbox.addDisplay(" Attuning", (power.type === -1) ? "Tachyons ("+pl.tachyons+")" : "Antimatter ("+pl.antimatter+")", "alert");
+        powerbox.spur("br");
+        elem = powerbox.spur("button", "Cancel Attunement");
+        elem.setClick(function() {
+            overpower.commands.setPowerOrder(pl, 0);
+        });
+        return;
+    }
     var b1, b2;
     if (pl.myPower === -1) {
         powerbox.addDisplay(" Current Power", "Tachyons ("+pl.tachyons+")");
@@ -104,13 +312,13 @@ powerbox.render = function() {
         b1.setClick(function() {
             overpower.commands.setPowerOrder(pl, 1);
         });
-        b1.elem.title = "This planet will not become attuned to this resource until your next turn.  You can only attune one planet per turn.  Planets can only be attuned to one resource: this will override any existing attunement.";
+        b1.elem.title = "This planet will not become attuned to this resource until your next turn.  Planets can only be attuned to one resource: this will override any existing attunement.";
     }
     if (b2) {
         b2.setClick(function() {
             overpower.commands.setPowerOrder(pl, -1);
         });
-        b2.elem.title = "This planet will not become attuned to this resource until your next turn.  You can only attune one planet per turn.  Planets can only be attuned to one resource: this will override any existing attunement.";
+        b2.elem.title = "This planet will not become attuned to this resource until your next turn.  Planets can only be attuned to one resource: this will override any existing attunement.";
     }
 };
 
@@ -128,6 +336,9 @@ targets.render = function() {
     targets.primary.render();
     targets.secondary.render();
     launchbox.render();
+    standingbox.render();
+    conditionalbox.render();
+    diplomacybox.render();
     powerbox.render();
 };
 targets.primary.render = function() {
@@ -142,6 +353,9 @@ targets.primary.render = function() {
     if (tDat.planet) {
         box.spurClass("p", "target1", "Primary Target: ("+tDat.hex.x+","+tDat.hex.y+")");
         box.spurElement(ophtml.jumperButton(tDat.planet));
+        if (tDat.planet.radar) {
+            box.spur("p", "Radar range: "+tDat.planet.radar+" sector"+((tDat.planet.radar === 1) ? "":"s"));
+        }
     } else {
         box.spurClass("span", "target1", "Primary Target: ");
         box.spurElement(ophtml.jumperButton(tDat.hex));
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"mule/overpower"
 	"net/http"
 	"strconv"
 )
@@ -56,7 +57,7 @@ func (h *Handler) ApiJSONget(w http.ResponseWriter, r *http.Request) {
 			return obj, err
 		}
 	case "powerorders":
-		names = []string{"gid", "fid"}
+		names = []string{"gid", "fid", "locx", "locy"}
 		getter = func(args ...KV) (interface{}, error) {
 			obj, err := h.M.PowerOrder().SelectWhere(SQLAND(args...))
 			return obj, err
@@ -85,6 +86,38 @@ func (h *Handler) ApiJSONget(w http.ResponseWriter, r *http.Request) {
 			obj, err := h.M.Truce().SelectWhere(SQLAND(args...))
 			return obj, err
 		}
+	case "messages":
+		names = []string{"gid", "fid", "mid"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.Message().SelectVisible(args[0].Value.(int), args[1].Value.(int))
+			if err != nil || len(args) < 3 {
+				return obj, err
+			}
+			for _, msg := range obj {
+				if msg.MID() == args[2].Value.(int) {
+					return []overpower.MessageDat{msg}, nil
+				}
+			}
+			return []overpower.MessageDat{}, nil
+		}
+	case "treaties":
+		names = []string{"gid", "fid", "partner"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.Treaty().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
+	case "visiongrants":
+		names = []string{"gid", "fid", "grantee"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.VisionGrant().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
+	case "treatybreaks":
+		names = []string{"gid", "fid", "turn", "partner"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.TreatyBreak().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
 	case "battlerecords":
 		names = []string{"gid", "fid", "locx", "locy", "turn", "index"}
 		getter = func(args ...KV) (interface{}, error) {
@@ -97,6 +130,30 @@ func (h *Handler) ApiJSONget(w http.ResponseWriter, r *http.Request) {
 			obj, err := h.M.LaunchOrder().SelectWhere(SQLAND(args...))
 			return obj, err
 		}
+	case "shiporders":
+		names = []string{"gid", "fid", "sid"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.ShipOrder().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
+	case "standingorders":
+		names = []string{"gid", "fid", "sourcex", "sourcey", "targetx", "targety"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.StandingOrder().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
+	case "conditionalorders":
+		names = []string{"gid", "fid", "cid"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.ConditionalOrder().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
+	case "conditionrecords":
+		names = []string{"gid", "fid", "turn", "cid"}
+		getter = func(args ...KV) (interface{}, error) {
+			obj, err := h.M.ConditionRecord().SelectWhere(SQLAND(args...))
+			return obj, err
+		}
 	case "launchrecords":
 		names = []string{"gid", "fid", "turn", "sourcex", "sourcey", "targetx", "targety"}
 		getter = func(args ...KV) (interface{}, error) {
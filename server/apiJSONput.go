@@ -4,6 +4,7 @@ import (
 	"mule/jsend"
 	"mule/overpower/models"
 	"net/http"
+	"strings"
 )
 
 // /overpower/json/RESOURCE
@@ -28,6 +29,18 @@ func (h *Handler) ApiJSONput(w http.ResponseWriter, r *http.Request) {
 		h.apiJSONputPowerOrders(w, r)
 	case "launchorders":
 		h.apiJSONputLaunchOrders(w, r)
+	case "shiporders":
+		h.apiJSONputShipOrders(w, r)
+	case "standingorders":
+		h.apiJSONputStandingOrders(w, r)
+	case "messages":
+		h.apiJSONputMessages(w, r)
+	case "treaties":
+		h.apiJSONputTreaties(w, r)
+	case "visiongrants":
+		h.apiJSONputVisionGrants(w, r)
+	case "conditionalorders":
+		h.apiJSONputConditionalOrders(w, r)
 	case "factions":
 		h.apiJSONputFactions(w, r)
 	case "mapviews":
@@ -61,7 +74,7 @@ func (h *Handler) apiJSONputTruces(w http.ResponseWriter, r *http.Request) {
 		JSONUserError(w, "You are not authorized for that faction")
 		return
 	}
-	for _, fid := range item.Trucees {
+	for _, fid := range append(item.Trucees, item.Hostiles...) {
 		if !facMap[fid] {
 			JSONUserError(w, "FACTION ID NOT FOUND", KV{"fid", fid})
 			return
@@ -83,6 +96,145 @@ func (h *Handler) apiJSONputTruces(w http.ResponseWriter, r *http.Request) {
 	JSONSuccess(w, nil)
 }
 
+func (h *Handler) apiJSONputMessages(w http.ResponseWriter, r *http.Request) {
+	item := &MessageCommand{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into messagecommand data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "message", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	var errS, errU error
+	item.Body = strings.TrimSpace(item.Body)
+	if item.Body == "" {
+		if item.Read < 1 {
+			JSONUserError(w, "EMPTY MESSAGE")
+			return
+		}
+		errS, errU = InternalReadMessages(item.GID, item.FID, item.Read)
+	} else {
+		if len(item.Body) > 1000 {
+			JSONUserError(w, "MESSAGE TOO LONG", KV{"max", 1000})
+			return
+		}
+		if item.Recipient == item.FID {
+			JSONUserError(w, "CANNOT MESSAGE SELF")
+			return
+		}
+		if item.Recipient != 0 {
+			facs, err := h.M.Faction().SelectWhere(h.FID(item.GID, item.Recipient))
+			if my, bad := Check(err, "API PUT failure on resource validation", "type", "message", "GID", item.GID, "recipient", item.Recipient); bad {
+				JSONServerError(w, my)
+				return
+			}
+			if len(facs) == 0 {
+				JSONUserError(w, "FACTION ID NOT FOUND", KV{"fid", item.Recipient})
+				return
+			}
+		}
+		errS, errU = InternalSendMessage(item)
+	}
+	if my, bad := Check(errS, "API JSON PUT MESSAGE failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
+func (h *Handler) apiJSONputTreaties(w http.ResponseWriter, r *http.Request) {
+	item := &TreatyCommand{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into treatycommand data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "treaty", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	if item.Partner == item.FID {
+		JSONUserError(w, "CANNOT FORM TREATY WITH SELF")
+		return
+	}
+	facs, err := h.M.Faction().SelectWhere(h.FID(item.GID, item.Partner))
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "treaty", "GID", item.GID, "partner", item.Partner); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if len(facs) == 0 {
+		JSONUserError(w, "FACTION ID NOT FOUND", KV{"fid", item.Partner})
+		return
+	}
+	errS, errU := InternalSetTreaty(item)
+	if my, bad := Check(errS, "API JSON PUT TREATY failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
+func (h *Handler) apiJSONputVisionGrants(w http.ResponseWriter, r *http.Request) {
+	item := &VisionGrantCommand{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into visiongrantcommand data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "visiongrant", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	if item.Grantee == item.FID {
+		JSONUserError(w, "CANNOT GRANT VISION TO SELF")
+		return
+	}
+	facs, err := h.M.Faction().SelectWhere(h.FID(item.GID, item.Grantee))
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "visiongrant", "GID", item.GID, "grantee", item.Grantee); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if len(facs) == 0 {
+		JSONUserError(w, "FACTION ID NOT FOUND", KV{"fid", item.Grantee})
+		return
+	}
+	errS, errU := InternalSetVisionGrant(item)
+	if my, bad := Check(errS, "API JSON PUT VISIONGRANT failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
 func (h *Handler) apiJSONputPowerOrders(w http.ResponseWriter, r *http.Request) {
 	item := &models.PowerOrder{}
 	err := jsend.Read(r, &item)
@@ -139,6 +291,90 @@ func (h *Handler) apiJSONputLaunchOrders(w http.ResponseWriter, r *http.Request)
 	JSONSuccess(w, nil)
 }
 
+func (h *Handler) apiJSONputShipOrders(w http.ResponseWriter, r *http.Request) {
+	item := &ShipOrderCommand{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into shiporder data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "shiporder", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	errS, errU := InternalSetShipOrder(item)
+	if my, bad := Check(errS, "API JSON PUT SHIPORDER failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
+func (h *Handler) apiJSONputStandingOrders(w http.ResponseWriter, r *http.Request) {
+	item := &models.StandingOrder{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into standingorder data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "standingorder", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	errS, errU := InternalSetStandingOrder(item)
+	if my, bad := Check(errS, "API JSON PUT STANDINGORDER failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
+func (h *Handler) apiJSONputConditionalOrders(w http.ResponseWriter, r *http.Request) {
+	item := &models.ConditionalOrder{}
+	err := jsend.Read(r, &item)
+	if err != nil {
+		JSONUserError(w, "Cannot read json into conditionalorder data")
+		return
+	}
+	_, ok, err := h.Validate(item.GID, item.FID)
+	if my, bad := Check(err, "API PUT failure on resource validation", "type", "conditionalorder", "GID", item.GID, "FID", item.FID); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if !ok {
+		JSONUserError(w, "You are not authorized for that faction")
+		return
+	}
+	errS, errU := InternalSetConditionalOrder(item)
+	if my, bad := Check(errS, "API JSON PUT CONDITIONALORDER failure on command execution", "item", item); bad {
+		JSONServerError(w, my)
+		return
+	}
+	if errU != nil {
+		JSONUserError(w, errU.Error())
+		return
+	}
+	JSONSuccess(w, nil)
+}
+
 func (h *Handler) apiJSONputFactions(w http.ResponseWriter, r *http.Request) {
 	item := &models.Faction{}
 	err := jsend.Read(r, &item)
@@ -3,39 +3,111 @@ package main
 import (
 	"mule/hexagon"
 	"mule/overpower"
+	"mule/overpower/bots"
 	"mule/overpower/models"
 )
 
+// TruceCommand sets the truces at one planet: Trucees are trusted there,
+// and Hostiles refused the truce a treaty would give.
 type TruceCommand struct {
-	GID     int           `json:"gid"`
-	FID     int           `json:"fid"`
-	Loc     hexagon.Coord `json:"loc"`
-	Trucees []int         `json:"trucees"`
+	GID      int           `json:"gid"`
+	FID      int           `json:"fid"`
+	Loc      hexagon.Coord `json:"loc"`
+	Trucees  []int         `json:"trucees"`
+	Hostiles []int         `json:"hostiles"`
+}
+
+// VisionGrantCommand starts FID sharing its vision with Grantee, or with
+// Grant unset stops it.
+type VisionGrantCommand struct {
+	GID     int  `json:"gid"`
+	FID     int  `json:"fid"`
+	Grantee int  `json:"grantee"`
+	Grant   bool `json:"grant"`
+}
+
+// MessageCommand sends Body from FID to Recipient, or to every faction
+// in the game when Recipient is 0.  With no Body it instead marks FID's
+// messages read up to MID Read.
+type MessageCommand struct {
+	GID       int    `json:"gid"`
+	FID       int    `json:"fid"`
+	Recipient int    `json:"recipient"`
+	Body      string `json:"body"`
+	Read      int    `json:"read"`
+}
+
+// TreatyCommand acts on the treaty between FID and Partner: "propose"
+// offers one of Kind, "accept" and "reject" answer the partner's offer,
+// and "cancel" withdraws an offer or breaks the treaty in force.
+type TreatyCommand struct {
+	GID     int    `json:"gid"`
+	FID     int    `json:"fid"`
+	Partner int    `json:"partner"`
+	Kind    int    `json:"kind"`
+	Act     string `json:"act"`
+}
+
+// ShipOrderCommand sets or, with Cancel, drops the order for one ship.
+type ShipOrderCommand struct {
+	GID    int           `json:"gid"`
+	FID    int           `json:"fid"`
+	SID    int           `json:"sid"`
+	Target hexagon.Coord `json:"target"`
+	Merge  int           `json:"merge"`
+	Cancel bool          `json:"cancel"`
+}
+
+// internalGameOver reports whether orders for the game are frozen because
+// it has been won.
+func internalGameOver(manager *models.Manager, gid int) (over bool, errS error) {
+	games, err := manager.Game().SelectWhere(manager.GID(gid))
+	if my, bad := Check(err, "internal game over check failure on resource aquisition", "resource", "game", "gid", gid); bad {
+		return false, my
+	}
+	if len(games) == 0 {
+		return false, nil
+	}
+	return overpower.GameOver(games[0]), nil
 }
 
 func InternalSetTruce(item *TruceCommand) (errS, errU error) {
 	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, item.GID); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
 	list, err := manager.Truce().Select("gid", item.GID, "fid", item.FID, "locx", item.Loc[0], "locy", item.Loc[1])
 	if my, bad := Check(err, "internal set truce failure on resource aquisition", "resource", "truce", "trucecommand", item); bad {
 		return my, nil
 	}
-	trMap := make(map[int]bool, len(item.Trucees))
+	// trucee, hostile
+	trMap := make(map[int]bool, len(item.Trucees)+len(item.Hostiles))
 	for _, fid := range item.Trucees {
+		trMap[fid] = false
+	}
+	for _, fid := range item.Hostiles {
+		if _, ok := trMap[fid]; ok {
+			return nil, NewError("Faction both trusted and refused at one planet")
+		}
 		trMap[fid] = true
 	}
 	for _, tr := range list {
-		if !trMap[tr.Trucee()] {
+		if hostile, ok := trMap[tr.Trucee()]; !ok {
 			tr.DELETE()
 		} else {
+			tr.SetHostile(hostile)
 			delete(trMap, tr.Trucee())
 		}
 	}
-	for fid, _ := range trMap {
+	for fid, hostile := range trMap {
 		newTr := &models.Truce{
-			GID:    item.GID,
-			FID:    item.FID,
-			Loc:    item.Loc,
-			Trucee: fid,
+			GID:     item.GID,
+			FID:     item.FID,
+			Loc:     item.Loc,
+			Trucee:  fid,
+			Hostile: hostile,
 		}
 		manager.CreateTruce(newTr)
 	}
@@ -48,17 +120,59 @@ func InternalSetTruce(item *TruceCommand) (errS, errU error) {
 
 func InternalSetPowerOrder(gid, fid, uppower int, loc hexagon.Coord) (errS, errU error) {
 	manager := OPDB.NewManager()
-	list, err := manager.PowerOrder().SelectWhere(manager.FID(gid, fid))
-	if my, bad := Check(err, "internal set powerorder failure on resource aquisition", "resource", "powerorder", "gid", gid, "fid", fid); bad {
+	if over, errS := internalGameOver(manager, gid); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
+	list, err := manager.PowerOrder().Select("gid", gid, "fid", fid, "locx", loc[0], "locy", loc[1])
+	if my, bad := Check(err, "internal set powerorder failure on resource aquisition", "resource", "powerorder", "gid", gid, "fid", fid, "loc", loc); bad {
 		return my, nil
 	}
-	if len(list) == 0 {
-		return nil, NewError("no faction found for given gid/fid")
+	var o overpower.PowerOrderDat
+	if len(list) > 0 {
+		o = list[0]
+	}
+	if uppower == 0 {
+		if o != nil {
+			o.DELETE()
+			err := manager.Close()
+			if my, bad := Check(err, "internal set power order failure on save order deletion", "power order", o); bad {
+				return my, nil
+			}
+		}
+		return nil, nil
+	}
+	if uppower != overpower.ANTIMATTER && uppower != overpower.TACHYONS {
+		return nil, NewError("Bad power for power order")
+	}
+	planets, err := manager.Planet().SelectByLocs(gid, loc)
+	if my, bad := Check(err, "internal set powerorder failure on resource aquisition", "resource", "planet", "gid", gid, "loc", loc); bad {
+		return my, nil
+	}
+	if len(planets) == 0 {
+		return nil, NewError("Planet not found for given location")
+	}
+	if pl := planets[0]; pl.PrimaryFaction() != fid && pl.SecondaryFaction() != fid {
+		return nil, NewError("Faction has no presence on planet")
+	}
+	if o != nil {
+		o.SetUpPower(uppower)
+		err := manager.Close()
+		if my, bad := Check(err, "internal set power order failure on save order update", "power order", o); bad {
+			return my, nil
+		}
+		return nil, nil
 	}
-	list[0].SetLoc(loc)
-	list[0].SetUpPower(uppower)
+	newO := &models.PowerOrder{
+		GID:     gid,
+		FID:     fid,
+		Loc:     loc,
+		UpPower: uppower,
+	}
+	manager.CreatePowerOrder(newO)
 	err = manager.Close()
-	if my, bad := Check(err, "internal set power order failure on manager close", "power order", list[0]); bad {
+	if my, bad := Check(err, "internal set power order failure on save order create", "power order", newO); bad {
 		return my, nil
 	}
 	return nil, nil
@@ -83,6 +197,11 @@ func InternalSetMapCenter(gid, fid int, center hexagon.Coord) (errS, errU error)
 
 func InternalSetLaunchOrder(gid, fid, size int, source, target hexagon.Coord) (errS, errU error) {
 	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, gid); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
 	planets, err := manager.Planet().SelectByLocs(gid, source, target)
 	if my, bad := Check(err, "internal set order failure on resource aquisition", "resource", "planets", "gid", gid, "source", source, "target", target); bad {
 		return my, nil
@@ -169,8 +288,469 @@ func InternalSetLaunchOrder(gid, fid, size int, source, target hexagon.Coord) (e
 	return nil, nil
 }
 
+// InternalSetStandingOrder sets the standing order for a route, or drops
+// it if neither a size nor a percent is given.
+func InternalSetStandingOrder(item *models.StandingOrder) (errS, errU error) {
+	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, item.GID); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
+	list, err := manager.StandingOrder().Select("gid", item.GID, "fid", item.FID,
+		"sourcex", item.Source[0], "sourcey", item.Source[1],
+		"targetx", item.Target[0], "targety", item.Target[1])
+	if my, bad := Check(err, "internal set standingorder failure on resource aquisition", "resource", "standingorder", "item", item); bad {
+		return my, nil
+	}
+	var o overpower.StandingOrderDat
+	if len(list) > 0 {
+		o = list[0]
+	}
+	if item.Size < 1 && item.Percent < 1 {
+		if o != nil {
+			o.DELETE()
+			err := manager.Close()
+			if my, bad := Check(err, "internal set standingorder failure on save order deletion", "order", o); bad {
+				return my, nil
+			}
+		}
+		return nil, nil
+	}
+	if item.Size > 0 && item.Percent > 0 {
+		return nil, NewError("Standing order needs a size or a percent, not both")
+	}
+	if item.Percent > 100 {
+		return nil, NewError("Standing order percent must be at most 100")
+	}
+	if item.Turns < 0 {
+		return nil, NewError("Standing order turns must not be negative")
+	}
+	if item.Source == item.Target {
+		return nil, NewError("Standing order source and target must differ")
+	}
+	planets, err := manager.Planet().SelectByLocs(item.GID, item.Source, item.Target)
+	if my, bad := Check(err, "internal set standingorder failure on resource aquisition", "resource", "planets", "item", item); bad {
+		return my, nil
+	}
+	if len(planets) != 2 {
+		return nil, NewError("Planets not found for given locations")
+	}
+	sPl := planets[0]
+	if sPl.Loc() != item.Source {
+		sPl = planets[1]
+	}
+	if sPl.PrimaryFaction() != item.FID && sPl.SecondaryFaction() != item.FID {
+		return nil, NewError("Faction not in control of source planet")
+	}
+	if o != nil {
+		o.SetSize(item.Size)
+		o.SetPercent(item.Percent)
+		o.SetTurns(item.Turns)
+		err := manager.Close()
+		if my, bad := Check(err, "internal set standingorder failure on save order update", "order", o); bad {
+			return my, nil
+		}
+		return nil, nil
+	}
+	newO := &models.StandingOrder{
+		GID:     item.GID,
+		FID:     item.FID,
+		Source:  item.Source,
+		Target:  item.Target,
+		Size:    item.Size,
+		Percent: item.Percent,
+		Turns:   item.Turns,
+	}
+	manager.CreateStandingOrder(newO)
+	err = manager.Close()
+	if my, bad := Check(err, "internal set standingorder failure on save order create", "order", newO); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+func InternalSetTreaty(item *TreatyCommand) (errS, errU error) {
+	manager := OPDB.NewManager()
+	games, err := manager.Game().SelectWhere(manager.GID(item.GID))
+	if my, bad := Check(err, "internal set treaty failure on resource aquisition", "resource", "game", "item", item); bad {
+		return my, nil
+	}
+	if len(games) == 0 {
+		return nil, NewError("GAME NOT FOUND")
+	}
+	if overpower.GameOver(games[0]) {
+		return nil, NewError("GAME IS OVER")
+	}
+	mine, err := manager.Treaty().Select("gid", item.GID, "fid", item.FID, "partner", item.Partner)
+	if my, bad := Check(err, "internal set treaty failure on resource aquisition", "resource", "treaty", "item", item); bad {
+		return my, nil
+	}
+	theirs, err := manager.Treaty().Select("gid", item.GID, "fid", item.Partner, "partner", item.FID)
+	if my, bad := Check(err, "internal set treaty failure on resource aquisition", "resource", "treaty", "item", item); bad {
+		return my, nil
+	}
+	var myO, theirO overpower.TreatyDat
+	if len(mine) > 0 {
+		myO = mine[0]
+	}
+	if len(theirs) > 0 {
+		theirO = theirs[0]
+	}
+	var inForce overpower.TreatyDat
+	if myO != nil && myO.Accepted() {
+		inForce = myO
+	} else if theirO != nil && theirO.Accepted() {
+		inForce = theirO
+	}
+	switch item.Act {
+	case "propose":
+		if item.Kind < overpower.TREATYNONAGGRESSION || item.Kind > overpower.TREATYALLIANCE {
+			return nil, NewError("Unknown treaty kind")
+		}
+		if inForce != nil {
+			return nil, NewError("A treaty is already in force with that faction")
+		}
+		if theirO != nil && theirO.Kind() == item.Kind {
+			theirO.SetAccepted(true)
+			if myO != nil {
+				myO.DELETE()
+			}
+		} else if myO != nil {
+			myO.SetKind(item.Kind)
+		} else {
+			manager.CreateTreaty(&models.Treaty{
+				GID:     item.GID,
+				FID:     item.FID,
+				Partner: item.Partner,
+				Kind:    item.Kind,
+			})
+		}
+	case "accept":
+		if theirO == nil || theirO.Accepted() {
+			return nil, NewError("No treaty offer from that faction")
+		}
+		if item.Kind != 0 && item.Kind != theirO.Kind() {
+			return nil, NewError("Treaty offer has changed")
+		}
+		theirO.SetAccepted(true)
+		if myO != nil {
+			myO.DELETE()
+		}
+	case "reject":
+		if theirO == nil || theirO.Accepted() {
+			return nil, NewError("No treaty offer from that faction")
+		}
+		theirO.DELETE()
+	case "cancel":
+		if inForce != nil {
+			inForce.DELETE()
+			manager.CreateTreatyBreak(&models.TreatyBreak{
+				GID:     item.GID,
+				Turn:    games[0].Turn(),
+				FID:     item.FID,
+				Partner: item.Partner,
+				Kind:    inForce.Kind(),
+			})
+		} else if myO != nil {
+			myO.DELETE()
+		} else {
+			return nil, NewError("No treaty with that faction")
+		}
+	default:
+		return nil, NewError("Unknown treaty action")
+	}
+	err = manager.Close()
+	if my, bad := Check(err, "internal set treaty failure on manager close", "item", item); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+func InternalSetVisionGrant(item *VisionGrantCommand) (errS, errU error) {
+	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, item.GID); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
+	list, err := manager.VisionGrant().Select("gid", item.GID, "fid", item.FID, "grantee", item.Grantee)
+	if my, bad := Check(err, "internal set visiongrant failure on resource aquisition", "resource", "visiongrant", "item", item); bad {
+		return my, nil
+	}
+	if item.Grant && len(list) == 0 {
+		manager.CreateVisionGrant(&models.VisionGrant{
+			GID:     item.GID,
+			FID:     item.FID,
+			Grantee: item.Grantee,
+		})
+	} else if !item.Grant {
+		for _, vg := range list {
+			vg.DELETE()
+		}
+	}
+	err = manager.Close()
+	if my, bad := Check(err, "internal set visiongrant failure on manager close", "item", item); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+func InternalSendMessage(item *MessageCommand) (errS, errU error) {
+	manager := OPDB.NewManager()
+	games, err := manager.Game().SelectWhere(manager.GID(item.GID))
+	if my, bad := Check(err, "internal send message failure on resource aquisition", "resource", "game", "item", item); bad {
+		return my, nil
+	}
+	if len(games) == 0 {
+		return nil, NewError("GAME NOT FOUND")
+	}
+	manager.CreateMessage(&models.Message{
+		GID:       item.GID,
+		Turn:      games[0].Turn(),
+		FID:       item.FID,
+		Recipient: item.Recipient,
+		Body:      item.Body,
+	})
+	err = manager.Close()
+	if my, bad := Check(err, "internal send message failure on manager close", "item", item); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+func InternalReadMessages(gid, fid, mid int) (errS, errU error) {
+	manager := OPDB.NewManager()
+	reads, err := manager.MessageRead().SelectWhere(manager.FID(gid, fid))
+	if my, bad := Check(err, "internal read messages failure on resource aquisition", "resource", "messageread", "gid", gid, "fid", fid); bad {
+		return my, nil
+	}
+	if len(reads) == 0 {
+		manager.CreateMessageRead(&models.MessageRead{
+			GID:      gid,
+			FID:      fid,
+			LastRead: mid,
+		})
+	} else if reads[0].LastRead() < mid {
+		reads[0].SetLastRead(mid)
+	}
+	err = manager.Close()
+	if my, bad := Check(err, "internal read messages failure on manager close", "gid", gid, "fid", fid, "mid", mid); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+// InternalSetConditionalOrder queues a new conditional order when no CID
+// is given, or drops the faction's order of that CID.
+func InternalSetConditionalOrder(item *models.ConditionalOrder) (errS, errU error) {
+	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, item.GID); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
+	list, err := manager.ConditionalOrder().Select("gid", item.GID, "fid", item.FID)
+	if my, bad := Check(err, "internal set conditionalorder failure on resource aquisition", "resource", "conditionalorder", "item", item); bad {
+		return my, nil
+	}
+	if item.CID != 0 {
+		for _, o := range list {
+			if o.CID() == item.CID {
+				o.DELETE()
+				err := manager.Close()
+				if my, bad := Check(err, "internal set conditionalorder failure on save order deletion", "order", o); bad {
+					return my, nil
+				}
+				return nil, nil
+			}
+		}
+		return nil, NewError("Conditional order not found")
+	}
+	switch item.Kind {
+	case overpower.CONDATTACKED:
+	case overpower.CONDSIGHTED:
+		if item.Within < 0 {
+			return nil, NewError("Conditional order range must not be negative")
+		}
+	default:
+		return nil, NewError("Unknown conditional order condition")
+	}
+	locs := []hexagon.Coord{item.Loc, item.Target}
+	switch item.Action {
+	case overpower.CONDLAUNCH:
+		if item.Size < 0 {
+			return nil, NewError("Conditional order size must not be negative")
+		}
+		if item.Source == item.Target {
+			return nil, NewError("Conditional order source and target must differ")
+		}
+		locs = append(locs, item.Source)
+	case overpower.CONDPOWER:
+		if item.Power != overpower.ANTIMATTER && item.Power != overpower.TACHYONS {
+			return nil, NewError("Conditional order power must be 1 or -1")
+		}
+	default:
+		return nil, NewError("Unknown conditional order action")
+	}
+	planets, err := manager.Planet().SelectByLocs(item.GID, locs...)
+	if my, bad := Check(err, "internal set conditionalorder failure on resource aquisition", "resource", "planets", "item", item); bad {
+		return my, nil
+	}
+	found := make(map[hexagon.Coord]overpower.PlanetDat, len(planets))
+	for _, pl := range planets {
+		found[pl.Loc()] = pl
+	}
+	for _, loc := range locs {
+		if found[loc] == nil {
+			return nil, NewError("Planets not found for given locations")
+		}
+	}
+	if item.Action == overpower.CONDLAUNCH {
+		if sPl := found[item.Source]; sPl.PrimaryFaction() != item.FID && sPl.SecondaryFaction() != item.FID {
+			return nil, NewError("Faction not in control of source planet")
+		}
+	}
+	var cid int
+	for _, o := range list {
+		if o.CID() > cid {
+			cid = o.CID()
+		}
+	}
+	newO := &models.ConditionalOrder{
+		GID:    item.GID,
+		FID:    item.FID,
+		CID:    cid + 1,
+		Kind:   item.Kind,
+		Loc:    item.Loc,
+		Within: item.Within,
+		Action: item.Action,
+		Source: item.Source,
+		Target: item.Target,
+		Size:   item.Size,
+		Power:  item.Power,
+	}
+	manager.CreateConditionalOrder(newO)
+	err = manager.Close()
+	if my, bad := Check(err, "internal set conditionalorder failure on save order create", "order", newO); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+func InternalSetShipOrder(item *ShipOrderCommand) (errS, errU error) {
+	manager := OPDB.NewManager()
+	games, err := manager.Game().SelectWhere(manager.GID(item.GID))
+	if my, bad := Check(err, "internal set shiporder failure on resource aquisition", "resource", "game", "item", item); bad {
+		return my, nil
+	}
+	if len(games) == 0 {
+		return nil, NewError("Game not found")
+	}
+	g := games[0]
+	if overpower.GameOver(g) {
+		return nil, NewError("GAME IS OVER")
+	}
+	ships, err := manager.Ship().SelectWhere(manager.FID(item.GID, item.FID))
+	if my, bad := Check(err, "internal set shiporder failure on resource aquisition", "resource", "ships", "item", item); bad {
+		return my, nil
+	}
+	shipMap := make(map[int]overpower.ShipDat, len(ships))
+	for _, sh := range ships {
+		shipMap[sh.SID()] = sh
+	}
+	sh, ok := shipMap[item.SID]
+	if !ok {
+		return nil, NewError("Ship not found for faction")
+	}
+	list, err := manager.ShipOrder().Select("gid", item.GID, "fid", item.FID, "sid", item.SID)
+	if my, bad := Check(err, "internal set shiporder failure on resource aquisition", "resource", "shiporder", "item", item); bad {
+		return my, nil
+	}
+	var o overpower.ShipOrderDat
+	if len(list) > 0 {
+		o = list[0]
+	}
+	if item.Cancel {
+		if o != nil {
+			o.DELETE()
+			err := manager.Close()
+			if my, bad := Check(err, "internal set shiporder failure on save order deletion", "order", o); bad {
+				return my, nil
+			}
+		}
+		return nil, nil
+	}
+	rules := g.Rules()
+	travelled, _ := overpower.Travelled(sh, g.Turn(), rules.SpeedOf(sh.Power()))
+	if len(travelled) < 1 {
+		return nil, NewError("Ship is not in flight")
+	}
+	at := travelled[0]
+	if item.Merge != 0 {
+		tar, ok := shipMap[item.Merge]
+		if !ok {
+			return nil, NewError("Ship to merge with not found for faction")
+		}
+		if item.Merge == item.SID {
+			return nil, NewError("Ship cannot merge with itself")
+		}
+		if tTravelled, _ := overpower.Travelled(tar, g.Turn(), rules.SpeedOf(tar.Power())); len(tTravelled) < 1 || tTravelled[0] != at {
+			return nil, NewError("Ships must share a hex to merge")
+		}
+		if tar.Power() != sh.Power() {
+			return nil, NewError("Ships must share a power to merge")
+		}
+	} else {
+		planets, err := manager.Planet().SelectByLocs(item.GID, item.Target)
+		if my, bad := Check(err, "internal set shiporder failure on resource aquisition", "resource", "planet", "item", item); bad {
+			return my, nil
+		}
+		if len(planets) == 0 {
+			return nil, NewError("Planet not found for target location")
+		}
+		if at == item.Target {
+			return nil, NewError("Ship is already at target")
+		}
+	}
+	if o != nil {
+		o.SetTarget(item.Target)
+		o.SetMerge(item.Merge)
+		err := manager.Close()
+		if my, bad := Check(err, "internal set shiporder failure on save order update", "order", o); bad {
+			return my, nil
+		}
+		return nil, nil
+	}
+	newO := &models.ShipOrder{
+		GID:    item.GID,
+		FID:    item.FID,
+		SID:    item.SID,
+		Target: item.Target,
+		Merge:  item.Merge,
+	}
+	manager.CreateShipOrder(newO)
+	err = manager.Close()
+	if my, bad := Check(err, "internal set shiporder failure on save order create", "order", newO); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
 func InternalSetDoneBuffer(gid, fid, buff int) (errS, errU error) {
+	return internalSetDoneBuffer(gid, fid, buff, 0)
+}
+
+// internalSetDoneBuffer sets a faction's done buffer, running the turn and
+// then the game's bots if every faction is done.  Chain counts the turns
+// already run back to back by bots alone.
+func internalSetDoneBuffer(gid, fid, buff, chain int) (errS, errU error) {
 	manager := OPDB.NewManager()
+	if over, errS := internalGameOver(manager, gid); errS != nil {
+		return errS, nil
+	} else if over {
+		return nil, NewError("GAME IS OVER")
+	}
 	facs, err := manager.Faction().SelectWhere(manager.GID(gid))
 	if my, bad := Check(err, "command set turnbuffer failure on data retrieval", "resource", "factions", "gid", gid); bad {
 		return my, nil
@@ -198,13 +778,128 @@ func InternalSetDoneBuffer(gid, fid, buff int) (errS, errU error) {
 	}
 	if allDone {
 		// TODO: Run multiple turns if all players have done buffers > 1
-		logE, failE := OPDB.SourceTransact(f.GID(), overpower.RunGameTurn)
+		logE, failE := OPDB.TurnTransact(f.GID(), overpower.RunGameTurn)
 		if my, bad := Check(failE, "command setturn done rungame failure", "gid", f.GID()); bad {
 			return my, nil
 		}
 		if logE != nil {
 			Log(logE)
 		}
+		if chain < BOTCHAIN {
+			return internalRunBots(gid, chain+1), nil
+		}
 	}
 	return nil, nil
 }
+
+// BOTCHAIN limits how many turns in a row bots may run by themselves
+// being done, so a game left with only bots cannot hold the server.
+const BOTCHAIN = 20
+
+// InternalRunBots has every bot faction of the game that is not yet done
+// give its orders for the turn and mark itself done.
+func InternalRunBots(gid int) (errS error) {
+	return internalRunBots(gid, 0)
+}
+
+func internalRunBots(gid, chain int) (errS error) {
+	manager := OPDB.NewManager()
+	games, err := manager.Game().SelectWhere(manager.GID(gid))
+	if my, bad := Check(err, "internal run bots failure on resource aquisition", "resource", "game", "gid", gid); bad {
+		return my
+	}
+	if len(games) == 0 || games[0].Turn() < 1 || overpower.GameOver(games[0]) {
+		return nil
+	}
+	g := games[0]
+	facs, err := manager.Faction().SelectWhere(manager.GID(gid))
+	if my, bad := Check(err, "internal run bots failure on resource aquisition", "resource", "factions", "gid", gid); bad {
+		return my
+	}
+	var ready []overpower.FactionDat
+	for _, f := range facs {
+		if f.Bot() == "" || f.DoneBuffer() != 0 {
+			continue
+		}
+		ai, ok := bots.Get(f.Bot())
+		if !ok {
+			Log(NewError("unknown bot strategy " + f.Bot()))
+			continue
+		}
+		if my := internalBotOrders(g, f.FID(), ai); my != nil {
+			return my
+		}
+		ready = append(ready, f)
+	}
+	// Orders all go in before any bot is done, so only the last can set
+	// off the turn.
+	for _, f := range ready {
+		errS, errU := internalSetDoneBuffer(gid, f.FID(), 1, chain)
+		if errS != nil {
+			return errS
+		} else if errU != nil {
+			Log(errU)
+		}
+	}
+	return nil
+}
+
+// internalBotOrders gives a bot the view its faction has of the game and
+// stores the orders it returns in place of any it had.
+func internalBotOrders(g overpower.GameDat, fid int, ai bots.AI) (errS error) {
+	gid, turn := g.GID(), g.Turn()
+	manager := OPDB.NewManager()
+	plVs, err1 := manager.PlanetView().SelectWhere(manager.FID(gid, fid))
+	shVs, err2 := manager.ShipView().SelectWhere(manager.TURN(gid, fid, turn-1))
+	batRec, err3 := manager.BattleRecord().SelectWhere(manager.FID(gid, fid))
+	truces, err4 := manager.Truce().SelectWhere(manager.FID(gid, fid))
+	for i, err := range []error{err1, err2, err3, err4} {
+		if my, bad := Check(err, "internal bot orders failure on resource aquisition", "index", i, "gid", gid, "fid", fid); bad {
+			return my
+		}
+	}
+	sortLDRecords(batRec)
+	v := &bots.View{
+		FID:     fid,
+		Turn:    turn,
+		Rules:   g.Rules(),
+		Planets: plVs,
+		Ships:   shVs,
+		Battles: batRec,
+	}
+	o := ai.Orders(v, bots.Rand(g.Seed(), fid, turn))
+	// A bot's bad order is logged and skipped, as a player's would be
+	// refused.
+	skip := func(errS, errU error) error {
+		if errU != nil {
+			Log(errU)
+		}
+		return errS
+	}
+	for _, l := range o.Launches {
+		if my := skip(InternalSetLaunchOrder(gid, fid, l.Size, l.Source, l.Target)); my != nil {
+			return my
+		}
+	}
+	for _, p := range o.Powers {
+		if my := skip(InternalSetPowerOrder(gid, fid, p.UpPower, p.Loc)); my != nil {
+			return my
+		}
+	}
+	kept := map[hexagon.Coord]bool{}
+	for _, t := range o.Truces {
+		kept[t.Loc] = true
+		if my := skip(InternalSetTruce(&TruceCommand{GID: gid, FID: fid, Loc: t.Loc, Trucees: t.Trucees})); my != nil {
+			return my
+		}
+	}
+	for _, tr := range truces {
+		if loc := tr.Loc(); !kept[loc] {
+			kept[loc] = true
+			if my := skip(InternalSetTruce(&TruceCommand{GID: gid, FID: fid, Loc: loc})); my != nil {
+				return my
+			}
+		}
+	}
+	return nil
+}
@@ -2,12 +2,14 @@ package main
 
 import (
 	"mule/overpower"
+	"mule/overpower/bots"
 	"mule/overpower/models"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func (h *Handler) CommandStartGame(g overpower.GameDat, facs []overpower.FactionDat, exodus bool) (errServer, errUser error) {
+func (h *Handler) CommandStartGame(g overpower.GameDat, facs []overpower.FactionDat, generator string) (errServer, errUser error) {
 	if g == nil {
 		return nil, NewError("USER HAS NO GAME TO START")
 	}
@@ -17,17 +19,55 @@ func (h *Handler) CommandStartGame(g overpower.GameDat, facs []overpower.Faction
 	if len(facs) < 1 {
 		return nil, NewError("GAME HAS NO PLAYERS")
 	}
+	if generator == "" {
+		generator = "standard"
+	}
+	if _, ok := overpower.GetGenerator(generator); !ok {
+		return nil, NewError("UNKNOWN GALAXY GENERATOR")
+	}
 	f := func(source overpower.Source) (logE, failE error) {
-		return nil, overpower.MakeGalaxy(source, exodus)
+		return nil, overpower.MakeGalaxy(source, generator)
 	}
-	logE, failE := OPDB.SourceTransact(g.GID(), f)
+	logE, failE := OPDB.TurnTransact(g.GID(), f)
 	if my, bad := Check(failE, "command startgame failure", "gid", g.GID()); bad {
 		return my, nil
 	}
 	if logE != nil {
 		Log(logE)
 	}
-	return nil, nil
+	return InternalRunBots(g.GID()), nil
+}
+
+func (h *Handler) CommandRewindGame(g overpower.GameDat, turnStr string) (errServer, errUser error) {
+	if g == nil {
+		return nil, NewError("USER HAS NO GAME TO REWIND")
+	}
+	if g.Turn() < 1 {
+		return nil, NewError("GAME HAS NOT YET BEGUN")
+	}
+	turnI, err := strconv.Atoi(turnStr)
+	if err != nil || turnI < 1 || turnI >= g.Turn() {
+		return nil, NewError("INVALID TURN TO REWIND TO")
+	}
+	turns, err := OPDB.SnapshotTurns(g.GID())
+	if my, bad := Check(err, "command rewind failure on snapshot lookup", "gid", g.GID()); bad {
+		return my, nil
+	}
+	var found bool
+	for _, t := range turns {
+		if t == turnI {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, NewError("NO SNAPSHOT KEPT FOR THAT TURN")
+	}
+	err = OPDB.RewindGame(g.GID(), turnI)
+	if my, bad := Check(err, "command rewind failure", "gid", g.GID(), "turn", turnI); bad {
+		return my, nil
+	}
+	return InternalRunBots(g.GID()), nil
 }
 
 func (h *Handler) CommandSetAutos(g overpower.GameDat, dayBools [7]bool) (errServer, errUser error) {
@@ -54,7 +94,28 @@ func (h *Handler) CommandDropGame(g overpower.GameDat) (errServer, errUser error
 	return nil, nil
 }
 
-func (h *Handler) CommandNewGame(g overpower.GameDat, password, gamename, facname, towin string) (errServer, errUser error) {
+// ParseRules reads the rules section of the new game form, as given by
+// form.  Fields left blank keep their standard values.
+func ParseRules(form func(string) string) (overpower.GameRules, error) {
+	rules := overpower.DefaultRules()
+	for _, field := range overpower.RuleFields {
+		str := strings.TrimSpace(form(field.Name))
+		if str == "" {
+			continue
+		}
+		val, err := strconv.Atoi(str)
+		if err != nil {
+			return rules, NewError("UNPARSABLE RULE VALUE: " + strings.ToUpper(field.Name))
+		}
+		*field.Of(&rules) = val
+	}
+	if err := rules.Validate(); err != nil {
+		return rules, NewError("INVALID RULES: " + strings.ToUpper(err.Error()))
+	}
+	return rules, nil
+}
+
+func (h *Handler) CommandNewGame(g overpower.GameDat, password, gamename, facname, towin string, ruleForm func(string) string) (errServer, errUser error) {
 	if g != nil {
 		return nil, NewError("USER ALREADY HAS GAME IN PROGRESS")
 	}
@@ -71,17 +132,23 @@ func (h *Handler) CommandNewGame(g overpower.GameDat, password, gamename, facnam
 	if ok != nil || winI < 2 {
 		return nil, NewError("INVALID GAME WIN THRESHOLD")
 	}
+	rules, err := ParseRules(ruleForm)
+	if err != nil {
+		return nil, err
+	}
 	newG := &models.Game{
 		Owner: h.User.String(),
 		Name:  gamename,
 		ToWin: winI,
+		Seed:  time.Now().UnixNano(),
+		Rules: rules,
 	}
 	if password != "" {
 		newG.Password.Valid = true
 		newG.Password.String = password
 	}
 	h.M.CreateGame(newG)
-	err := h.M.Close()
+	err = h.M.Close()
 	if my, bad := Check(err, "make game failure", "user", h.User, "gamename", gamename, "password", password, "towin", winI); bad {
 		return my, nil
 	}
@@ -150,11 +217,130 @@ func (h *Handler) CommandNewFaction(g overpower.GameDat, facs []overpower.Factio
 	return nil, nil
 }
 
+// CommandNewBot adds a faction played by the named bot strategy.  Each bot
+// is given an owner name no user can have.
+func (h *Handler) CommandNewBot(g overpower.GameDat, facs []overpower.FactionDat, strategy, facname string) (errServer, errUser error) {
+	if g.Owner() != h.User.String() {
+		return nil, NewError("ONLY THE GAME OWNER MAY ADD BOTS")
+	}
+	if g.Turn() > 0 {
+		return nil, NewError("GAME IN PROGRESS")
+	}
+	if _, ok := bots.Get(strategy); !ok {
+		return nil, NewError("UNKNOWN BOT STRATEGY")
+	}
+	if !ValidText(facname) {
+		return nil, NewError("BAD FACTION NAME")
+	}
+	lwFName := strings.ToLower(facname)
+	for _, f := range facs {
+		if strings.ToLower(f.Name()) == lwFName {
+			return nil, NewError("FACTION NAME ALREADY IN USE FOR THIS GAME")
+		}
+	}
+	newF := &models.Faction{
+		GID:   g.GID(),
+		Owner: botOwner(facs),
+		Name:  facname,
+		Bot:   strategy,
+	}
+	h.M.CreateFaction(newF)
+	err := h.M.Close()
+	if my, bad := Check(err, "data creation error", "type", "faction", "gid", g.GID(), "bot", strategy, "facname", facname); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
+// botOwner gives an owner name for a new bot faction that no user and no
+// other faction of the game has.
+func botOwner(facs []overpower.FactionDat) string {
+	owners := make(map[string]bool, len(facs))
+	for _, f := range facs {
+		owners[f.Owner()] = true
+	}
+	var owner string
+	for i := 1; owner == "" || owners[owner]; i++ {
+		owner = "~bot" + strconv.Itoa(i)
+	}
+	return owner
+}
+
+// CommandBotFaction hands a human faction of a running game to the named
+// bot strategy, so a game whose player has gone need not stall.  The bot
+// gives the faction's orders from the current turn on.
+func (h *Handler) CommandBotFaction(g overpower.GameDat, facs []overpower.FactionDat, fidStr, strategy string) (errServer, errUser error) {
+	if g.Owner() != h.User.String() {
+		return nil, NewError("ONLY THE GAME OWNER MAY HAND FACTIONS TO BOTS")
+	}
+	if g.Turn() < 1 {
+		return nil, NewError("GAME HAS NOT YET BEGUN")
+	}
+	if overpower.GameOver(g) {
+		return nil, NewError("GAME IS OVER")
+	}
+	if _, ok := bots.Get(strategy); !ok {
+		return nil, NewError("UNKNOWN BOT STRATEGY")
+	}
+	fid, err := strconv.Atoi(fidStr)
+	if err != nil {
+		return nil, NewError("UNPARSABLE FACTION ID")
+	}
+	var fac overpower.FactionDat
+	for _, f := range facs {
+		if f.FID() == fid && f.Bot() == "" {
+			fac = f
+			break
+		}
+	}
+	if fac == nil {
+		return nil, NewError("NO PLAYER FACTION WITH THAT ID")
+	}
+	fac.SetOwner(botOwner(facs))
+	fac.SetBot(strategy)
+	fac.SetDoneBuffer(0)
+	err = h.M.Close()
+	if my, bad := Check(err, "command bot faction failure", "game", g, "faction", fac); bad {
+		return my, nil
+	}
+	return InternalRunBots(g.GID()), nil
+}
+
+func (h *Handler) CommandDropBot(g overpower.GameDat, facs []overpower.FactionDat, fidStr string) (errServer, errUser error) {
+	if g.Owner() != h.User.String() {
+		return nil, NewError("ONLY THE GAME OWNER MAY DROP BOTS")
+	}
+	if g.Turn() > 0 {
+		return nil, NewError("GAME IN PROGRESS")
+	}
+	fid, err := strconv.Atoi(fidStr)
+	if err != nil {
+		return nil, NewError("UNPARSABLE FACTION ID")
+	}
+	var bot overpower.FactionDat
+	for _, f := range facs {
+		if f.FID() == fid && f.Bot() != "" {
+			bot = f
+			break
+		}
+	}
+	if bot == nil {
+		return nil, NewError("NO BOT FACTION WITH THAT ID")
+	}
+	bot.DELETE()
+	err = h.M.Close()
+	if my, bad := Check(err, "command drop bot failure", "game", g, "faction", bot); bad {
+		return my, nil
+	}
+	return nil, nil
+}
+
 func (h *Handler) CommandQuitGame(g overpower.GameDat, f overpower.FactionDat, turnStr string) (errServer, errUser error) {
 	turnI, err := strconv.Atoi(turnStr)
 	if err != nil || turnI != g.Turn() {
 		return nil, NewError("FORM SUBMISSION TURN DOES NOT MATCH GAME TURN")
 	}
+	overpower.RecordQuit(g, f.FID())
 	f.DELETE()
 	return h.M.Close(), nil
 }
@@ -163,6 +349,9 @@ func (h *Handler) CommandSetDoneBuffer(g overpower.GameDat, f overpower.FactionD
 	if g.Turn() < 1 {
 		return nil, NewError("GAME HAS NOT YET BEGUN")
 	}
+	if overpower.GameOver(g) {
+		return nil, NewError("GAME IS OVER")
+	}
 	turnI, err := strconv.Atoi(turnStr)
 	if err != nil || turnI != g.Turn() {
 		return nil, NewError("FORM SUBMISSION TURN DOES NOT MATCH GAME TURN")
@@ -187,16 +376,19 @@ func (h *Handler) CommandForceTurn(g overpower.GameDat, turnStr string) (errServ
 	if g.Turn() < 1 {
 		return nil, NewError("GAME HAS NOT YET BEGUN")
 	}
+	if overpower.GameOver(g) {
+		return nil, NewError("GAME IS OVER")
+	}
 	turnI, err := strconv.Atoi(turnStr)
 	if err != nil || turnI != g.Turn() {
 		return nil, NewError("FORM SUBMISSION TURN DOES NOT MATCH GAME TURN")
 	}
-	logE, failE := OPDB.SourceTransact(g.GID(), overpower.RunGameTurn)
+	logE, failE := OPDB.TurnTransact(g.GID(), overpower.RunGameTurn)
 	if my, bad := Check(failE, "failure on running turn", "gid", g.GID()); bad {
 		return my, nil
 	}
 	if logE != nil {
 		Log(logE)
 	}
-	return nil, nil
+	return InternalRunBots(g.GID()), nil
 }
@@ -8,15 +8,24 @@ import (
 type FullView struct {
 	Game overpower.GameDat `json:"game"`
 	//	Faction       overpower.FactionDat        `json:"faction"`
-	Factions      []overpower.FactionDat      `json:"factions"`
-	PlanetViews   []overpower.PlanetViewDat   `json:"planetviews"`
-	ShipViews     []overpower.ShipViewDat     `json:"shipviews"`
-	LaunchOrders  []overpower.LaunchOrderDat  `json:"launchorders"`
-	PowerOrder    overpower.PowerOrderDat     `json:"powerorder"`
-	LaunchRecords []overpower.LaunchRecordDat `json:"launchrecords"`
-	BattleRecords []overpower.BattleRecordDat `json:"battlerecords"`
-	MapView       overpower.MapViewDat        `json:"mapview"`
-	Truces        []overpower.TruceDat        `json:"truces"`
+	Factions       []overpower.FactionDat          `json:"factions"`
+	PlanetViews    []overpower.PlanetViewDat       `json:"planetviews"`
+	ShipViews      []overpower.ShipViewDat         `json:"shipviews"`
+	LaunchOrders   []overpower.LaunchOrderDat      `json:"launchorders"`
+	ShipOrders     []overpower.ShipOrderDat        `json:"shiporders"`
+	StandingOrders []overpower.StandingOrderDat    `json:"standingorders"`
+	Conditionals   []overpower.ConditionalOrderDat `json:"conditionalorders"`
+	PowerOrders    []overpower.PowerOrderDat       `json:"powerorders"`
+	LaunchRecords  []overpower.LaunchRecordDat     `json:"launchrecords"`
+	BattleRecords  []overpower.BattleRecordDat     `json:"battlerecords"`
+	ConditionRecs  []overpower.ConditionRecordDat  `json:"conditionrecords"`
+	MapView        overpower.MapViewDat            `json:"mapview"`
+	Truces         []overpower.TruceDat            `json:"truces"`
+	Treaties       []overpower.TreatyDat           `json:"treaties"`
+	TreatyBreaks   []overpower.TreatyBreakDat      `json:"treatybreaks"`
+	VisionGrants   []overpower.VisionGrantDat      `json:"visiongrants"`
+	Messages       []overpower.MessageDat          `json:"messages"`
+	LastRead       int                             `json:"lastread"`
 }
 
 func (h *Handler) GetFullView(gid int) (fv *FullView, errS, errU error) {
@@ -60,14 +69,20 @@ func (h *Handler) GetFullView(gid int) (fv *FullView, errS, errU error) {
 	batRec, err6 := h.M.BattleRecord().SelectWhere(wTURN)
 	powOrds, err7 := h.M.PowerOrder().SelectWhere(wFID)
 	truces, err8 := h.M.Truce().SelectWhere(wFID)
-	for i, err := range []error{err1, err2, err3, err4, err5, err6, err7, err8} {
+	shipOrders, err9 := h.M.ShipOrder().SelectWhere(wFID)
+	standing, err10 := h.M.StandingOrder().SelectWhere(wFID)
+	conditionals, err11 := h.M.ConditionalOrder().SelectWhere(wFID)
+	conRec, err12 := h.M.ConditionRecord().SelectWhere(wTURN)
+	treaties, err13 := h.M.Treaty().SelectWhere(h.PARTY(gid, userF.FID()))
+	breaks, err14 := h.M.TreatyBreak().SelectWhere(h.PARTY(gid, userF.FID()))
+	msgs, err15 := h.M.Message().SelectVisible(gid, userF.FID())
+	reads, err16 := h.M.MessageRead().SelectWhere(wFID)
+	grants, err17 := h.M.VisionGrant().SelectWhere(h.GRANT(gid, userF.FID()))
+	for i, err := range []error{err1, err2, err3, err4, err5, err6, err7, err8, err9, err10, err11, err12, err13, err14, err15, err16, err17} {
 		if my, bad := Check(err, "fill fullview failure", "index", i, "gid", gid, "fid", userF.FID(), "turn", turn); bad {
 			return nil, my, nil
 		}
 	}
-	if len(powOrds) == 0 {
-		return nil, NewError("FILL FULLVIEW FAILED TO FIND POWERORDER"), nil
-	}
 	if len(mapviews) == 0 {
 		return nil, NewError("FILL FULLVIEW FAILED TO FIND MAPVIEWS"), nil
 	}
@@ -75,18 +90,32 @@ func (h *Handler) GetFullView(gid int) (fv *FullView, errS, errU error) {
 	sortLARecords(laRec)
 	sortLDRecords(batRec)
 	sortFactions(facs)
+	sortMessages(msgs)
+	var lastRead int
+	if len(reads) != 0 {
+		lastRead = reads[0].LastRead()
+	}
 	fv = &FullView{
 		Game: g,
 		//Faction:       userF,
-		Factions:      facs,
-		PlanetViews:   plVs,
-		ShipViews:     shVs,
-		LaunchOrders:  launchOrders,
-		PowerOrder:    powOrds[0],
-		Truces:        truces,
-		MapView:       mapviews[0],
-		LaunchRecords: laRec,
-		BattleRecords: batRec,
+		Factions:       facs,
+		PlanetViews:    plVs,
+		ShipViews:      shVs,
+		LaunchOrders:   launchOrders,
+		ShipOrders:     shipOrders,
+		StandingOrders: standing,
+		Conditionals:   conditionals,
+		PowerOrders:    powOrds,
+		Truces:         truces,
+		Treaties:       treaties,
+		TreatyBreaks:   breaks,
+		VisionGrants:   grants,
+		Messages:       msgs,
+		LastRead:       lastRead,
+		MapView:        mapviews[0],
+		LaunchRecords:  laRec,
+		BattleRecords:  batRec,
+		ConditionRecs:  conRec,
 	}
 	return fv, nil, nil
 }
@@ -100,10 +129,14 @@ func sortLDRecords(list []overpower.BattleRecordDat) {
 func sortFactions(list []overpower.FactionDat) {
 	sort.Sort(sortFA(list))
 }
+func sortMessages(list []overpower.MessageDat) {
+	sort.Sort(sortMS(list))
+}
 
 type sortLA []overpower.LaunchRecordDat
 type sortLD []overpower.BattleRecordDat
 type sortFA []overpower.FactionDat
+type sortMS []overpower.MessageDat
 
 func (s sortLA) Len() int {
 	return len(s)
@@ -145,3 +178,13 @@ func (s sortFA) Swap(i, j int) {
 func (s sortFA) Less(i, j int) bool {
 	return s[i].FID() < s[j].FID()
 }
+
+func (s sortMS) Len() int {
+	return len(s)
+}
+func (s sortMS) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s sortMS) Less(i, j int) bool {
+	return s[i].MID() < s[j].MID()
+}
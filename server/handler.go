@@ -61,21 +61,27 @@ func (h *Handler) GID(gid int) sq.Condition {
 func (h *Handler) FID(gid, fid int) sq.Condition {
 	return sq.AND(sq.EQ("gid", gid), sq.EQ("fid", fid))
 }
+func (h *Handler) PARTY(gid, fid int) sq.Condition {
+	return sq.AND(sq.EQ("gid", gid), sq.OR(sq.EQ("fid", fid), sq.EQ("partner", fid)))
+}
+func (h *Handler) GRANT(gid, fid int) sq.Condition {
+	return sq.AND(sq.EQ("gid", gid), sq.OR(sq.EQ("fid", fid), sq.EQ("grantee", fid)))
+}
 func (h *Handler) TURN(gid, fid, turn int) sq.Condition {
 	return sq.AND(sq.EQ("gid", gid), sq.EQ("fid", fid), sq.EQ("turn", turn))
 }
 
-func (h *Handler) FetchBasicData(gid int) (g overpower.GameDat, f overpower.FactionDat, facs []overpower.FactionDat, err error) {
+func (h *Handler) FetchBasicData(gid int) (g overpower.GameDat, f overpower.FactionDat, facs []overpower.FactionDat, unread int, err error) {
 	games, err := h.M.Game().SelectWhere(h.GID(gid))
 	if my, bad := Check(err, "handler basic fetch failure on resource aquisition", "resource", "games", "gid", gid); bad {
-		return nil, nil, nil, my
+		return nil, nil, nil, 0, my
 	}
 	if len(games) != 0 {
 		g = games[0]
 	}
 	facs, err = h.M.Faction().SelectWhere(h.GID(gid))
 	if my, bad := Check(err, "handler basic fetch failure on resource aquisition", "resource", "factions", "gid", gid, "owner"); bad {
-		return nil, nil, nil, my
+		return nil, nil, nil, 0, my
 	}
 	if h.LoggedIn {
 		for _, testF := range facs {
@@ -85,7 +91,37 @@ func (h *Handler) FetchBasicData(gid int) (g overpower.GameDat, f overpower.Fact
 			}
 		}
 	}
-	return g, f, facs, nil
+	if f != nil {
+		unread, err = h.Unread(gid, f.FID())
+		if my, bad := Check(err, "handler basic fetch failure on resource aquisition", "resource", "messages", "gid", gid, "fid", f.FID()); bad {
+			return nil, nil, nil, 0, my
+		}
+	}
+	return g, f, facs, unread, nil
+}
+
+// Unread counts the messages sent to fid, broadcasts included, that fid
+// has not yet marked read.
+func (h *Handler) Unread(gid, fid int) (int, error) {
+	reads, err := h.M.MessageRead().SelectWhere(h.FID(gid, fid))
+	if err != nil {
+		return 0, err
+	}
+	var lastRead int
+	if len(reads) != 0 {
+		lastRead = reads[0].LastRead()
+	}
+	msgs, err := h.M.Message().SelectVisible(gid, fid)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, msg := range msgs {
+		if msg.MID() > lastRead && msg.FID() != fid {
+			count++
+		}
+	}
+	return count, nil
 }
 
 func (h *Handler) Validate(gid int, fids ...int) (f overpower.FactionDat, ok bool, err error) {
@@ -1,11 +1,10 @@
 package main
 
 import (
-	"math/rand"
+	"mule/overpower"
 	"mule/overpower/models"
 	"mule/users"
 	"net/http"
-	"time"
 )
 
 const (
@@ -19,10 +18,6 @@ var (
 	DBLOCK  bool
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 func main() {
 	var err error
 	USERREG, err = users.GetRegistry()
@@ -37,6 +32,10 @@ func main() {
 		panic(my)
 	}
 	defer OPDB.Close()
+	err = overpower.RegisterMapTemplates(DATADIR + "maps")
+	if my, bad := Check(err, "failed to load map templates"); bad {
+		Log(my)
+	}
 	go AutoTimer()
 	SetupMux()
 	Announce("STARTING SERVER AT", SERVPORT)
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"mule/overpower/models"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// makeMessageGame makes a fresh three faction game on turn 4.
+func makeMessageGame(t *testing.T) (gid int, fids [3]int) {
+	g := &models.Game{
+		Owner: "Testing_Messages",
+		Name:  "MessageGame",
+		Turn:  4,
+		ToWin: 100,
+	}
+	facs := make([]*models.Faction, len(fids))
+	_, failE := OPDB.Transact(func(m *models.Manager) (logE, failE error) {
+		old, err := m.Game().Select("owner", g.Owner)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range old {
+			o.DELETE()
+		}
+		if err = m.Close(); err != nil {
+			return nil, err
+		}
+		m.CreateGame(g)
+		if err = m.Close(); err != nil {
+			return nil, err
+		}
+		for i := range facs {
+			facs[i] = &models.Faction{
+				GID:   g.GID,
+				Owner: fmt.Sprintf("Messenger%d", i),
+				Name:  fmt.Sprintf("Messenger%d", i),
+			}
+			m.CreateFaction(facs[i])
+		}
+		return nil, m.Close()
+	})
+	if failE != nil {
+		t.Fatal("make game failed:", failE)
+	}
+	for i, f := range facs {
+		fids[i] = f.FID
+	}
+	return g.GID, fids
+}
+
+func TestMessages(t *testing.T) {
+	d, err := models.LoadDB()
+	if err != nil {
+		t.Skip("no database:", err)
+	}
+	OPDB = d
+	gid, fids := makeMessageGame(t)
+	a, b, c := fids[0], fids[1], fids[2]
+	send := func(fid, recipient int, body string) {
+		if errS, errU := InternalSendMessage(&MessageCommand{GID: gid, FID: fid, Recipient: recipient, Body: body}); errS != nil || errU != nil {
+			t.Fatal("send failed:", errS, errU)
+		}
+	}
+	send(a, 0, "to all")
+	send(a, b, "a to b")
+	send(b, a, "b to a")
+	lastMID := map[int]int{}
+	for fid, want := range map[int][]string{
+		a: {"a to b", "b to a", "to all"},
+		b: {"a to b", "b to a", "to all"},
+		c: {"to all"},
+	} {
+		msgs, err := OPDB.NewManager().Message().SelectVisible(gid, fid)
+		if err != nil {
+			t.Fatal("select visible failed:", err)
+		}
+		var got []string
+		for _, msg := range msgs {
+			got = append(got, msg.Body())
+			if msg.Turn() != 4 {
+				t.Errorf("message %q tagged turn %d, want 4", msg.Body(), msg.Turn())
+			}
+			if msg.MID() > lastMID[fid] {
+				lastMID[fid] = msg.MID()
+			}
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("faction %d sees %v, want %v", fid, got, want)
+		}
+	}
+	unread := func(fid, want int, when string) {
+		h := &Handler{M: OPDB.NewManager()}
+		got, err := h.Unread(gid, fid)
+		if err != nil {
+			t.Fatal("unread failed:", err)
+		}
+		if got != want {
+			t.Errorf("%s: faction %d has %d unread, want %d", when, fid, got, want)
+		}
+	}
+	// a's own broadcast is not unread to it
+	unread(a, 1, "before reading")
+	unread(b, 2, "before reading")
+	unread(c, 1, "before reading")
+	if errS, errU := InternalReadMessages(gid, b, lastMID[b]); errS != nil || errU != nil {
+		t.Fatal("read failed:", errS, errU)
+	}
+	unread(b, 0, "after reading")
+	unread(a, 1, "after b read")
+	send(c, 0, "late")
+	unread(b, 1, "after a later broadcast")
+	unread(c, 1, "after its own broadcast")
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"mule/overpower"
 	"net/http"
+	"sort"
 )
 
 var (
@@ -66,14 +67,17 @@ func pageOPHome(w http.ResponseWriter, r *http.Request) {
 			errS, errU = h.CommandSetAutos(g, dayBool)
 
 		case "startgame":
-			exodus := r.FormValue("exodus") == "on"
-			errS, errU = h.CommandStartGame(g, gFacs, exodus)
+			generator := r.FormValue("generator")
+			errS, errU = h.CommandStartGame(g, gFacs, generator)
 		case "newgame":
 			gamename, password := r.FormValue("gamename"), r.FormValue("password")
 			facname, towin := r.FormValue("facname"), r.FormValue("towin")
-			errS, errU = h.CommandNewGame(g, password, gamename, facname, towin)
+			errS, errU = h.CommandNewGame(g, password, gamename, facname, towin, r.FormValue)
 		case "dropgame":
 			errS, errU = h.CommandDropGame(g)
+		case "rewind":
+			turn := r.FormValue("turn")
+			errS, errU = h.CommandRewindGame(g, turn)
 		default:
 			errU = NewError("UNKNOWN ACTION TYPE")
 		}
@@ -91,9 +95,38 @@ func pageOPHome(w http.ResponseWriter, r *http.Request) {
 	if hasG {
 		m["game"] = g
 		m["active"] = g.Turn() > 0
+		if g.Turn() < 1 {
+			m["generators"] = overpower.GeneratorNames()
+		}
+		if g.Turn() > 1 {
+			turns, err := OPDB.SnapshotTurns(g.GID())
+			if my, bad := Check(err, "resource error in homepage", "resource", "snapshots", "gid", g.GID()); bad {
+				h.HandleServerError(w, r, my)
+				return
+			}
+			if len(turns) > 0 && turns[len(turns)-1] == g.Turn() {
+				turns = turns[:len(turns)-1]
+			}
+			if len(turns) > 0 {
+				m["snapturns"] = turns
+			}
+		}
 	}
 	if gHasF {
 		m["gfactions"] = gFacs
+		if g.Turn() > 0 {
+			reaches, err := h.M.HomeReach().SelectWhere(h.GID(g.GID()))
+			if my, bad := Check(err, "resource error in homepage", "resource", "homereach", "gid", g.GID()); bad {
+				h.HandleServerError(w, r, my)
+				return
+			}
+			if len(reaches) > 0 {
+				rows, spread := makeReachRows(reaches, gFacs)
+				m["reachrows"] = rows
+				m["reachspread"] = spread
+				m["reachdist"] = reaches[0].Reach()
+			}
+		}
 		days := g.AutoDays()
 		var any bool
 		for _, b := range days {
@@ -126,5 +159,45 @@ func pageOPHome(w http.ResponseWriter, r *http.Request) {
 		m["ofactions"] = oFacs
 		m["ogames"] = facGames
 	}
+	if !hasG {
+		m["rules"] = overpower.DefaultRules()
+	}
 	h.Apply(TPOPHOME, w)
 }
+
+type reachRow struct {
+	Name       string
+	Planets    int
+	Antimatter int
+	Tachyons   int
+}
+
+type sortReachRows []reachRow
+
+func (s sortReachRows) Len() int           { return len(s) }
+func (s sortReachRows) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortReachRows) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+func makeReachRows(reaches []overpower.HomeReachDat, facs []overpower.FactionDat) ([]reachRow, int) {
+	names := make(map[int]string, len(facs))
+	for _, f := range facs {
+		names[f.FID()] = f.Name()
+	}
+	rows := make([]reachRow, 0, len(reaches))
+	list := make([]overpower.Reach, 0, len(reaches))
+	for _, hr := range reaches {
+		rows = append(rows, reachRow{
+			Name:       names[hr.FID()],
+			Planets:    hr.Planets(),
+			Antimatter: hr.Antimatter(),
+			Tachyons:   hr.Tachyons(),
+		})
+		list = append(list, overpower.Reach{
+			Planets:    hr.Planets(),
+			Antimatter: hr.Antimatter(),
+			Tachyons:   hr.Tachyons(),
+		})
+	}
+	sort.Sort(sortReachRows(rows))
+	return rows, overpower.ReachSpread(list)
+}
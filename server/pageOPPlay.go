@@ -24,7 +24,7 @@ func pageOPPlay(w http.ResponseWriter, r *http.Request) {
 		h.HandleUserError(w, r, "NO/BAD GAME ID SPECIFIED")
 		return
 	}
-	g, f, _, err := h.FetchBasicData(gid)
+	g, f, _, unread, err := h.FetchBasicData(gid)
 	if my, bad := Check(err, "page play failure on resource aquisition", "gid", gid); bad {
 		h.HandleServerError(w, r, my)
 		return
@@ -45,6 +45,7 @@ func pageOPPlay(w http.ResponseWriter, r *http.Request) {
 	m := h.DefaultApp()
 	m["game"] = g
 	m["faction"] = f
+	m["unread"] = unread
 	TPPLAY := MixTemp("frame", "titlebar", "opplay")
 	h.Apply(TPPLAY, w)
 }
@@ -29,7 +29,7 @@ func pageOPQuit(w http.ResponseWriter, r *http.Request) {
 		h.HandleUserError(w, r, "NO/BAD GAME ID SPECIFIED")
 		return
 	}
-	g, f, _, err := h.FetchBasicData(gid)
+	g, f, _, _, err := h.FetchBasicData(gid)
 	if my, bad := Check(err, "page quit failure on resource aquisition", "gid", gid); bad {
 		h.HandleServerError(w, r, my)
 		return
@@ -2,7 +2,9 @@ package main
 
 import (
 	"mule/overpower"
+	"mule/overpower/bots"
 	"net/http"
+	"sort"
 )
 
 var (
@@ -38,8 +40,19 @@ func pageOPView(w http.ResponseWriter, r *http.Request) {
 		h.HandleServerError(w, r, my)
 		return
 	}
+	allFacs := make([]overpower.FactionDat, len(facs))
+	copy(allFacs, facs)
 	m["factions"] = facs
 	m["active"] = g.Turn() > 0
+	if overpower.GameOver(g) {
+		standings, err := h.M.Standing().SelectWhere(h.GID(gid))
+		if my, bad := Check(err, "resource failure", "page", "opview", "resource", "standings", "gid", gid); bad {
+			h.HandleServerError(w, r, my)
+			return
+		}
+		m["over"] = true
+		m["results"] = makeResults(standings, facs)
+	}
 	var ownedF overpower.FactionDat
 	if h.LoggedIn {
 		m["user"] = h.User.String()
@@ -52,6 +65,12 @@ func pageOPView(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	if h.LoggedIn && g.Owner() == h.User.String() && g.Turn() < 1 {
+		m["botstrategies"] = bots.Names()
+	}
+	if h.LoggedIn && g.Owner() == h.User.String() && g.Turn() > 0 && !overpower.GameOver(g) {
+		m["takeover"] = bots.Names()
+	}
 	if len(facs) > 1 || (len(facs) > 0 && ownedF == nil) {
 		m["otherf"] = true
 	}
@@ -74,6 +93,12 @@ func pageOPView(w http.ResponseWriter, r *http.Request) {
 			errS, errU = h.CommandDropFaction(g, ownedF)
 		case "newfac":
 			errS, errU = h.CommandNewFaction(g, facs, ownedF, r.FormValue("password"), r.FormValue("facname"))
+		case "newbot":
+			errS, errU = h.CommandNewBot(g, allFacs, r.FormValue("strategy"), r.FormValue("facname"))
+		case "dropbot":
+			errS, errU = h.CommandDropBot(g, allFacs, r.FormValue("fid"))
+		case "botfaction":
+			errS, errU = h.CommandBotFaction(g, allFacs, r.FormValue("fid"), r.FormValue("strategy"))
 		default:
 			errU = NewError("UNKNOWN ACTION TYPE")
 		}
@@ -99,3 +124,45 @@ func pageOPView(w http.ResponseWriter, r *http.Request) {
 	}
 	h.Apply(TPOPVIEW, w)
 }
+
+type resultRow struct {
+	Rank     int
+	Name     string
+	Owner    string
+	Planets  int
+	Presence int
+	Ships    int
+}
+
+type sortResults []resultRow
+
+func (s sortResults) Len() int      { return len(s) }
+func (s sortResults) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortResults) Less(i, j int) bool {
+	if s[i].Rank != s[j].Rank {
+		return s[i].Rank < s[j].Rank
+	}
+	return s[i].Name < s[j].Name
+}
+
+func makeResults(standings []overpower.StandingDat, facs []overpower.FactionDat) []resultRow {
+	facMap := make(map[int]overpower.FactionDat, len(facs))
+	for _, f := range facs {
+		facMap[f.FID()] = f
+	}
+	rows := make([]resultRow, 0, len(standings))
+	for _, st := range standings {
+		row := resultRow{
+			Rank:     st.Rank(),
+			Planets:  st.Planets(),
+			Presence: st.Presence(),
+			Ships:    st.Ships(),
+		}
+		if f, ok := facMap[st.FID()]; ok {
+			row.Name, row.Owner = f.Name(), f.Owner()
+		}
+		rows = append(rows, row)
+	}
+	sort.Sort(sortResults(rows))
+	return rows
+}
@@ -37,7 +37,7 @@ func AutoTimer() {
 		countChan := make(chan byte)
 		wkDay := int(now.Weekday())
 		for _, g := range games {
-			if g.Turn() < 1 {
+			if g.Turn() < 1 || overpower.GameOver(g) {
 				continue
 			}
 			days := g.AutoDays()
@@ -48,13 +48,16 @@ func AutoTimer() {
 					count++
 					go func(g overpower.GameDat, done chan byte) {
 						Announce("AUTO RUNNING GAME", g.GID())
-						logE, failE := OPDB.SourceTransact(g.GID(), overpower.RunGameTurn)
+						logE, failE := OPDB.TurnTransact(g.GID(), overpower.RunGameTurn)
 						if my, bad := Check(failE, "failure on auto-running turn", "gid", g.GID()); bad {
 							Log(my)
 						}
 						if logE != nil {
 							Log(logE)
 						}
+						if my := InternalRunBots(g.GID()); my != nil {
+							Log(my)
+						}
 						done <- 0
 					}(g, countChan)
 				}
@@ -0,0 +1,119 @@
+package overpower
+
+import (
+	"mule/hexagon"
+	"sort"
+)
+
+// Sources make no promise about the order of the lists they return, so
+// everything that draws randomness or creates records sorts its input
+// first to keep seeded games reproducible.
+
+func coordLess(a, b hexagon.Coord) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
+
+type sortFactions []FactionDat
+
+func (s sortFactions) Len() int           { return len(s) }
+func (s sortFactions) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortFactions) Less(i, j int) bool { return s[i].FID() < s[j].FID() }
+
+type sortPlanets []PlanetDat
+
+func (s sortPlanets) Len() int           { return len(s) }
+func (s sortPlanets) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortPlanets) Less(i, j int) bool { return coordLess(s[i].Loc(), s[j].Loc()) }
+
+type sortShips []ShipDat
+
+func (s sortShips) Len() int           { return len(s) }
+func (s sortShips) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortShips) Less(i, j int) bool { return s[i].SID() < s[j].SID() }
+
+type sortLaunchOrders []LaunchOrderDat
+
+func (s sortLaunchOrders) Len() int      { return len(s) }
+func (s sortLaunchOrders) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortLaunchOrders) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	if a.Source() != b.Source() {
+		return coordLess(a.Source(), b.Source())
+	}
+	return coordLess(a.Target(), b.Target())
+}
+
+type sortShipOrders []ShipOrderDat
+
+func (s sortShipOrders) Len() int      { return len(s) }
+func (s sortShipOrders) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortShipOrders) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	return a.SID() < b.SID()
+}
+
+type sortPowerOrders []PowerOrderDat
+
+func (s sortPowerOrders) Len() int      { return len(s) }
+func (s sortPowerOrders) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortPowerOrders) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	return coordLess(a.Loc(), b.Loc())
+}
+
+type sortTreaties []TreatyDat
+
+func (s sortTreaties) Len() int      { return len(s) }
+func (s sortTreaties) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortTreaties) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	return a.Partner() < b.Partner()
+}
+
+type sortVisionGrants []VisionGrantDat
+
+func (s sortVisionGrants) Len() int      { return len(s) }
+func (s sortVisionGrants) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortVisionGrants) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	return a.Grantee() < b.Grantee()
+}
+
+type sortConditionalOrders []ConditionalOrderDat
+
+func (s sortConditionalOrders) Len() int      { return len(s) }
+func (s sortConditionalOrders) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortConditionalOrders) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.FID() != b.FID() {
+		return a.FID() < b.FID()
+	}
+	return a.CID() < b.CID()
+}
+
+func sortedFids(radar map[int][]Sensor) []int {
+	fids := make([]int, 0, len(radar))
+	for fid, _ := range radar {
+		fids = append(fids, fid)
+	}
+	sort.Ints(fids)
+	return fids
+}
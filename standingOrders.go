@@ -0,0 +1,109 @@
+package overpower
+
+import (
+	"encoding/json"
+	"mule/hexagon"
+)
+
+// StandingLaunches turns the standing orders into launch orders for the
+// turn, leaving alone any route a faction gave a launch order of its own.
+// Only orders that launch use up one of their turns, so a route overridden
+// for a turn keeps its repetitions.  Orders for a source the faction no
+// longer holds, or out of turns, are dropped.
+func StandingLaunches(gid int, standing []StandingOrderDat, planets []PlanetDat, orders []LaunchOrderDat) []LaunchOrderDat {
+	if len(standing) == 0 {
+		return nil
+	}
+	planetGrid := make(map[hexagon.Coord]PlanetDat, len(planets))
+	for _, pl := range planets {
+		planetGrid[pl.Loc()] = pl
+	}
+	given := make(map[launchRoute]bool, len(orders))
+	for _, o := range orders {
+		given[launchRoute{o.FID(), o.Source(), o.Target()}] = true
+	}
+	var launches []LaunchOrderDat
+	for _, so := range standing {
+		fid := so.FID()
+		src, ok1 := planetGrid[so.Source()]
+		_, ok2 := planetGrid[so.Target()]
+		if !(ok1 && ok2) || (src.PrimaryFaction() != fid && src.SecondaryFaction() != fid) {
+			so.DELETE()
+			continue
+		}
+		size := so.Size()
+		if pct := so.Percent(); pct > 0 {
+			size = src.PresenceLevel(fid) * pct / 100
+		}
+		if size < 1 || given[launchRoute{fid, so.Source(), so.Target()}] {
+			continue
+		}
+		launches = append(launches, &standingLaunch{
+			gid:    gid,
+			fid:    fid,
+			source: so.Source(),
+			target: so.Target(),
+			size:   size,
+		})
+		if turns := so.Turns(); turns == 1 {
+			so.DELETE()
+		} else if turns > 1 {
+			so.SetTurns(turns - 1)
+		}
+	}
+	return launches
+}
+
+type launchRoute struct {
+	fid            int
+	source, target hexagon.Coord
+}
+
+// IsStandingLaunch reports whether a launch order was made for the turn by
+// StandingLaunches rather than given by its faction.
+func IsStandingLaunch(o LaunchOrderGet) bool {
+	_, ok := o.(*standingLaunch)
+	return ok
+}
+
+// standingLaunch is a launch order made for the turn from a standing
+// order; it is archived with the rest, marked as standing, but never
+// stored.
+type standingLaunch struct {
+	gid, fid       int
+	source, target hexagon.Coord
+	size           int
+}
+
+func (o *standingLaunch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		GID    int           `json:"gid"`
+		FID    int           `json:"fid"`
+		Source hexagon.Coord `json:"source"`
+		Target hexagon.Coord `json:"target"`
+		Size   int           `json:"size"`
+	}{o.gid, o.fid, o.source, o.target, o.size})
+}
+func (o *standingLaunch) UnmarshalJSON([]byte) error {
+	return nil
+}
+func (o *standingLaunch) DELETE() {}
+
+func (o *standingLaunch) GID() int {
+	return o.gid
+}
+func (o *standingLaunch) FID() int {
+	return o.fid
+}
+func (o *standingLaunch) Source() hexagon.Coord {
+	return o.source
+}
+func (o *standingLaunch) Target() hexagon.Coord {
+	return o.target
+}
+func (o *standingLaunch) Size() int {
+	return o.size
+}
+func (o *standingLaunch) SetSize(x int) {
+	o.size = x
+}
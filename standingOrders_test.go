@@ -0,0 +1,71 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"testing"
+)
+
+func TestStandingOrders(t *testing.T) {
+	s := makeTestGalaxy(t, 2)
+	home := s.HomePlanet(1)
+	target := nearestFree(s, home.Loc)
+	foreign := s.HomePlanet(2)
+	route := s.AddStandingOrder(1, 2, 0, 2, home.Loc, target.Loc)
+	lost := s.AddStandingOrder(1, 1, 0, 0, foreign.Loc, target.Loc)
+	launched := func() map[int]int {
+		sizes := map[int]int{}
+		for _, sh := range s.ShipList {
+			if sh.FID == 1 && sh.Path[len(sh.Path)-1] == target.Loc {
+				sizes[sh.Launched] += sh.Size
+			}
+		}
+		return sizes
+	}
+	turn := s.GameItem.Turn
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if got := launched()[turn]; got != 2 {
+		t.Errorf("standing order launched %d, want 2", got)
+	}
+	if route.Deleted || route.Turns != 1 {
+		t.Errorf("standing order left with %d turns, deleted %v; want 1 turn", route.Turns, route.Deleted)
+	}
+	if !lost.Deleted {
+		t.Error("standing order from a planet not held was kept")
+	}
+	if ar := s.Archive[len(s.Archive)-1]; len(ar.LaunchOrders) != 1 || ar.LaunchOrders[0].Size != 2 {
+		t.Errorf("archived launch orders %+v, want the standing launch", ar.LaunchOrders)
+	}
+	// a launch order of the faction's own replaces the standing one
+	s.AddLaunchOrder(1, 1, home.Loc, target.Loc)
+	turn = s.GameItem.Turn
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if got := launched()[turn]; got != 1 {
+		t.Errorf("launched %d with a launch order given, want 1", got)
+	}
+	if route.Deleted || route.Turns != 1 {
+		t.Errorf("overridden standing order left with %d turns, deleted %v; want 1 turn", route.Turns, route.Deleted)
+	}
+	turn = s.GameItem.Turn
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if got := launched()[turn]; got != 2 {
+		t.Errorf("standing order launched %d on its last turn, want 2", got)
+	}
+	if !route.Deleted {
+		t.Error("standing order kept past its turns")
+	}
+	pres := home.PrimaryPresence
+	s.AddStandingOrder(1, 0, 50, 0, home.Loc, target.Loc)
+	turn = s.GameItem.Turn
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if got, want := launched()[turn], pres/2; got != want {
+		t.Errorf("half presence order launched %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package overpower
+
+import (
+	"encoding/json"
+	"mule/hexagon"
+)
+
+// treatyTruces lays the truces of the accepted treaties over truceMap,
+// except where hostile withdraws one, and gives each faction's allies.  A
+// truce row already at a planet stays, wrapped so that losing it to a
+// betrayal also breaks the treaty: broken gets the betrayor, keyed by the
+// treaty's index in treaties.
+func treatyTruces(treaties []TreatyDat, planets []PlanetDat, truceMap map[hexagon.Coord]map[[2]int]TruceDat, hostile map[hexagon.Coord]map[[2]int]bool, broken map[int]int) map[int][]int {
+	allies := map[int][]int{}
+	for i, tr := range treaties {
+		if !tr.Accepted() {
+			continue
+		}
+		a, b := tr.FID(), tr.Partner()
+		if tr.Kind() == TREATYALLIANCE {
+			allies[a] = append(allies[a], b)
+			allies[b] = append(allies[b], a)
+		}
+		for _, pl := range planets {
+			if tr.Kind() == TREATYNONAGGRESSION {
+				pFid, sFid := pl.PrimaryFaction(), pl.SecondaryFaction()
+				if !((pFid == a || sFid == a) && (pFid == b || sFid == b)) {
+					continue
+				}
+			}
+			loc := pl.Loc()
+			mp, ok := truceMap[loc]
+			if !ok {
+				mp = map[[2]int]TruceDat{}
+				truceMap[loc] = mp
+			}
+			for _, pair := range [][2]int{{a, b}, {b, a}} {
+				if hostile[loc][pair] {
+					continue
+				}
+				mp[pair] = &treatyTruce{
+					gid:    tr.GID(),
+					fid:    pair[0],
+					trucee: pair[1],
+					loc:    loc,
+					row:    mp[pair],
+					index:  i,
+					broken: broken,
+				}
+			}
+		}
+	}
+	return allies
+}
+
+// sharedRadar gives each faction's radar joined with its allies', a
+// faction with no planets of its own seeing by its allies' alone.
+func sharedRadar(radar map[int][]Sensor, allies map[int][]int) map[int][]Sensor {
+	if len(allies) == 0 {
+		return radar
+	}
+	shared := make(map[int][]Sensor, len(radar))
+	for fid, list := range radar {
+		joined := append([]Sensor{}, list...)
+		for _, ally := range allies[fid] {
+			joined = append(joined, radar[ally]...)
+		}
+		shared[fid] = joined
+	}
+	for fid, list := range allies {
+		if _, ok := radar[fid]; ok {
+			continue
+		}
+		var joined []Sensor
+		for _, ally := range list {
+			joined = append(joined, radar[ally]...)
+		}
+		if len(joined) > 0 {
+			shared[fid] = joined
+		}
+	}
+	return shared
+}
+
+// treatyTruce is a truce a treaty gives at one planet.
+type treatyTruce struct {
+	gid, fid, trucee int
+	loc              hexagon.Coord
+	row              TruceDat
+	index            int
+	broken           map[int]int
+}
+
+func (t *treatyTruce) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		GID    int           `json:"gid"`
+		FID    int           `json:"fid"`
+		Loc    hexagon.Coord `json:"loc"`
+		Trucee int           `json:"trucee"`
+	}{t.gid, t.fid, t.loc, t.trucee})
+}
+func (t *treatyTruce) UnmarshalJSON([]byte) error {
+	return nil
+}
+func (t *treatyTruce) DELETE() {
+	if t.row != nil {
+		t.row.DELETE()
+	}
+	if _, ok := t.broken[t.index]; !ok {
+		t.broken[t.index] = t.trucee
+	}
+}
+
+func (t *treatyTruce) GID() int {
+	return t.gid
+}
+func (t *treatyTruce) FID() int {
+	return t.fid
+}
+func (t *treatyTruce) Loc() hexagon.Coord {
+	return t.loc
+}
+func (t *treatyTruce) Trucee() int {
+	return t.trucee
+}
+func (t *treatyTruce) Hostile() bool {
+	return false
+}
+func (t *treatyTruce) SetHostile(bool) {}
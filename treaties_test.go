@@ -0,0 +1,88 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestTreaties(t *testing.T) {
+	land := func(s *memsource.Source, fid, size int, pl *memsource.Planet) {
+		turn := s.GameItem.Turn
+		s.NewShip(fid, 9000+fid, size, overpower.ANTIMATTER, turn, pl.Loc.Ring(1)[0].PathTo(pl.Loc))
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+	}
+	// an offer not yet accepted gives no truce
+	s := makeTestGalaxy(t, 2)
+	home := s.HomePlanet(1)
+	s.AddTreaty(2, 1, overpower.TREATYPEACE, false)
+	land(s, 2, 1, home)
+	if home.SecondaryFaction == 2 {
+		t.Error("ship landed in peace under an unaccepted treaty")
+	}
+	// peace lets a partner land and join
+	s = makeTestGalaxy(t, 2)
+	home = s.HomePlanet(1)
+	pres := home.PrimaryPresence
+	peace := s.AddTreaty(2, 1, overpower.TREATYPEACE, true)
+	land(s, 2, 1, home)
+	if home.PrimaryPresence < pres || home.SecondaryFaction != 2 {
+		t.Errorf("peaceful landing left %+v, want faction 2 joined beside presence %d", *home, pres)
+	}
+	if peace.Deleted || len(s.TreatyBreakList) != 0 {
+		t.Error("treaty broken by a peaceful landing")
+	}
+	// a planet's hostile override betrays the treaty
+	s = makeTestGalaxy(t, 2)
+	home = s.HomePlanet(1)
+	peace = s.AddTreaty(2, 1, overpower.TREATYPEACE, true)
+	s.AddTruce(1, 2, home.Loc).Hostile = true
+	turn := s.GameItem.Turn
+	land(s, 2, 1, home)
+	if !peace.Deleted {
+		t.Error("betrayed treaty kept")
+	}
+	if len(s.TreatyBreakList) != 1 {
+		t.Fatalf("%d treaty breaks, want 1", len(s.TreatyBreakList))
+	}
+	if br := s.TreatyBreakList[0]; br.FID != 1 || br.Partner != 2 || br.Turn != turn || br.Kind != overpower.TREATYPEACE {
+		t.Errorf("treaty break %+v, want faction 1 breaking peace with 2 on turn %d", *br, turn)
+	}
+	if home.SecondaryFaction == 2 {
+		t.Error("betrayed ship landed in peace")
+	}
+}
+
+// TestTreatyEverywhere has allies meet at a planet neither held when the
+// turn began.
+func TestTreatyEverywhere(t *testing.T) {
+	send := func(s *memsource.Source, fid, dist int, pl *memsource.Planet) {
+		s.NewShip(fid, 9000+fid, 3, overpower.ANTIMATTER, s.GameItem.Turn, pl.Loc.Ring(dist)[0].PathTo(pl.Loc))
+	}
+	// one ally takes the planet a step before the other lands
+	s := makeTestGalaxy(t, 2)
+	s.AddTreaty(1, 2, overpower.TREATYALLIANCE, true)
+	target := nearestFree(s, s.HomePlanet(1).Loc)
+	send(s, 1, 1, target)
+	send(s, 2, 2, target)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if target.PrimaryFaction != 1 || target.PrimaryPresence != 3 || target.SecondaryFaction != 2 || target.SecondaryPresence != 3 {
+		t.Errorf("allies landing a step apart left %+v, want both holding 3", *target)
+	}
+	// both arrive together
+	s = makeTestGalaxy(t, 2)
+	s.AddTreaty(1, 2, overpower.TREATYALLIANCE, true)
+	target = nearestFree(s, s.HomePlanet(1).Loc)
+	send(s, 1, 1, target)
+	send(s, 2, 1, target)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if target.PrimaryPresence+target.SecondaryPresence != 6 {
+		t.Errorf("allies arriving together left %+v, want both holding 3", *target)
+	}
+}
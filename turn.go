@@ -1,14 +1,37 @@
 package overpower
 
 import (
+	"math/rand"
 	"mule/hexagon"
+	"sort"
+	"strings"
 )
 
+// RunGameTurn resolves the current turn using randomness drawn from the
+// game's seed, so the same state and orders always give the same result.
 func RunGameTurn(source Source) (logger, breaker error) {
+	return RunGameTurnRand(source, nil)
+}
+
+// RunGameTurnRand resolves the current turn drawing all randomness from rng.
+// A nil rng uses TurnRand(game.Seed(), game.Turn()).
+func RunGameTurnRand(source Source, rng *rand.Rand) (logger, breaker error) {
 	game, err := source.Game()
 	if my, bad := Check(err, "run turn resource failure"); bad {
 		return nil, my
 	}
+	if rng == nil {
+		rng = TurnRand(game.Seed(), game.Turn())
+	}
+	// --------- GAME ALREADY OVER -------- //
+	if GameOver(game) {
+		return nil, nil
+	}
+	rules := game.Rules()
+	if err := rules.Validate(); err != nil {
+		my, _ := Check(err, "run turn bad rules", "rules", rules)
+		return nil, my
+	}
 	planets, err := source.Planets()
 	if my, bad := Check(err, "run turn resource failure"); bad {
 		return nil, my
@@ -33,33 +56,75 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	if my, bad := Check(err, "run turn resource failure"); bad {
 		return nil, my
 	}
+	shipOrders, err := source.ShipOrders()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	standing, err := source.StandingOrders()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	treaties, err := source.Treaties()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	grants, err := source.VisionGrants()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	conditionals, err := source.ConditionalOrders()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	orders = append(orders, StandingLaunches(game.GID(), standing, planets, orders)...)
+	source.ArchiveOrders(game.Turn(), orders, shipOrders, dbPowerOrders, truces, treaties, grants, conditionals)
 	err = source.ClearLaunchOrders()
 	if my, bad := Check(err, "run turn resource failure"); bad {
 		return nil, my
 	}
-	//err = source.ClearPowerOrders()
-	//if my, bad := Check(err, "run turn resource failure"); bad {
-	//return nil, my
-	//}
-	// --------- GAME ALREADY OVER -------- //
-	if game.HighScore() >= game.ToWin() {
-		return nil, nil
+	err = source.ClearShipOrders()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
 	}
+	err = source.ClearPowerOrders()
+	if my, bad := Check(err, "run turn resource failure"); bad {
+		return nil, my
+	}
+	sort.Sort(sortPlanets(planets))
+	sort.Sort(sortFactions(factions))
+	sort.Sort(sortLaunchOrders(orders))
+	sort.Sort(sortShips(ships))
+	sort.Sort(sortPowerOrders(dbPowerOrders))
+	sort.Sort(sortShipOrders(shipOrders))
+	sort.Sort(sortTreaties(treaties))
+	sort.Sort(sortVisionGrants(grants))
+	sort.Sort(sortConditionalOrders(conditionals))
 	// -------------------------------- //
 	var errOccured bool
 	loggerM, _ := Check(ErrIgnorable, "run turn problem")
 	planetGrid := make(map[hexagon.Coord]PlanetDat, len(planets))
-	radar := make(map[int]hexagon.CoordList, len(factions))
+	radar := make(map[int][]Sensor, len(factions))
 
 	truceMap := map[hexagon.Coord]map[[2]int]TruceDat{}
+	hostile := map[hexagon.Coord]map[[2]int]bool{}
 	for _, tr := range truces {
 		loc := tr.Loc()
+		if tr.Hostile() {
+			if hostile[loc] == nil {
+				hostile[loc] = map[[2]int]bool{}
+			}
+			hostile[loc][[2]int{tr.FID(), tr.Trucee()}] = true
+			continue
+		}
 		if mp, ok := truceMap[loc]; ok {
 			mp[[2]int{tr.FID(), tr.Trucee()}] = tr
 		} else {
 			truceMap[loc] = map[[2]int]TruceDat{[2]int{tr.FID(), tr.Trucee()}: tr}
 		}
 	}
+	broken := map[int]int{}
+	allies := treatyTruces(treaties, planets, truceMap, hostile, broken)
+	source = grantedSource{source, visionGrants(grants, allies)}
 	var atWar []PlanetDat
 	for _, p := range planets {
 		loc := p.Loc()
@@ -74,13 +139,11 @@ func RunGameTurn(source Source) (logger, breaker error) {
 			if fid == 0 {
 				continue
 			}
-			if list, ok := radar[fid]; ok {
-				radar[fid] = append(list, loc)
-			} else {
-				radar[fid] = hexagon.CoordList{loc}
-			}
+			// this turn's sightings use the radar of the turn's start
+			radar[fid] = append(radar[fid], Sensor{loc, rules.RadarOf(p)})
 		}
 	}
+	radar = sharedRadar(radar, allies)
 	powerOrders := make([]PowerOrderDat, 0, len(dbPowerOrders))
 	for _, pO := range dbPowerOrders {
 		pl, ok := planetGrid[pO.Loc()]
@@ -111,13 +174,99 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	if !auto {
 		game.SetFreeAutos(game.FreeAutos() + 1)
 	}
-	// ---- SHIPS LAUNCH ---- //
-	var secondaryOrders []LaunchOrderDat
-	launched := map[hexagon.Coord][2]int{}
+	// ---- PLANETS GROW ---- //
+	for _, p := range planets {
+		GrowPlanet(p, rules)
+	}
+	// ---- SHIPS CHANGE COURSE ---- //
+	// redirects first, so a ship merged into keeps its new course
+	shipMap := make(map[int]ShipDat, len(ships))
+	// every SID held at the turn's start stays taken, as a merged ship's
+	// row is only removed once the turn is done
 	sidMap := make(map[int]bool, len(ships))
 	for _, sh := range ships {
+		shipMap[sh.SID()] = sh
 		sidMap[sh.SID()] = true
 	}
+	at := func(sh ShipDat) (hexagon.Coord, bool) {
+		travelled, _ := Travelled(sh, turn, rules.SpeedOf(sh.Power()))
+		if len(travelled) < 1 {
+			return hexagon.Coord{}, false
+		}
+		return travelled[0], true
+	}
+	var merges []ShipOrderDat
+	for _, o := range shipOrders {
+		if o.Merge() != 0 {
+			merges = append(merges, o)
+			continue
+		}
+		sh, ok := shipMap[o.SID()]
+		if !ok || sh.FID() != o.FID() {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ship not found", "shiporder", o)
+			continue
+		}
+		if _, ok := planetGrid[o.Target()]; !ok {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "planet not found", "shiporder", o)
+			continue
+		}
+		loc, ok := at(sh)
+		if !ok || loc == o.Target() {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ship already at target", "shiporder", o)
+			continue
+		}
+		sh.SetPath(loc.PathTo(o.Target()))
+		sh.SetLaunched(turn)
+	}
+	merged := map[int]int{}
+	for _, o := range merges {
+		sh, ok1 := shipMap[o.SID()]
+		into := o.Merge()
+		for merged[into] != 0 {
+			into = merged[into]
+		}
+		tar, ok2 := shipMap[into]
+		if !(ok1 && ok2) || sh.FID() != o.FID() || tar.FID() != o.FID() || merged[o.SID()] != 0 {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ships not found", "shiporder", o)
+			continue
+		}
+		if into == o.SID() {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ship merging into itself", "shiporder", o)
+			continue
+		}
+		loc1, ok1 := at(sh)
+		loc2, ok2 := at(tar)
+		if !(ok1 && ok2) || loc1 != loc2 {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ships not together", "shiporder", o)
+			continue
+		}
+		if sh.Power() != tar.Power() {
+			errOccured = true
+			loggerM.AddContext("bad shiporder", "ships of different powers", "shiporder", o)
+			continue
+		}
+		tar.SetSize(tar.Size() + sh.Size())
+		sh.DELETE()
+		merged[o.SID()] = into
+	}
+	if len(merged) > 0 {
+		kept := ships[:0]
+		for _, sh := range ships {
+			if merged[sh.SID()] == 0 {
+				kept = append(kept, sh)
+			}
+		}
+		ships = kept
+	}
+	// ---- SHIPS LAUNCH ---- //
+	var secondaryOrders []LaunchOrderDat
+	launched := map[hexagon.Coord][2]int{}
 
 	for _, o := range orders {
 		src, ok2 := planetGrid[o.Source()]
@@ -171,7 +320,7 @@ func RunGameTurn(source Source) (logger, breaker error) {
 		}
 		if size > 0 {
 			path := src.Loc().PathTo(tar.Loc())
-			sh := source.NewShip(src.PrimaryFaction(), GenSID(sidMap), size, turn, path)
+			sh := source.NewShip(src.PrimaryFaction(), GenSID(rng, sidMap), size, src.PrimaryPower(), turn, path)
 			ships = append(ships, sh)
 			source.NewLaunchRecord(turn, o, sh)
 			launched[o.Source()] = [2]int{lCount[0] + size, lCount[1]}
@@ -224,7 +373,7 @@ func RunGameTurn(source Source) (logger, breaker error) {
 		}
 		if size > 0 {
 			path := src.Loc().PathTo(tar.Loc())
-			sh := source.NewShip(src.SecondaryFaction(), GenSID(sidMap), size, turn, path)
+			sh := source.NewShip(src.SecondaryFaction(), GenSID(rng, sidMap), size, src.SecondaryPower(), turn, path)
 			ships = append(ships, sh)
 			source.NewLaunchRecord(turn, o, sh)
 			launched[o.Source()] = [2]int{lCount[0], lCount[1] + size}
@@ -234,31 +383,40 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	}
 	// ---- PLANETS AT WAR ---- //
 	for _, p := range atWar {
-		Battle(source, p, nil, turn, truceMap[p.Loc()])
+		Battle(source, rules, p, nil, turn, truceMap[p.Loc()])
 	}
 	// ---- SHIPS MOVE ---- //
 	// dist, ship index
 	landings := map[int][]int{}
-	for i, sh := range ships {
-		travelled, land := Travelled(sh, turn)
-		if len(travelled) < 1 {
-			errOccured = true
-			loggerM.AddContext("bad ship", "no travel dist", "ship", sh)
-			sh.DELETE()
-			continue
-		}
+	gone := make(map[int]bool, len(ships))
+	sighted := map[int][]sighting{}
+	seers := sortedFids(radar)
+	sight := NewRadarIndex(radar)
+	stealthSight := sight
+	if rules.StealthSize > 0 {
+		stealthSight = NewRadarIndex(dimmedRadar(radar, rules.StealthRange))
+	}
+	// seeShip gives every faction its view of a ship's travel this turn,
+	// noting each sighting of another faction's ship when note is set.
+	seeShip := func(sh ShipDat, travelled []hexagon.Coord, land, note bool) {
 		at := travelled[len(travelled)-1]
-		// ----- SHIP MOVEMENT IS SEEN ------ //
-		for fid, rList := range radar {
+		index := sight
+		if rules.Stealthy(sh.Size()) {
+			index = stealthSight
+		}
+		for _, fid := range seers {
 			var destValid, spottedShip bool
 			var spotted hexagon.CoordList
 			if fid == sh.FID() {
 				spotted, spottedShip = travelled, true
 				destValid = true
 			} else {
-				spotted, spottedShip = RadarCheck(rList, travelled)
+				spotted, spottedShip = index.Check(fid, travelled)
 			}
 			if len(spotted) > 0 {
+				if note && fid != sh.FID() {
+					sighted[fid] = append(sighted[fid], sighting{sh.FID(), spotted})
+				}
 				var trail []hexagon.Coord
 				var loc, dest hexagon.Coord
 				locValid := spottedShip && !land
@@ -280,6 +438,18 @@ func RunGameTurn(source Source) (logger, breaker error) {
 				source.NewShipView(sh, fid, turn, locNC, destNC, trail)
 			}
 		}
+	}
+	for i, sh := range ships {
+		travelled, land := Travelled(sh, turn, rules.SpeedOf(sh.Power()))
+		if len(travelled) < 1 {
+			errOccured = true
+			loggerM.AddContext("bad ship", "no travel dist", "ship", sh)
+			sh.DELETE()
+			gone[i] = true
+			continue
+		}
+		// ----- SHIP MOVEMENT IS SEEN ------ //
+		seeShip(sh, travelled, land, true)
 		// ---- LANDINGS TAGGED FOR LATER ------ //
 		if land {
 			dist := len(travelled) - 1
@@ -293,12 +463,15 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	//
 	// ---- SHIPS LAND ---- //
 	// plid, amount
-	for i := 1; i < SHIPSPEED+1; i++ {
+	attacked := map[hexagon.Coord]map[int]int{}
+	for i := 1; i < rules.MaxSpeed()+1; i++ {
 		shipsLandings, ok := landings[i]
 		if !ok {
 			continue
 		}
-		for _, sI := range shuffleInts(shipsLandings) {
+		var arrivals []hexagon.Coord
+		arriving := map[hexagon.Coord][]ShipDat{}
+		for _, sI := range shuffleInts(rng, shipsLandings) {
 			sh := ships[sI]
 			path := sh.Path()
 			loc := path[len(path)-1]
@@ -306,10 +479,23 @@ func RunGameTurn(source Source) (logger, breaker error) {
 			if !ok {
 				loggerM.AddContext("bad ship", "landing nonexistant", "ship", sh)
 				errOccured = true
+			} else if rules.CombatMode == SIMULTANEOUS {
+				markAttacked(attacked, p, sh.FID(), truceMap[loc])
+				if _, ok := arriving[loc]; !ok {
+					arrivals = append(arrivals, loc)
+				}
+				arriving[loc] = append(arriving[loc], sh)
 			} else {
-				Battle(source, p, sh, turn, truceMap[loc])
+				markAttacked(attacked, p, sh.FID(), truceMap[loc])
+				Battle(source, rules, p, sh, turn, truceMap[loc])
 			}
-			sh.DELETE()
+			gone[sI] = true
+		}
+		for _, loc := range arrivals {
+			BattleAll(source, rules, planetGrid[loc], arriving[loc], turn, truceMap[loc])
+		}
+		for _, sI := range shipsLandings {
+			ships[sI].DELETE()
 		}
 		delete(landings, i)
 	}
@@ -318,6 +504,72 @@ func RunGameTurn(source Source) (logger, breaker error) {
 		errOccured = true
 	}
 	//
+	// ---- TREATIES BROKEN ---- //
+	for i, tr := range treaties {
+		breaker, ok := broken[i]
+		if !ok {
+			continue
+		}
+		partner := tr.Partner()
+		if breaker == partner {
+			partner = tr.FID()
+		}
+		tr.DELETE()
+		source.NewTreatyBreak(turn, breaker, partner, tr.Kind())
+	}
+	//
+	// ---- CONDITIONAL ORDERS FIRE ---- //
+	for _, co := range conditionals {
+		cause := conditionCause(co, attacked, sighted)
+		if cause == 0 {
+			continue
+		}
+		co.DELETE()
+		fid := co.FID()
+		var size int
+		switch co.Action() {
+		case CONDLAUNCH:
+			src, ok1 := planetGrid[co.Source()]
+			tar, ok2 := planetGrid[co.Target()]
+			if !(ok1 && ok2) || src.Loc() == tar.Loc() {
+				errOccured = true
+				loggerM.AddContext("bad conditionalorder", "planets not found", "conditionalorder", co)
+				break
+			}
+			var power int
+			size, power = conditionLaunch(src, fid, co.Size(), launched)
+			if size > 0 {
+				// The ship is stamped as launched on turn+1 because ships
+				// have already moved this turn; Travelled counts legs from
+				// the launch turn, so a ship stamped turn would skip its
+				// first leg.  It is seen this turn waiting at its source,
+				// and the launch record keeps turn, like the condition
+				// record beside it, since the order fired this turn and
+				// players read it with this turn's reports.
+				path := src.Loc().PathTo(tar.Loc())
+				sh := source.NewShip(fid, GenSID(rng, sidMap), size, power, turn+1, path)
+				seeShip(sh, path[:1], false, false)
+				ships = append(ships, sh)
+				source.NewLaunchRecord(turn, co, sh)
+			} else {
+				source.NewLaunchRecord(turn, co, nil)
+			}
+		case CONDPOWER:
+			pl, ok := planetGrid[co.Target()]
+			if !ok || (co.Power() != ANTIMATTER && co.Power() != TACHYONS) {
+				errOccured = true
+				loggerM.AddContext("bad conditionalorder", "bad power target", "conditionalorder", co)
+				break
+			}
+			if pl.PrimaryFaction() == fid {
+				pl.SetPrimaryPower(co.Power())
+			} else if pl.SecondaryFaction() == fid {
+				pl.SetSecondaryPower(co.Power())
+			}
+		}
+		source.NewConditionRecord(turn, co, cause, size)
+	}
+	//
 	// ------- PLANETS CHANGE POWER -------- //
 	for _, pO := range powerOrders {
 		pLoc, upP, fid := pO.Loc(), pO.UpPower(), pO.FID()
@@ -342,19 +594,22 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	game.IncTurn()
 	turn = game.Turn()
 	facScores := make(map[int]int, len(factions))
+	facPresence := make(map[int]int, len(factions))
 	for _, pl := range planets {
 		// ---- PLANETS ARE SEEN ---- //
+		pl.SetRadar(rules.RadarOf(pl))
 		for _, cont := range []int{pl.PrimaryFaction(), pl.SecondaryFaction()} {
 			if cont == 0 {
 				continue
 			}
 			facScores[cont] += 1
+			facPresence[cont] += pl.PresenceLevel(cont)
 			source.UpdatePlanetView(cont, turn, pl)
 		}
 	}
 	var highScore int
 	toWin := game.ToWin()
-	winners := make([]FactionDat, 0)
+	var won bool
 	for _, f := range factions {
 		score := facScores[f.FID()]
 		if score > highScore {
@@ -362,12 +617,39 @@ func RunGameTurn(source Source) (logger, breaker error) {
 		}
 		f.SetScore(score)
 		if score >= toWin {
-			winners = append(winners, f)
+			won = true
 		}
 	}
 	game.SetHighScore(highScore)
-	if len(winners) > 0 {
-		Ping("TODO: WINNING!", winners)
+	// ------- GAME IS WON -------- //
+	if won {
+		facShips := make(map[int]int, len(factions))
+		for i, sh := range ships {
+			if !gone[i] {
+				facShips[sh.FID()] += sh.Size()
+			}
+		}
+		standings := make([]Standing, len(factions))
+		names := make(map[int]string, len(factions))
+		for i, f := range factions {
+			fid := f.FID()
+			names[fid] = f.Name()
+			standings[i] = Standing{
+				FID:      fid,
+				Planets:  facScores[fid],
+				Presence: facPresence[fid],
+				Ships:    facShips[fid],
+			}
+		}
+		game.SetTieBreak(RankStandings(standings))
+		var winners []string
+		for _, st := range standings {
+			if st.Rank == 1 {
+				winners = append(winners, names[st.FID])
+			}
+			source.NewStanding(st.FID, st.Rank, st.Planets, st.Presence, st.Ships)
+		}
+		game.SetWinner(strings.Join(winners, ", "))
 	}
 	if errOccured {
 		return loggerM, nil
@@ -376,9 +658,9 @@ func RunGameTurn(source Source) (logger, breaker error) {
 	}
 }
 
-func GenSID(sidMap map[int]bool) int {
+func GenSID(rng *rand.Rand, sidMap map[int]bool) int {
 	for {
-		sid := pick(10000)
+		sid := pick(rng, 10000)
 		if !sidMap[sid] {
 			sidMap[sid] = true
 			return sid
@@ -0,0 +1,218 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"reflect"
+	"testing"
+)
+
+func TestRunGameTurnLaunch(t *testing.T) {
+	s := makeRulesGalaxy(t, allMechanics(), 2, 1, "standard")
+	home := s.HomePlanet(1)
+	target := nearestFree(s, home.Loc)
+	s.AddLaunchOrder(1, 3, home.Loc, target.Loc)
+	logE, failE := overpower.RunGameTurn(s)
+	if failE != nil || logE != nil {
+		t.Fatal("run turn failed:", logE, failE)
+	}
+	if len(s.LaunchOrderList) != 0 {
+		t.Error("launch orders not cleared")
+	}
+	if len(s.ShipList) != 1 {
+		t.Fatalf("launched %d ships, want 1", len(s.ShipList))
+	}
+	if sh := s.ShipList[0]; sh.FID != 1 || sh.Size != 3 || sh.Launched != 1 {
+		t.Errorf("bad ship launched: %+v", sh)
+	}
+	if len(s.LaunchRecordList) != 1 || s.LaunchRecordList[0].Size != 3 {
+		t.Errorf("bad launch records: %+v", s.LaunchRecordList)
+	}
+	// the home grew one presence, paid from its resources, before launching
+	if res := home.Antimatter + home.Tachyons; res != 16 {
+		t.Errorf("home resources after launch: %d, want 16", res)
+	}
+	if home.PrimaryPresence != 6 {
+		t.Errorf("home presence after growth: %d, want 6", home.PrimaryPresence)
+	}
+	var seen bool
+	for _, sv := range s.ShipViewList {
+		if sv.FID == 1 && sv.SID == s.ShipList[0].SID {
+			seen = true
+		}
+	}
+	if !seen {
+		t.Error("launching faction has no view of its ship")
+	}
+	if s.GameItem.Turn != 2 {
+		t.Errorf("game on turn %d after run, want 2", s.GameItem.Turn)
+	}
+}
+
+func TestRunGameTurnLanding(t *testing.T) {
+	s := makeTestGalaxy(t, 2)
+	home := s.HomePlanet(1)
+	target := nearestFree(s, home.Loc)
+	s.AddLaunchOrder(1, 3, home.Loc, target.Loc)
+	for i := 0; i < 20; i++ {
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+		if ships, _ := s.Ships(); len(ships) == 0 {
+			break
+		}
+	}
+	if ships, _ := s.Ships(); len(ships) != 0 {
+		t.Fatal("ship never landed")
+	}
+	if target.PrimaryFaction != 1 {
+		t.Errorf("target held by %d after landing, want 1", target.PrimaryFaction)
+	}
+	var recorded bool
+	for _, br := range s.BattleRecordList {
+		if br.FID == 1 && br.Loc == target.Loc && br.ShipFaction == 1 {
+			recorded = true
+			if n := len(br.Events); n == 0 || br.Events[n-1].Kind != overpower.COMBATTAKE {
+				t.Errorf("landing record ends without the ship taking the planet: %+v", br.Events)
+			}
+		}
+	}
+	if !recorded {
+		t.Error("no battle record for landing")
+	}
+}
+
+func TestSeededGamesRepeat(t *testing.T) {
+	play := func(seed int64) *memsource.Source {
+		s := makeGalaxy(t, 4, seed, "standard")
+		for turn := 0; turn < 12; turn++ {
+			for _, f := range s.FactionList {
+				home := s.HomePlanet(f.FID)
+				if home == nil {
+					continue
+				}
+				if target := nearestFree(s, home.Loc); target != nil {
+					s.AddLaunchOrder(f.FID, 2, home.Loc, target.Loc)
+				}
+			}
+			if _, failE := overpower.RunGameTurn(s); failE != nil {
+				t.Fatal("run turn failed:", failE)
+			}
+		}
+		return s
+	}
+	a, b := play(42), play(42)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("same seed and orders gave different games")
+	}
+	if c := play(43); reflect.DeepEqual(a.PlanetList, c.PlanetList) {
+		t.Error("different seeds gave identical galaxies")
+	}
+}
+
+func TestShipOrders(t *testing.T) {
+	s := memsource.New(1)
+	s.GameItem.ToWin = 100
+	s.GameItem.Seed = 1
+	s.GameItem.Rules.ShipSpeed = 1
+	s.AddFaction("tester", "Faction")
+	s.AddFaction("tester", "Faction")
+	if err := overpower.MakeGalaxy(s, "standard"); err != nil {
+		t.Fatal("make galaxy failed:", err)
+	}
+	home := s.HomePlanet(1)
+	var far *memsource.Planet
+	for _, pl := range s.PlanetList {
+		if far == nil || home.Loc.StepsTo(pl.Loc) > home.Loc.StepsTo(far.Loc) {
+			far = pl
+		}
+	}
+	step := home.Loc.PathTo(far.Loc)[1]
+	var other *memsource.Planet
+	for _, pl := range s.PlanetList {
+		if pl != far && pl != home && home.Loc.PathTo(pl.Loc)[1] == step {
+			other = pl
+			break
+		}
+	}
+	if other == nil {
+		t.Fatal("no second planet along the first step")
+	}
+	s.AddLaunchOrder(1, 2, home.Loc, far.Loc)
+	s.AddLaunchOrder(1, 3, home.Loc, other.Loc)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if len(s.ShipList) != 2 {
+		t.Fatalf("launched %d ships, want 2", len(s.ShipList))
+	}
+	small, big := s.ShipList[0], s.ShipList[1]
+	if small.Size > big.Size {
+		small, big = big, small
+	}
+	at := step
+	s.AddMerge(1, small.SID, big.SID)
+	s.AddRedirect(1, big.SID, far.Loc)
+	s.AddMerge(2, big.SID, small.SID)
+	logE, failE := overpower.RunGameTurn(s)
+	if failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if logE == nil {
+		t.Error("order for another faction's ship was not reported")
+	}
+	if len(s.ShipOrderList) != 0 {
+		t.Error("ship orders not cleared")
+	}
+	ships, _ := s.Ships()
+	if len(ships) != 1 || ships[0].SID() != big.SID {
+		t.Fatalf("ships after merge %+v, want only %d", ships, big.SID)
+	}
+	if big.Size != 5 || big.Launched != 2 {
+		t.Errorf("merged ship size %d launched %d, want 5 and 2", big.Size, big.Launched)
+	}
+	if big.Path[0] != at || big.Path[len(big.Path)-1] != far.Loc {
+		t.Errorf("redirected ship path %v, want %v to %v", big.Path, at, far.Loc)
+	}
+	game, _ := s.Game()
+	factions, _ := s.Factions()
+	planets, _ := s.Planets()
+	r, err := memsource.Replay(game, factions, s.Archive)
+	if err != nil {
+		t.Fatal("replay failed:", err)
+	}
+	if diffs := r.Diff(planets, ships); len(diffs) != 0 {
+		t.Errorf("replay with ship orders diverged: %v", diffs)
+	}
+}
+
+func TestPowerOrders(t *testing.T) {
+	s := makeTestGalaxy(t, 2)
+	home := s.HomePlanet(1)
+	colony := nearestFree(s, home.Loc)
+	colony.PrimaryFaction, colony.PrimaryPresence, colony.PrimaryPower = 1, 3, overpower.ANTIMATTER
+	foreign := s.HomePlanet(2)
+	want := map[hexagon.Coord]int{
+		home.Loc:    -home.PrimaryPower,
+		colony.Loc:  overpower.TACHYONS,
+		foreign.Loc: foreign.PrimaryPower,
+	}
+	s.AddPowerOrder(1, want[home.Loc], home.Loc)
+	s.AddPowerOrder(1, want[colony.Loc], colony.Loc)
+	s.AddPowerOrder(1, -foreign.PrimaryPower, foreign.Loc)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	for _, pl := range []*memsource.Planet{home, colony, foreign} {
+		if pl.PrimaryPower != want[pl.Loc] {
+			t.Errorf("planet %s has power %d, want %d", pl.Name, pl.PrimaryPower, want[pl.Loc])
+		}
+	}
+	if n := len(s.PowerOrdersFor(1)); n != 0 {
+		t.Errorf("%d power orders left after the turn, want 0", n)
+	}
+	if ar := s.Archive[len(s.Archive)-1]; len(ar.PowerOrders) != 3 {
+		t.Errorf("archived %d power orders, want 3", len(ar.PowerOrders))
+	}
+}
@@ -0,0 +1,72 @@
+package overpower
+
+import (
+	"sort"
+)
+
+// Standing is a faction's final place in a finished game.
+type Standing struct {
+	FID      int
+	Rank     int
+	Planets  int
+	Presence int
+	Ships    int
+}
+
+// GameOver reports whether a game has been won.  Games finished before
+// winners were recorded only show it through their high score.
+func GameOver(g GameGet) bool {
+	return g.Winner() != "" || (g.ToWin() > 0 && g.HighScore() >= g.ToWin())
+}
+
+// RankStandings sorts standings by planets held, then total presence, then
+// ships in flight, and numbers their ranks; factions level on all three
+// share a rank.  It returns what separated first place from second:
+// "presence" or "ships" when planets were level, "shared" when nothing
+// did, and "" when planets alone decided it.
+func RankStandings(list []Standing) (tieBreak string) {
+	sort.Sort(sortStandings(list))
+	for i := range list {
+		if i > 0 && !standingLess(list[i-1], list[i]) {
+			list[i].Rank = list[i-1].Rank
+		} else {
+			list[i].Rank = i + 1
+		}
+	}
+	if len(list) < 2 {
+		return ""
+	}
+	a, b := list[0], list[1]
+	switch {
+	case a.Planets != b.Planets:
+		return ""
+	case a.Presence != b.Presence:
+		return "presence"
+	case a.Ships != b.Ships:
+		return "ships"
+	default:
+		return "shared"
+	}
+}
+
+// standingLess reports whether a places ahead of b.
+func standingLess(a, b Standing) bool {
+	if a.Planets != b.Planets {
+		return a.Planets > b.Planets
+	}
+	if a.Presence != b.Presence {
+		return a.Presence > b.Presence
+	}
+	return a.Ships > b.Ships
+}
+
+type sortStandings []Standing
+
+func (s sortStandings) Len() int      { return len(s) }
+func (s sortStandings) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortStandings) Less(i, j int) bool {
+	if standingLess(s[i], s[j]) || standingLess(s[j], s[i]) {
+		return standingLess(s[i], s[j])
+	}
+	return s[i].FID < s[j].FID
+}
@@ -0,0 +1,67 @@
+package overpower_test
+
+import (
+	"mule/overpower"
+	"testing"
+)
+
+func TestGameVictory(t *testing.T) {
+	s := makeTestGalaxy(t, 2)
+	s.GameItem.ToWin = 2
+	s.FactionList[0].Name = "Winners"
+	home := s.HomePlanet(1)
+	target := nearestFree(s, home.Loc)
+	s.AddLaunchOrder(1, 3, home.Loc, target.Loc)
+	for i := 0; i < 20 && s.GameItem.Winner == ""; i++ {
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+	}
+	if s.GameItem.Winner != "Winners" {
+		t.Fatalf("winner %q, want %q", s.GameItem.Winner, "Winners")
+	}
+	if len(s.StandingList) != 2 {
+		t.Fatalf("recorded %d standings, want 2", len(s.StandingList))
+	}
+	for _, st := range s.StandingList {
+		if st.FID == 1 && (st.Rank != 1 || st.Planets != 2) {
+			t.Errorf("bad winning standing: %+v", st)
+		}
+		if st.FID == 2 && st.Rank != 2 {
+			t.Errorf("bad losing standing: %+v", st)
+		}
+	}
+	if pl := s.PlanetAt(target.Loc); pl.PrimaryFaction != 1 || pl.SecondaryFaction != 0 || pl.SecondaryPresence != 0 {
+		t.Errorf("captured planet held as %+v, want faction 1 alone", *pl)
+	}
+	turn := s.GameItem.Turn
+	s.AddLaunchOrder(2, 1, s.HomePlanet(2).Loc, target.Loc)
+	if _, failE := overpower.RunGameTurn(s); failE != nil {
+		t.Fatal("run turn failed:", failE)
+	}
+	if ships, _ := s.Ships(); s.GameItem.Turn != turn || len(ships) != 0 || len(s.StandingList) != 2 {
+		t.Error("finished game kept running")
+	}
+}
+
+func TestRankStandings(t *testing.T) {
+	for i, test := range []struct {
+		list     []overpower.Standing
+		ranks    []int
+		tieBreak string
+	}{
+		{[]overpower.Standing{{FID: 1, Planets: 3}, {FID: 2, Planets: 5}}, []int{1, 2}, ""},
+		{[]overpower.Standing{{FID: 1, Planets: 5, Presence: 9}, {FID: 2, Planets: 5, Presence: 12}}, []int{1, 2}, "presence"},
+		{[]overpower.Standing{{FID: 1, Planets: 5, Ships: 4}, {FID: 2, Planets: 5, Ships: 3}, {FID: 3, Planets: 1}}, []int{1, 2, 3}, "ships"},
+		{[]overpower.Standing{{FID: 1, Planets: 5}, {FID: 2, Planets: 5}, {FID: 3, Planets: 2}}, []int{1, 1, 3}, "shared"},
+	} {
+		if got := overpower.RankStandings(test.list); got != test.tieBreak {
+			t.Errorf("case %d: tie break %q, want %q", i, got, test.tieBreak)
+		}
+		for j, st := range test.list {
+			if st.Rank != test.ranks[j] {
+				t.Errorf("case %d: place %d has rank %d, want %d", i, j, st.Rank, test.ranks[j])
+			}
+		}
+	}
+}
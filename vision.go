@@ -0,0 +1,40 @@
+package overpower
+
+// visionGrants adds each granter to the allies whose radar its grantees
+// join, and gives the factions each faction has granted vision to.
+func visionGrants(grants []VisionGrantDat, allies map[int][]int) map[int][]int {
+	grantees := map[int][]int{}
+	for _, vg := range grants {
+		fid, grantee := vg.FID(), vg.Grantee()
+		if fid == grantee {
+			continue
+		}
+		grantees[fid] = append(grantees[fid], grantee)
+		var joined bool
+		for _, ally := range allies[grantee] {
+			if ally == fid {
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			allies[grantee] = append(allies[grantee], fid)
+		}
+	}
+	return grantees
+}
+
+// grantedSource passes every planet view a faction gets on to the
+// factions it has granted vision to.
+type grantedSource struct {
+	Source
+	grantees map[int][]int
+}
+
+func (s grantedSource) UpdatePlanetView(fid, turn int, planet PlanetDat) PlanetViewDat {
+	pv := s.Source.UpdatePlanetView(fid, turn, planet)
+	for _, grantee := range s.grantees[fid] {
+		s.Source.UpdatePlanetView(grantee, turn, planet)
+	}
+	return pv
+}
@@ -0,0 +1,68 @@
+package overpower_test
+
+import (
+	"mule/hexagon"
+	"mule/overpower"
+	"mule/overpower/memsource"
+	"testing"
+)
+
+func TestVisionGrants(t *testing.T) {
+	launch := func(s *memsource.Source) {
+		home := s.HomePlanet(1)
+		s.AddLaunchOrder(1, 3, home.Loc, nearestFree(s, home.Loc).Loc)
+		if _, failE := overpower.RunGameTurn(s); failE != nil {
+			t.Fatal("run turn failed:", failE)
+		}
+	}
+	seen := func(s *memsource.Source, fid int) bool {
+		for _, sv := range s.ShipViewList {
+			if sv.FID == fid && sv.Controller == 1 {
+				return true
+			}
+		}
+		return false
+	}
+	viewTurn := func(s *memsource.Source, fid int, loc hexagon.Coord) int {
+		for _, pv := range s.PlanetViewList {
+			if pv.FID == fid && pv.Loc == loc {
+				return pv.Turn
+			}
+		}
+		return -1
+	}
+	s := makeTestGalaxy(t, 2)
+	launch(s)
+	if seen(s, 2) {
+		t.Fatal("faction 2 saw the launch without a grant")
+	}
+	if got := viewTurn(s, 2, s.HomePlanet(1).Loc); got == s.GameItem.Turn {
+		t.Error("faction 2's view of faction 1's home updated without a grant")
+	}
+	// a grant shares both radar and planet views
+	s = makeTestGalaxy(t, 2)
+	grant := s.AddVisionGrant(1, 2)
+	launch(s)
+	if !seen(s, 2) {
+		t.Error("grantee did not see the granter's launch")
+	}
+	if !seen(s, 1) {
+		t.Error("granter lost its own view of the launch")
+	}
+	if got, want := viewTurn(s, 2, s.HomePlanet(1).Loc), s.GameItem.Turn; got != want {
+		t.Errorf("grantee's view of the granter's home is from turn %d, want %d", got, want)
+	}
+	if got := viewTurn(s, 1, s.HomePlanet(2).Loc); got == s.GameItem.Turn {
+		t.Error("vision shared back to the granter")
+	}
+	// revoking the grant ends it from the next turn
+	grant.Deleted = true
+	s.ShipViewList = s.ShipViewList[:0]
+	launch(s)
+	if seen(s, 2) {
+		t.Error("grantee still saw the granter's launch after revoking")
+	}
+	if got := viewTurn(s, 2, s.HomePlanet(1).Loc); got == s.GameItem.Turn {
+		t.Error("grantee's view still updated after revoking")
+	}
+}